@@ -0,0 +1,189 @@
+package logtor
+
+import (
+	"sort"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// FanOutPolicy decides when LogItAll reports a message as logged.
+type FanOutPolicy int32
+
+const (
+	// FanOutAny reports a message as logged if at least one log creator recorded it. It is the default.
+	FanOutAny FanOutPolicy = iota
+	// FanOutAll reports a message as logged only if every ready log creator recorded it.
+	FanOutAll
+)
+
+// SetFanOutPolicy sets when LogItAll reports a message as logged.
+//
+// Parameters:
+//   - policy: FanOutAny or FanOutAll.
+//
+// Returns:
+//   - bool: True if the policy was set; false if it is unknown.
+func (l *Logtor) SetFanOutPolicy(policy FanOutPolicy) bool {
+	if policy != FanOutAny && policy != FanOutAll {
+		return false
+	}
+	l.fanOutPolicy.Store(int32(policy))
+	return true
+}
+
+// LogItAll logs a message with every registered log creator that is ready.
+//
+// Unlike BroadcastIt, the level is checked once against the global log level, log creators that are not
+// ready are skipped rather than stood in for by the default creator, and the outcome is a single result
+// decided by the policy set with SetFanOutPolicy. The message goes to the log creators registered when
+// it is logged; log creators removed meanwhile are only shut down once it has been logged.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: With FanOutAny, true if at least one log creator recorded the message; with FanOutAll, true
+//     if every ready log creator recorded it. False if the level is disabled or no log creator is ready.
+func (l *Logtor) LogItAll(level types.LogLevel, logMessage interface{}) bool {
+	if !l.IsLevelEnabled(level) || !l.dedup(level, logMessage) || !l.sample(level) || !l.rateLimit(level) {
+		return false
+	}
+	epoch, ok := l.beginLog()
+	if !ok {
+		return false
+	}
+	var afterEntry interface{}
+	defer l.runAfterHooks(&afterEntry)
+	defer l.endLog(epoch)
+	original := logMessage
+	logMessage, ok = l.runPreLogHooks(level, logMessage)
+	if !ok {
+		return false
+	}
+	afterEntry = logMessage
+	requireAll := FanOutPolicy(l.fanOutPolicy.Load()) == FanOutAll
+
+	var attempted, succeeded int
+	for _, logCreator := range l.logCreatorsByName() {
+		if !logCreator.IsReady() || !l.creatorAccepts(logCreator, level, original) {
+			continue
+		}
+		attempted++
+		var logged bool
+		if renderer, ok := logCreator.(RenderedLogCreator); ok {
+			logged = renderer.LogRendered(l.renderEntry(level, l.adjustedDepth(logCreator, 0), logMessage))
+		} else if adjustment := l.callDepthAdjustment.Load(); adjustment != 0 {
+			logged = logCreator.LogItWithCallDepth(level, logItCallDepth(logCreator, adjustment), logMessage)
+		} else {
+			logged = logCreator.LogIt(level, logMessage)
+		}
+		if l.countDelivered(logCreator, level, logged) {
+			succeeded++
+		}
+	}
+	if succeeded > 0 {
+		l.countRecorded(level)
+	}
+	if requireAll {
+		return attempted > 0 && succeeded == attempted
+	}
+	return succeeded > 0
+}
+
+// BroadcastIt logs a message with every registered log creator.
+//
+// Each log creator records the message if the level is enabled for it, by its own log level or the
+// global log level. A log creator that is not ready is stood in for by the default creator, for its slot
+// only, so the other log creators still receive the message. The pre-log hooks run once, and the message
+// goes to the log creators registered when it is broadcast.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - []bool: Whether each log creator recorded the message, ordered by log creator name.
+func (l *Logtor) BroadcastIt(level types.LogLevel, logMessage interface{}) []bool {
+	return l.broadcast(level, 0, logMessage)
+}
+
+// BroadcastItWithCallDepth logs a message with every registered log creator, like BroadcastIt, at the
+// specified call depth.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for calling function, or zero or less for the log creators' configured call depth.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - []bool: Whether each log creator recorded the message, ordered by log creator name.
+func (l *Logtor) BroadcastItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) []bool {
+	if callDepth > 0 {
+		// broadcast adds a frame between the caller and the log creators.
+		callDepth++
+	}
+	return l.broadcast(level, callDepth, logMessage)
+}
+
+// broadcast logs a message with every registered log creator in name order. A callDepth of zero or less
+// uses the configured call depth of each log creator.
+func (l *Logtor) broadcast(level types.LogLevel, callDepth int, logMessage interface{}) []bool {
+	epoch, ok := l.beginLog()
+	if !ok {
+		return nil
+	}
+	var afterEntry interface{}
+	defer l.runAfterHooks(&afterEntry)
+	defer l.endLog(epoch)
+	logCreators := l.logCreatorsByName()
+	results := make([]bool, len(logCreators))
+	hooked, recorded := false, false
+	original := logMessage
+	for i, logCreator := range logCreators {
+		if !l.levelEnabledFor(level, logCreator) || !l.creatorAccepts(logCreator, level, original) {
+			continue
+		}
+		if !hooked {
+			if !l.dedup(level, original) || !l.sample(level) || !l.rateLimit(level) {
+				return results
+			}
+			if logMessage, ok = l.runPreLogHooks(level, logMessage); !ok {
+				return results
+			}
+			afterEntry, hooked = logMessage, true
+		}
+		if !logCreator.IsReady() {
+			if logCreator = l.defaultCreator.Load(); logCreator == nil {
+				continue
+			}
+		}
+		if renderer, ok := logCreator.(RenderedLogCreator); ok {
+			results[i] = renderer.LogRendered(l.renderEntry(level, l.adjustedDepth(logCreator, callDepth), logMessage))
+		} else if callDepth > 0 {
+			results[i] = logCreator.LogItWithCallDepth(level, callDepth, logMessage)
+		} else if adjustment := l.callDepthAdjustment.Load(); adjustment != 0 {
+			results[i] = logCreator.LogItWithCallDepth(level, logItCallDepth(logCreator, adjustment), logMessage)
+		} else {
+			results[i] = logCreator.LogIt(level, logMessage)
+		}
+		if l.countDelivered(logCreator, level, results[i]) && !recorded {
+			l.countRecorded(level)
+			recorded = true
+		}
+	}
+	return results
+}
+
+// logCreatorsByName returns the registered log creators ordered by name. The log creators are called
+// without holding changeMutex, so a log creator logging through the Logtor does not take it again.
+func (l *Logtor) logCreatorsByName() []LogCreator {
+	l.changeMutex.RLock()
+	logCreators := make([]LogCreator, 0, len(l.logCreatorList))
+	for _, logCreator := range l.logCreatorList {
+		logCreators = append(logCreators, logCreator)
+	}
+	l.changeMutex.RUnlock()
+	sort.Slice(logCreators, func(i, j int) bool { return logCreators[i].LogName() < logCreators[j].LogName() })
+	return logCreators
+}
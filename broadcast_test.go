@@ -0,0 +1,93 @@
+package logtor_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorBroadcastIt(t *testing.T) {
+	console, file, kafka := newRecordingCreator("Console"), newRecordingCreator("File"), newRecordingCreator("Kafka")
+	fallback := newRecordingCreator("Fallback")
+	kafka.notReady = true
+	newLogtor := logtor.New().WithDefaultCreator(fallback)
+	newLogtor.AddLogCreators(console, file, kafka)
+	newLogtor.SetLogLevel(types.WARN)
+	newLogtor.SetCreatorLogLevel("File", types.TRACE)
+
+	var hookCalls int
+	newLogtor.AddPreLogHooks(func(level types.LogLevel, logMessage interface{}) interface{} {
+		hookCalls++
+		return logMessage
+	})
+
+	results := newLogtor.BroadcastIt(types.ERROR, "disk full")
+	if fmt.Sprint(results) != "[true true true true]" {
+		t.Errorf("every log creator should record the message: %v", results)
+	}
+	results = newLogtor.BroadcastItWithCallDepth(types.DEBUG, 2, "cache miss")
+	if fmt.Sprint(results) != "[false false true false]" {
+		t.Errorf("only File should record DEBUG: %v", results)
+	}
+	if hookCalls != 2 {
+		t.Errorf("hooks should run once per broadcast, got %d calls", hookCalls)
+	}
+
+	if messages := file.Messages(); fmt.Sprint(messages) != "[ERROR disk full DEBUG cache miss]" {
+		t.Errorf("unexpected File messages: %q", messages)
+	}
+	if messages := console.Messages(); fmt.Sprint(messages) != "[ERROR disk full]" {
+		t.Errorf("unexpected Console messages: %q", messages)
+	}
+	if len(kafka.Messages()) != 0 || len(fallback.Messages()) != 2 {
+		t.Errorf("the default creator should stand in for Kafka only, got %q", fallback.Messages())
+	}
+
+	newLogtor.Shutdown()
+	if results := newLogtor.BroadcastIt(types.ERROR, "after shutdown"); results != nil {
+		t.Errorf("nothing should be broadcast after Shutdown: %v", results)
+	}
+}
+
+// failingCreator is a log creator that never records a message.
+type failingCreator struct {
+	discardCreator
+}
+
+func (fc *failingCreator) LogIt(level types.LogLevel, logMessage interface{}) bool { return false }
+
+func (fc *failingCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	return false
+}
+
+func TestLogtorLogItAll(t *testing.T) {
+	console, file, offline := newRecordingCreator("Console"), newRecordingCreator("File"), newRecordingCreator("Offline")
+	offline.notReady = true
+	newLogtor := logtor.New().WithDefaultCreator(newRecordingCreator("Fallback"))
+	newLogtor.AddLogCreators(console, file, offline)
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.SetCreatorLogLevel("File", types.ERROR)
+
+	if !newLogtor.LogItAll(types.INFO, "to all") {
+		t.Error("the message should be logged")
+	}
+	if newLogtor.LogItAll(types.TRACE, "disabled") {
+		t.Error("the global log level should be checked")
+	}
+	if len(console.Messages()) != 1 || len(file.Messages()) != 1 || len(offline.Messages()) != 0 {
+		t.Errorf("every ready log creator should record the message once: %q %q %q", console.Messages(), file.Messages(), offline.Messages())
+	}
+
+	newLogtor.AddLogCreators(&failingCreator{discardCreator{name: "Failing"}})
+	if !newLogtor.LogItAll(types.INFO, "any") {
+		t.Error("FanOutAny should succeed if one log creator records the message")
+	}
+	if newLogtor.SetFanOutPolicy(logtor.FanOutPolicy(7)) || !newLogtor.SetFanOutPolicy(logtor.FanOutAll) {
+		t.Error("only known policies should be accepted")
+	}
+	if newLogtor.LogItAll(types.INFO, "all") {
+		t.Error("FanOutAll should fail if a log creator does not record the message")
+	}
+}
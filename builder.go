@@ -0,0 +1,159 @@
+package logtor
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// Builder assembles a Logtor configuration step by step and constructs it in a single Build call.
+//
+// The console, file and broker steps use the creator factories registered by the creators package,
+// so it must be imported (directly or for side effects) when they are used.
+//
+// Example:
+//
+//	l, err := logtor.NewBuilder().
+//		Console("").
+//		File("/var/log/app.log", "").
+//		Broker([]string{"127.0.0.1:9092"}, "logs", "").
+//		Level(types.INFO).
+//		DefaultTo("Console").
+//		RouteLevels(types.ERROR, types.FATAL).To("Broker").
+//		Build()
+type Builder struct {
+	config Config
+	errs   []error
+}
+
+// RouteStep is the intermediate step returned by Builder.RouteLevels.
+type RouteStep struct {
+	builder *Builder
+	levels  []types.LogLevel
+}
+
+// NewBuilder creates an empty Builder.
+//
+// Returns:
+//   - *Builder: A pointer to the newly created Builder.
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Creator declares a log creator constructed by the factory registered for typeName.
+//
+// Parameters:
+//   - name: The name the log creator is registered under.
+//   - typeName: The registered factory type name.
+//   - settings: Factory specific settings.
+func (b *Builder) Creator(name types.LogCreatorName, typeName string, settings map[string]interface{}) *Builder {
+	b.config.Creators = append(b.config.Creators, CreatorConfig{
+		Name:     name,
+		Type:     typeName,
+		Settings: settings,
+	})
+	return b
+}
+
+// Console declares a console log creator. An empty name defaults to "Console".
+func (b *Builder) Console(name types.LogCreatorName) *Builder {
+	if name == "" {
+		name = "Console"
+	}
+	return b.Creator(name, "console", nil)
+}
+
+// File declares a file log creator writing to path. An empty name defaults to "File".
+func (b *Builder) File(path string, name types.LogCreatorName) *Builder {
+	if name == "" {
+		name = "File"
+	}
+	return b.Creator(name, "file", map[string]interface{}{"file_name": path})
+}
+
+// Broker declares a Kafka broker log creator publishing to topic. An empty name defaults to "Broker".
+func (b *Builder) Broker(brokers []string, topic string, name types.LogCreatorName) *Builder {
+	if name == "" {
+		name = "Broker"
+	}
+	return b.Creator(name, "broker", map[string]interface{}{"brokers": brokers, "topic": topic})
+}
+
+// Level sets the global log level.
+func (b *Builder) Level(level types.LogLevel) *Builder {
+	if !level.IsValid() {
+		b.errs = append(b.errs, fmt.Errorf("logtor: invalid log level %q", level))
+	}
+	b.config.LogLevel = level
+	return b
+}
+
+// ActiveTo sets the initially active log creator. Defaults to the first declared creator.
+func (b *Builder) ActiveTo(name types.LogCreatorName) *Builder {
+	b.config.ActiveCreator = name
+	return b
+}
+
+// DefaultTo sets the log creator used when the active one is not ready.
+func (b *Builder) DefaultTo(name types.LogCreatorName) *Builder {
+	b.config.DefaultCreator = name
+	return b
+}
+
+// RouteLevels starts a route sending the given log levels to a specific log creator.
+// Complete it with RouteStep.To.
+func (b *Builder) RouteLevels(levels ...types.LogLevel) *RouteStep {
+	return &RouteStep{builder: b, levels: levels}
+}
+
+// To completes the route by naming the log creator the levels are sent to.
+func (rs *RouteStep) To(name types.LogCreatorName) *Builder {
+	if rs.builder.config.Routes == nil {
+		rs.builder.config.Routes = make(map[types.LogLevel]types.LogCreatorName)
+	}
+	for _, level := range rs.levels {
+		rs.builder.config.Routes[level] = name
+	}
+	return rs.builder
+}
+
+// Config returns the configuration accumulated by the Builder.
+func (b *Builder) Config() Config {
+	return b.config
+}
+
+// Build validates the accumulated steps and constructs the Logtor.
+//
+// Cross-references (active creator, default creator and routes) must name declared log creators.
+// All problems found are reported together. If constructing any log creator fails, the log creators
+// constructed so far are shut down.
+//
+// Returns:
+//   - *Logtor: The constructed Logtor.
+//   - error: An error describing every invalid step, or the construction failure.
+func (b *Builder) Build() (*Logtor, error) {
+	errs := append([]error(nil), b.errs...)
+	declared := make(map[types.LogCreatorName]struct{}, len(b.config.Creators))
+	for _, creatorConfig := range b.config.Creators {
+		declared[creatorConfig.Name] = struct{}{}
+	}
+	checkReference := func(what string, name types.LogCreatorName) {
+		if _, ok := declared[name]; !ok {
+			errs = append(errs, fmt.Errorf("logtor: %s refers to undeclared creator %q", what, name))
+		}
+	}
+	if b.config.ActiveCreator != "" {
+		checkReference("active creator", b.config.ActiveCreator)
+	}
+	if b.config.DefaultCreator != "" {
+		checkReference("default creator", b.config.DefaultCreator)
+	}
+	for level, name := range b.config.Routes {
+		checkReference(fmt.Sprintf("route %s", level), name)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return NewFromConfig(b.config)
+}
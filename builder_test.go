@@ -0,0 +1,69 @@
+package logtor_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	_ "github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestBuilderBuildsRoutedLogtor(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "temp.log")
+	newLogtor, err := logtor.NewBuilder().
+		Console("").
+		File(filename, "").
+		Level(types.INFO).
+		DefaultTo("Console").
+		RouteLevels(types.ERROR, types.FATAL).To("File").
+		Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer newLogtor.Shutdown()
+
+	if newLogtor.LogLevel() != types.INFO || newLogtor.LogCreator().LogName() != "Console" {
+		t.Errorf("unexpected level or active creator: %s %s", newLogtor.LogLevel(), newLogtor.LogCreator().LogName())
+	}
+	newLogtor.LogIt(types.INFO, "Example Console Message")
+	newLogtor.LogIt(types.ERROR, "Example Routed Message")
+	newLogtor.LogIt(types.DEBUG, "Example Filtered Message")
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "Example Routed Message") || strings.Contains(string(content), "Example Console Message") {
+		t.Errorf("unexpected file content: %s", content)
+	}
+}
+
+func TestBuilderRejectsUndeclaredReferences(t *testing.T) {
+	_, err := logtor.NewBuilder().
+		Console("").
+		DefaultTo("Missing").
+		RouteLevels(types.ERROR).To("Broker").
+		Build()
+	if err == nil {
+		t.Fatal("expected an error for undeclared creators")
+	}
+	if !strings.Contains(err.Error(), "Missing") || !strings.Contains(err.Error(), "Broker") {
+		t.Errorf("error should list every undeclared creator: %v", err)
+	}
+}
+
+func TestBuilderCleansUpOnError(t *testing.T) {
+	_, err := logtor.NewBuilder().
+		Creator("BuilderFirst", "recording", nil).
+		Creator("BuilderSecond", "recording", map[string]interface{}{"fail": true}).
+		Build()
+	if err == nil {
+		t.Fatal("expected construction error")
+	}
+	if shutdowns := recordedCreator("BuilderFirst").Shutdowns(); shutdowns != 1 {
+		t.Errorf("partially constructed creator should be shut down once, got %d", shutdowns)
+	}
+}
@@ -0,0 +1,185 @@
+package logtor
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the current time to Logtor and the log creators, so tests can freeze it.
+type Clock interface {
+	Now() time.Time
+}
+
+// TimerClock is an optional interface for clocks that can wait for a point in time, so log creators
+// acting on time boundaries, such as a FileCreator rotating by time, can be driven by a FakeClock in tests.
+type TimerClock interface {
+	Clock
+
+	// At returns a channel that receives the time of the clock once it reaches t.
+	At(t time.Time) <-chan time.Time
+}
+
+// SystemClock is the Clock backed by time.Now. It is used unless another Clock is configured.
+type SystemClock struct{}
+
+// Now returns the current local time.
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// At returns a channel that receives the current time once t is reached.
+//
+// Parameters:
+//   - t: The time to wait for.
+//
+// Returns:
+//   - <-chan time.Time: The channel receiving the time.
+func (SystemClock) At(t time.Time) <-chan time.Time {
+	return time.After(time.Until(t))
+}
+
+// NewFakeClock creates a new instance of FakeClock, which reports a fixed time until it is changed.
+//
+// Parameters:
+//   - now: The time the clock reports.
+//
+// Returns:
+//   - *FakeClock: A pointer to the newly created FakeClock.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// FakeClock is a Clock that only moves when it is set or advanced. It is safe for concurrent use.
+type FakeClock struct {
+	mutex   sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+// fakeWaiter is a channel returned by FakeClock.At, waiting for the clock to reach its deadline.
+type fakeWaiter struct {
+	deadline time.Time
+	channel  chan time.Time
+}
+
+// Now returns the time the clock is set to.
+func (fc *FakeClock) Now() time.Time {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	return fc.now
+}
+
+// Set changes the time the clock reports.
+//
+// Parameters:
+//   - now: The new time.
+func (fc *FakeClock) Set(now time.Time) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	fc.now = now
+	fc.fireWaiters()
+}
+
+// Advance moves the clock forward.
+//
+// Parameters:
+//   - d: The duration to add to the current time.
+func (fc *FakeClock) Advance(d time.Duration) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	fc.now = fc.now.Add(d)
+	fc.fireWaiters()
+}
+
+// At returns a channel that receives the time of the clock once it is set or advanced to t or later.
+// The channel receives immediately if the clock already reached t.
+//
+// Parameters:
+//   - t: The time to wait for.
+//
+// Returns:
+//   - <-chan time.Time: The channel receiving the time.
+func (fc *FakeClock) At(t time.Time) <-chan time.Time {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	channel := make(chan time.Time, 1)
+	if !fc.now.Before(t) {
+		channel <- fc.now
+		return channel
+	}
+	fc.waiters = append(fc.waiters, fakeWaiter{deadline: t, channel: channel})
+	return channel
+}
+
+// fireWaiters sends the time to the waiters whose deadline was reached. It must be called with mutex held.
+func (fc *FakeClock) fireWaiters() {
+	waiting := fc.waiters[:0]
+	for _, waiter := range fc.waiters {
+		if fc.now.Before(waiter.deadline) {
+			waiting = append(waiting, waiter)
+			continue
+		}
+		waiter.channel <- fc.now
+	}
+	fc.waiters = waiting
+}
+
+// SetClock sets the clock Logtor uses for its time windows, such as the interval of LogItEvery.
+// It does not affect the log creators, which take a clock of their own.
+//
+// Parameters:
+//   - clock: The clock to use. Nil restores the SystemClock.
+func (l *Logtor) SetClock(clock Clock) {
+	if clock == nil {
+		l.clock.Store(nil)
+		return
+	}
+	l.clock.Store(&clock)
+}
+
+// at returns a channel receiving the time once the Logtor's clock reaches t: from the clock itself if it
+// implements TimerClock, otherwise from a timer firing once the clock is expected to reach t.
+func (l *Logtor) at(t time.Time) <-chan time.Time {
+	clock := l.clock.Load()
+	if clock == nil {
+		return time.After(time.Until(t))
+	}
+	if timerClock, ok := (*clock).(TimerClock); ok {
+		return timerClock.At(t)
+	}
+	return time.After(t.Sub((*clock).Now()))
+}
+
+// schedule calls fn from a new goroutine once the Logtor's clock reaches t, unless the Logtor is shut
+// down first.
+func (l *Logtor) schedule(t time.Time, fn func()) {
+	fired, done := l.at(t), l.timers()
+	go func() {
+		select {
+		case <-fired:
+			fn()
+		case <-done:
+		}
+	}()
+}
+
+// timers returns the channel closed by ShutdownCtx to stop the goroutines started by schedule.
+func (l *Logtor) timers() <-chan struct{} {
+	l.shutdownMutex.Lock()
+	defer l.shutdownMutex.Unlock()
+	if l.timersDone == nil {
+		l.timersDone = make(chan struct{})
+		if l.shutDown.Load() {
+			close(l.timersDone)
+		}
+	}
+	return l.timersDone
+}
+
+// now returns the current time of the Logtor's clock.
+func (l *Logtor) now() time.Time {
+	if clock := l.clock.Load(); clock != nil {
+		return (*clock).Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,72 @@
+package logtor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	clock := logtor.NewFakeClock(start)
+	clock.Advance(90 * time.Second)
+	if !clock.Now().Equal(start.Add(90 * time.Second)) {
+		t.Errorf("unexpected time after Advance: %s", clock.Now())
+	}
+	clock.Set(start)
+	if !clock.Now().Equal(start) {
+		t.Errorf("unexpected time after Set: %s", clock.Now())
+	}
+}
+
+func TestFakeClockAt(t *testing.T) {
+	start := time.Date(2024, 3, 4, 5, 0, 0, 0, time.UTC)
+	clock := logtor.NewFakeClock(start)
+	if _, ok := interface{}(clock).(logtor.TimerClock); !ok {
+		t.Fatal("FakeClock should implement TimerClock")
+	}
+	select {
+	case <-clock.At(start):
+	default:
+		t.Error("a reached deadline should fire immediately")
+	}
+
+	waiting := clock.At(start.Add(time.Hour))
+	clock.Advance(59 * time.Minute)
+	select {
+	case <-waiting:
+		t.Error("the deadline should not fire early")
+	default:
+	}
+	clock.Advance(2 * time.Minute)
+	select {
+	case fired := <-waiting:
+		if !fired.Equal(start.Add(61 * time.Minute)) {
+			t.Errorf("unexpected time: %s", fired)
+		}
+	default:
+		t.Error("the deadline should fire once the clock reaches it")
+	}
+}
+
+func TestLogtorLogItEveryWithFakeClock(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+	clock := logtor.NewFakeClock(time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC))
+	newLogtor.SetClock(clock)
+
+	logged := 0
+	for minute := 0; minute < 10; minute++ {
+		if newLogtor.LogItEvery("disk", 5*time.Minute, types.WARN, "disk nearly full") {
+			logged++
+		}
+		clock.Advance(time.Minute)
+	}
+	if logged != 2 || len(recorder.Messages()) != 2 {
+		t.Errorf("expected 2 messages in 10 minutes, got %d: %v", logged, recorder.Messages())
+	}
+}
@@ -0,0 +1,343 @@
+package logtor
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// Config describes a Logtor setup that can be loaded from a JSON file.
+//
+// Fields:
+//   - LogLevel: The global log level. An empty value leaves the current level unchanged.
+//   - ActiveCreator: The name of the log creator to make active. An empty value keeps the current one.
+//   - DefaultCreator: The name of the log creator used when the active one is not ready. An empty value keeps the current one.
+//   - Routes: Log levels that are always sent to a specific log creator. They replace all existing routes.
+//   - Creators: The log creators declared by the configuration.
+type Config struct {
+	LogLevel       types.LogLevel                          `json:"log_level"`
+	ActiveCreator  types.LogCreatorName                    `json:"active_creator"`
+	DefaultCreator types.LogCreatorName                    `json:"default_creator,omitempty"`
+	Routes         map[types.LogLevel]types.LogCreatorName `json:"routes,omitempty"`
+	Creators       []CreatorConfig                         `json:"creators"`
+}
+
+// CreatorConfig declares a single log creator inside a Config.
+//
+// Fields:
+//   - Name: The name the log creator is registered under.
+//   - Type: The factory type name used to construct the log creator (see RegisterCreatorFactory).
+//   - Settings: Factory specific settings. The Name is passed to the factory under the "name" key.
+type CreatorConfig struct {
+	Name     types.LogCreatorName   `json:"name"`
+	Type     string                 `json:"type"`
+	Settings map[string]interface{} `json:"settings,omitempty"`
+}
+
+// CreatorFactory constructs a log creator from its configuration settings.
+type CreatorFactory func(settings map[string]interface{}) (LogCreator, error)
+
+var (
+	creatorFactories    = make(map[string]CreatorFactory)
+	creatorFactoryMutex sync.RWMutex
+)
+
+// RegisterCreatorFactory registers a factory that constructs log creators of the given type name.
+//
+// Parameters:
+//   - typeName: The type name referenced by CreatorConfig.Type.
+//   - factory: The function constructing the log creator.
+//
+// Returns:
+//   - error: An error if the type name is empty, the factory is nil or the type name is already registered.
+func RegisterCreatorFactory(typeName string, factory CreatorFactory) error {
+	if typeName == "" {
+		return fmt.Errorf("logtor: creator factory type name must not be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("logtor: creator factory %q must not be nil", typeName)
+	}
+	creatorFactoryMutex.Lock()
+	defer creatorFactoryMutex.Unlock()
+	if _, ok := creatorFactories[typeName]; ok {
+		return fmt.Errorf("logtor: creator factory %q is already registered", typeName)
+	}
+	creatorFactories[typeName] = factory
+	return nil
+}
+
+// BuildCreator constructs a log creator using the factory registered for the given type name.
+//
+// Parameters:
+//   - typeName: The registered factory type name.
+//   - settings: The settings passed to the factory.
+//
+// Returns:
+//   - LogCreator: The constructed log creator.
+//   - error: An error if no factory is registered for the type name or the factory fails.
+func BuildCreator(typeName string, settings map[string]interface{}) (LogCreator, error) {
+	creatorFactoryMutex.RLock()
+	factory, ok := creatorFactories[typeName]
+	creatorFactoryMutex.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("logtor: unknown creator type %q", typeName)
+	}
+	logCreator, err := factory(settings)
+	if err != nil {
+		return nil, err
+	}
+	if logCreator == nil || reflect.ValueOf(logCreator).IsNil() {
+		return nil, fmt.Errorf("logtor: creator factory %q returned nil", typeName)
+	}
+	return logCreator, nil
+}
+
+func isCreatorTypeRegistered(typeName string) bool {
+	creatorFactoryMutex.RLock()
+	defer creatorFactoryMutex.RUnlock()
+	_, ok := creatorFactories[typeName]
+	return ok
+}
+
+// LoadConfigFile reads and decodes a JSON configuration file.
+//
+// Parameters:
+//   - path: The path of the configuration file.
+//
+// Returns:
+//   - Config: The decoded configuration.
+//   - error: An error if the file cannot be read or is not valid JSON.
+func LoadConfigFile(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, err
+	}
+	return parseConfig(data)
+}
+
+func parseConfig(data []byte) (Config, error) {
+	var cfg Config
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("logtor: invalid config: %w", err)
+	}
+	return cfg, nil
+}
+
+// NewFromConfig creates a new Logtor instance and applies the given configuration to it.
+//
+// Parameters:
+//   - cfg: The configuration to apply.
+//
+// Returns:
+//   - *Logtor: The configured Logtor.
+//   - error: An error if the configuration is invalid or a log creator cannot be constructed.
+func NewFromConfig(cfg Config) (*Logtor, error) {
+	l := New()
+	if _, err := l.ApplyConfig(cfg); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// ApplyConfig applies the configuration to the Logtor instance.
+//
+// The configuration is validated and all new log creators are constructed before anything is changed,
+// so an invalid configuration never half-applies. Creators declared by a previously applied configuration
+// that are no longer declared are removed and shut down, and creators whose declaration changed are replaced.
+// Log creators added outside of a configuration are left untouched.
+//
+// Parameters:
+//   - cfg: The configuration to apply.
+//
+// Returns:
+//   - string: A description of the applied changes, empty if nothing changed.
+//   - error: An error if the configuration is invalid or a log creator cannot be constructed.
+func (l *Logtor) ApplyConfig(cfg Config) (string, error) {
+	l.configMutex.Lock()
+	defer l.configMutex.Unlock()
+
+	if err := l.validateConfig(cfg); err != nil {
+		return "", err
+	}
+
+	declared := make(map[types.LogCreatorName]CreatorConfig, len(cfg.Creators))
+	for _, creatorConfig := range cfg.Creators {
+		declared[creatorConfig.Name] = creatorConfig
+	}
+
+	var added, replaced, removed []types.LogCreatorName
+	built := make(map[types.LogCreatorName]LogCreator)
+	for _, creatorConfig := range cfg.Creators {
+		previous, ok := l.appliedCreators[creatorConfig.Name]
+		if ok && reflect.DeepEqual(previous, creatorConfig) {
+			continue
+		}
+		settings := make(map[string]interface{}, len(creatorConfig.Settings)+1)
+		for k, v := range creatorConfig.Settings {
+			settings[k] = v
+		}
+		settings["name"] = string(creatorConfig.Name)
+		logCreator, err := BuildCreator(creatorConfig.Type, settings)
+		if err != nil {
+			for _, b := range built {
+				b.Shutdown()
+			}
+			return "", fmt.Errorf("logtor: creator %q: %w", creatorConfig.Name, err)
+		}
+		built[creatorConfig.Name] = logCreator
+		if ok {
+			replaced = append(replaced, creatorConfig.Name)
+		} else {
+			added = append(added, creatorConfig.Name)
+		}
+	}
+	for name := range l.appliedCreators {
+		if _, ok := declared[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+
+	var obsolete []LogCreator
+	l.changeMutex.Lock()
+	oldActive := types.LogCreatorName("")
+	if current := l.currentLogCreator.Load(); current != nil {
+		oldActive = current.LogName()
+	}
+	for _, name := range removed {
+		if logCreator, ok := l.logCreatorList[name]; ok {
+			obsolete = append(obsolete, logCreator)
+			delete(l.logCreatorList, name)
+			delete(l.creatorLevels, name)
+			delete(l.creatorChains, name)
+		}
+	}
+	for name, logCreator := range built {
+		if old, ok := l.logCreatorList[name]; ok {
+			obsolete = append(obsolete, old)
+			delete(l.creatorChains, name)
+		}
+		l.logCreatorList[name] = logCreator
+	}
+	newActive := oldActive
+	if cfg.ActiveCreator != "" {
+		newActive = cfg.ActiveCreator
+	} else if _, ok := l.logCreatorList[oldActive]; !ok && len(cfg.Creators) > 0 {
+		newActive = cfg.Creators[0].Name
+	}
+	l.currentLogCreator.Store(l.logCreatorList[newActive])
+	if cfg.DefaultCreator != "" {
+		l.defaultCreator.Store(l.logCreatorList[cfg.DefaultCreator])
+	} else if defaultCreator := l.defaultCreator.Load(); defaultCreator != nil {
+		// A replaced default creator is followed to its new instance; a removed one is cleared.
+		for _, logCreator := range obsolete {
+			if sameCreator(defaultCreator, logCreator) {
+				l.defaultCreator.Store(l.logCreatorList[logCreator.LogName()])
+				break
+			}
+		}
+	}
+	for _, name := range removed {
+		if l.previousLogCreator == name {
+			l.previousLogCreator = ""
+		}
+	}
+	l.levelRoutes = make(map[types.LogLevel]types.LogCreatorName, len(cfg.Routes))
+	for level, name := range cfg.Routes {
+		l.levelRoutes[level] = name
+	}
+	l.rebuildRoutedCreators()
+	l.rebuildCreatorLevelRanks()
+	l.appliedCreators = declared
+	l.changeMutex.Unlock()
+
+	oldLevel := l.LogLevel()
+	if cfg.LogLevel != "" {
+		l.SetLogLevel(cfg.LogLevel)
+	}
+
+	if len(obsolete) > 0 {
+		l.waitForLogCalls()
+	}
+	for _, logCreator := range obsolete {
+		logCreator.Shutdown()
+	}
+
+	var changes []string
+	if oldLevel != l.LogLevel() {
+		changes = append(changes, fmt.Sprintf("log level %s -> %s", oldLevel, l.LogLevel()))
+	}
+	if len(added) > 0 {
+		changes = append(changes, "added "+joinCreatorNames(added))
+	}
+	if len(replaced) > 0 {
+		changes = append(changes, "replaced "+joinCreatorNames(replaced))
+	}
+	if len(removed) > 0 {
+		changes = append(changes, "removed "+joinCreatorNames(removed))
+	}
+	if oldActive != newActive {
+		changes = append(changes, fmt.Sprintf("active creator %s -> %s", oldActive, newActive))
+	}
+	return strings.Join(changes, ", "), nil
+}
+
+func (l *Logtor) validateConfig(cfg Config) error {
+	if cfg.LogLevel != "" && !cfg.LogLevel.IsValid() {
+		return fmt.Errorf("logtor: invalid log level %q", cfg.LogLevel)
+	}
+	names := make(map[types.LogCreatorName]struct{}, len(cfg.Creators))
+	for i, creatorConfig := range cfg.Creators {
+		if creatorConfig.Name == "" {
+			return fmt.Errorf("logtor: creator #%d has no name", i)
+		}
+		if _, ok := names[creatorConfig.Name]; ok {
+			return fmt.Errorf("logtor: creator %q is declared more than once", creatorConfig.Name)
+		}
+		if !isCreatorTypeRegistered(creatorConfig.Type) {
+			return fmt.Errorf("logtor: creator %q has unknown type %q", creatorConfig.Name, creatorConfig.Type)
+		}
+		names[creatorConfig.Name] = struct{}{}
+	}
+	isKnown := func(name types.LogCreatorName) bool {
+		if _, ok := names[name]; ok {
+			return true
+		}
+		l.changeMutex.RLock()
+		defer l.changeMutex.RUnlock()
+		_, registered := l.logCreatorList[name]
+		_, fromConfig := l.appliedCreators[name]
+		return registered && !fromConfig
+	}
+	if cfg.ActiveCreator != "" && !isKnown(cfg.ActiveCreator) {
+		return fmt.Errorf("logtor: active creator %q is not declared", cfg.ActiveCreator)
+	}
+	if cfg.DefaultCreator != "" && !isKnown(cfg.DefaultCreator) {
+		return fmt.Errorf("logtor: default creator %q is not declared", cfg.DefaultCreator)
+	}
+	for level, name := range cfg.Routes {
+		if !level.IsValid() || level == types.NONE {
+			return fmt.Errorf("logtor: invalid route log level %q", level)
+		}
+		if !isKnown(name) {
+			return fmt.Errorf("logtor: route %s -> %q targets a creator that is not declared", level, name)
+		}
+	}
+	return nil
+}
+
+func joinCreatorNames(names []types.LogCreatorName) string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		result = append(result, string(name))
+	}
+	sort.Strings(result)
+	return "[" + strings.Join(result, ", ") + "]"
+}
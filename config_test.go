@@ -0,0 +1,241 @@
+package logtor_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// recordingCreator is a LogCreator that keeps every logged message in memory.
+type recordingCreator struct {
+	mutex     sync.Mutex
+	name      types.LogCreatorName
+	callDepth int
+	messages  []string
+	shutdowns int
+	notReady  bool
+}
+
+func newRecordingCreator(name types.LogCreatorName) *recordingCreator {
+	return &recordingCreator{name: name, callDepth: 2}
+}
+
+func (rc *recordingCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return rc.LogItWithCallDepth(level, rc.callDepth, logMessage)
+}
+
+func (rc *recordingCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.messages = append(rc.messages, fmt.Sprintf("%s %+v", level, logMessage))
+	return true
+}
+
+func (rc *recordingCreator) LogName() types.LogCreatorName { return rc.name }
+
+func (rc *recordingCreator) SetCallDepth(callDepth int) { rc.callDepth = callDepth }
+
+func (rc *recordingCreator) CallDepth() int { return rc.callDepth }
+
+func (rc *recordingCreator) IsReady() bool {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	return !rc.notReady
+}
+
+func (rc *recordingCreator) Shutdown() {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.shutdowns++
+}
+
+func (rc *recordingCreator) Messages() []string {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	return append([]string(nil), rc.messages...)
+}
+
+func (rc *recordingCreator) Shutdowns() int {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	return rc.shutdowns
+}
+
+var (
+	recordedCreatorsMutex sync.Mutex
+	recordedCreators      = map[types.LogCreatorName]*recordingCreator{}
+)
+
+func init() {
+	err := logtor.RegisterCreatorFactory("recording", func(settings map[string]interface{}) (logtor.LogCreator, error) {
+		name, _ := settings["name"].(string)
+		if fail, _ := settings["fail"].(bool); fail {
+			return nil, fmt.Errorf("recording creator %q failed", name)
+		}
+		rc := newRecordingCreator(types.LogCreatorName(name))
+		recordedCreatorsMutex.Lock()
+		recordedCreators[rc.name] = rc
+		recordedCreatorsMutex.Unlock()
+		return rc, nil
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func recordedCreator(name types.LogCreatorName) *recordingCreator {
+	recordedCreatorsMutex.Lock()
+	defer recordedCreatorsMutex.Unlock()
+	return recordedCreators[name]
+}
+
+func writeConfig(t *testing.T, path string, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	// Make sure the change is visible even on filesystems with coarse modification times.
+	modTime := time.Now().Add(time.Duration(len(content)) * time.Second)
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestRegisterCreatorFactoryDuplicate(t *testing.T) {
+	err := logtor.RegisterCreatorFactory("recording", func(settings map[string]interface{}) (logtor.LogCreator, error) {
+		return nil, nil
+	})
+	if err == nil {
+		t.Error("duplicate factory registration should fail")
+	}
+}
+
+func TestNewFromConfigRejectsInvalidConfig(t *testing.T) {
+	configs := []logtor.Config{
+		{LogLevel: "LOUD"},
+		{Creators: []logtor.CreatorConfig{{Name: "A", Type: "missing"}}},
+		{Creators: []logtor.CreatorConfig{{Name: "A", Type: "recording"}, {Name: "A", Type: "recording"}}},
+		{ActiveCreator: "B", Creators: []logtor.CreatorConfig{{Name: "A", Type: "recording"}}},
+		{Creators: []logtor.CreatorConfig{{Name: "A", Type: "recording", Settings: map[string]interface{}{"fail": true}}}},
+	}
+	for _, cfg := range configs {
+		if _, err := logtor.NewFromConfig(cfg); err == nil {
+			t.Errorf("expected error for config %+v", cfg)
+		}
+	}
+}
+
+func TestWatchConfigReloadsOnChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logtor.json")
+	writeConfig(t, path, `{"log_level":"ERROR","creators":[{"name":"WatchA","type":"recording"}]}`)
+
+	newLogtor := logtor.New()
+	stop, err := newLogtor.WatchConfig(path, logtor.WithPollInterval(5*time.Millisecond), logtor.WithReloadDebounce(10*time.Millisecond))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	creatorA := recordedCreator("WatchA")
+	if newLogtor.LogIt(types.INFO, "filtered") {
+		t.Error("INFO should be filtered at ERROR level")
+	}
+	if !newLogtor.LogIt(types.ERROR, "first") {
+		t.Error("ERROR should be logged")
+	}
+
+	writeConfig(t, path, `{"log_level":"INFO","active_creator":"WatchB","creators":[{"name":"WatchA","type":"recording"},{"name":"WatchB","type":"recording"}]}`)
+	waitFor(t, func() bool {
+		creator := newLogtor.LogCreator()
+		return creator != nil && creator.LogName() == "WatchB" && newLogtor.LogLevel() == types.INFO
+	})
+	creatorB := recordedCreator("WatchB")
+	waitFor(t, func() bool { return len(creatorB.Messages()) > 0 })
+	if !newLogtor.LogIt(types.INFO, "second") {
+		t.Error("INFO should be logged after reload")
+	}
+
+	writeConfig(t, path, `{"log_level":"INFO","creators":[{"name":"WatchB","type":"recording"}]}`)
+	waitFor(t, func() bool { return creatorA.Shutdowns() == 1 })
+
+	writeConfig(t, path, `{"log_level":"TRACE","creators":[{"name":"WatchB","type":"recording"},{"name":"WatchC","type":"missing"}]}`)
+	waitFor(t, func() bool {
+		messages := creatorB.Messages()
+		return len(messages) > 0 && messages[len(messages)-1][:5] == "ERROR"
+	})
+	if newLogtor.LogLevel() != types.INFO {
+		t.Errorf("broken config must not be applied, got level %s", newLogtor.LogLevel())
+	}
+	if creatorA.Messages()[0] != "ERROR first" {
+		t.Errorf("unexpected messages for WatchA: %v", creatorA.Messages())
+	}
+	if creatorB.Shutdowns() != 0 {
+		t.Error("WatchB must not be shut down")
+	}
+}
+
+func TestApplyConfigUpdatesDefaultAndPreviousCreators(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	_, err := newLogtor.ApplyConfig(logtor.Config{
+		ActiveCreator:  "ApplyA",
+		DefaultCreator: "ApplyB",
+		Creators:       []logtor.CreatorConfig{{Name: "ApplyA", Type: "recording"}, {Name: "ApplyB", Type: "recording"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldB := recordedCreator("ApplyB")
+	if !newLogtor.ChangeLogCreator("ApplyB") {
+		t.Fatal("ApplyB should become active")
+	}
+
+	_, err = newLogtor.ApplyConfig(logtor.Config{
+		ActiveCreator: "ApplyC",
+		Creators: []logtor.CreatorConfig{
+			{Name: "ApplyB", Type: "recording", Settings: map[string]interface{}{"version": 2}},
+			{Name: "ApplyC", Type: "recording"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := newLogtor.RevertLogCreator(); err != logtor.ErrNoPreviousLogCreator {
+		t.Errorf("the removed creator should not be reverted to, got %v", err)
+	}
+	newB, creatorC := recordedCreator("ApplyB"), recordedCreator("ApplyC")
+	creatorC.mutex.Lock()
+	creatorC.notReady = true
+	creatorC.mutex.Unlock()
+	if result := newLogtor.LogItAttempt(types.INFO, "fallback"); !result.FallbackUsed || result.CreatorUsed != "ApplyB" {
+		t.Errorf("the replaced default creator should be used: %+v", result)
+	}
+	if len(newB.Messages()) != 1 || len(oldB.Messages()) != 0 || oldB.Shutdowns() != 1 {
+		t.Errorf("the new default instance should log: new=%v old=%v", newB.Messages(), oldB.Messages())
+	}
+
+	_, err = newLogtor.ApplyConfig(logtor.Config{Creators: []logtor.CreatorConfig{{Name: "ApplyC", Type: "recording"}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result := newLogtor.LogItAttempt(types.INFO, "dropped"); result.Logged || len(newB.Messages()) != 1 {
+		t.Errorf("the removed default creator should be cleared: %+v", result)
+	}
+}
@@ -0,0 +1,178 @@
+package logtor
+
+import (
+	"context"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// contextKey is a context key registered with RegisterContextKey and the field it is logged as.
+type contextKey struct {
+	key       interface{}
+	fieldName string
+}
+
+// RegisterContextKey registers a context key whose value is logged as a field by LogItContext.
+//
+// Registering a key again replaces its field name. The registered keys are read lock-free by the
+// logging path, so keys can be registered while other goroutines log.
+//
+// Parameters:
+//   - key: The key of the value in the context, as passed to context.WithValue.
+//   - fieldName: The name of the field the value is logged as.
+func (l *Logtor) RegisterContextKey(key interface{}, fieldName string) {
+	if key == nil || fieldName == "" {
+		return
+	}
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	var keys []contextKey
+	if current := l.contextKeys.Load(); current != nil {
+		keys = make([]contextKey, 0, len(*current)+1)
+		for _, registered := range *current {
+			if registered.key != key {
+				keys = append(keys, registered)
+			}
+		}
+	}
+	keys = append(keys, contextKey{key: key, fieldName: fieldName})
+	l.contextKeys.Store(&keys)
+}
+
+// LogItContext logs a message with the values of the registered context keys as fields.
+//
+// The message is logged as a types.LogEntry attributed to the caller of LogItContext, carrying a field
+// for every key registered with RegisterContextKey that has a value in the context. Fields of a
+// types.LogEntry or ContextualMessage passed as the message take precedence over context fields with the
+// same name. The entry then passes through the global fields and the pre-log hooks like LogEntry.
+//
+// Parameters:
+//   - ctx: The context carrying request-scoped values, such as request or trace IDs. It may be nil.
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged; false if it was skipped due to the log level
+//     or the Logtor has been shut down.
+func (l *Logtor) LogItContext(ctx context.Context, level types.LogLevel, logMessage interface{}) bool {
+	return l.logItContext(ctx, level, 2, logMessage)
+}
+
+// LogItContextWithCallDepth logs a message like LogItContext, attributing it to the caller at the given
+// call depth.
+//
+// Parameters:
+//   - ctx: The context carrying request-scoped values, such as request or trace IDs. It may be nil.
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for calling function, as for LogItWithCallDepth, or zero or less for the caller of LogItContextWithCallDepth.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged; false if it was skipped due to the log level
+//     or the Logtor has been shut down.
+func (l *Logtor) LogItContextWithCallDepth(ctx context.Context, level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	skip := 2
+	if callDepth > 0 {
+		// A log creator resolves runtime.Caller(callDepth - 1) one frame below the Logtor method.
+		skip = callDepth - 1
+	}
+	return l.logItContext(ctx, level, skip, logMessage)
+}
+
+// logItContext logs a message as a types.LogEntry carrying the context fields, attributing it to the
+// caller found by runtime.Caller(skip) from logItContext.
+func (l *Logtor) logItContext(ctx context.Context, level types.LogLevel, skip int, logMessage interface{}) bool {
+	logCreator := l.creatorFor(level)
+	if !l.levelEnabledFor(level, logCreator) {
+		l.countFiltered(level)
+		return false
+	}
+	if !l.creatorAccepts(logCreator, level, logMessage) || !l.dedup(level, logMessage) || !l.sample(level) || !l.rateLimit(level) {
+		return false
+	}
+	entry := l.contextEntry(ctx, level, logMessage)
+	if _, file, line, ok := runtime.Caller(skip); ok {
+		entry.Caller, entry.Line = file, line
+	}
+	call, err := l.startEntryCall(logCreator, entry)
+	if err != nil {
+		return false
+	}
+	defer call.end()
+	return call.fallBack(0, true, 1, call.dispatch(0, true, 1)) == nil
+}
+
+// contextEntry builds the entry logged by LogItContext, with the context fields merged under the fields
+// of the message.
+func (l *Logtor) contextEntry(ctx context.Context, level types.LogLevel, logMessage interface{}) types.LogEntry {
+	var entry types.LogEntry
+	switch typed := logMessage.(type) {
+	case types.LogEntry:
+		entry = typed
+	case ContextualMessage:
+		entry = types.LogEntry{Message: typed.Message, Fields: typed.Fields}
+	default:
+		entry = types.LogEntry{Message: logMessage}
+	}
+	entry.Level = level
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = l.now()
+	}
+	if fields := l.contextFields(ctx); fields != nil {
+		entry.Fields = withGlobalFields(fields, entry.Fields)
+	}
+	return entry
+}
+
+// contextFields returns the values of the registered context keys found in the context, or nil if
+// there are none.
+func (l *Logtor) contextFields(ctx context.Context) map[string]interface{} {
+	keys := l.contextKeys.Load()
+	if ctx == nil || keys == nil {
+		return nil
+	}
+	var fields map[string]interface{}
+	for _, registered := range *keys {
+		value := ctx.Value(registered.key)
+		if value == nil {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]interface{}, len(*keys))
+		}
+		fields[registered.fieldName] = value
+	}
+	return fields
+}
+
+// logEntryWith passes a structured entry to a log creator, using the most specific interface it
+// implements.
+func logEntryWith(logCreator LogCreator, entry types.LogEntry) bool {
+	if entryCreator, ok := logCreator.(EntryLogCreator); ok {
+		return entryCreator.LogItEntry(withStack(entry))
+	}
+	if creatorV2, ok := logCreator.(LogCreatorV2); ok {
+		return creatorV2.LogEntry(entry)
+	}
+	return logCreator.LogIt(entry.Level, entry)
+}
+
+// withStack returns a PANIC entry with the stack of the calling goroutine in the "stack" field, unless
+// the entry already has one. Entries at other levels are returned as they are.
+func withStack(entry types.LogEntry) types.LogEntry {
+	if entry.Level != types.PANIC {
+		return entry
+	}
+	if _, ok := entry.Fields["stack"]; ok {
+		return entry
+	}
+	fields := make(map[string]interface{}, len(entry.Fields)+1)
+	for key, value := range entry.Fields {
+		fields[key] = value
+	}
+	fields["stack"] = string(debug.Stack())
+	entry.Fields = fields
+	return entry
+}
@@ -0,0 +1,93 @@
+package logtor_test
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+type requestIDKey struct{}
+
+type traceIDKey struct{}
+
+// entryCreator records the entries passed to LogItEntry.
+type entryCreator struct {
+	discardCreator
+	entries []types.LogEntry
+}
+
+func (ec *entryCreator) LogItEntry(entry types.LogEntry) bool {
+	ec.entries = append(ec.entries, entry)
+	return true
+}
+
+func TestLogtorLogItContext(t *testing.T) {
+	entries := &entryCreator{discardCreator: discardCreator{name: "Entries"}}
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(entries)
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.RegisterContextKey(requestIDKey{}, "request_id")
+	newLogtor.RegisterContextKey(traceIDKey{}, "trace")
+	newLogtor.RegisterContextKey(traceIDKey{}, "trace_id")
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	ctx = context.WithValue(ctx, traceIDKey{}, "trace-9")
+	_, _, line, _ := runtime.Caller(0)
+	if !newLogtor.LogItContext(ctx, types.WARN, "slow query") {
+		t.Fatal("message not logged")
+	}
+	if newLogtor.LogItContext(ctx, types.TRACE, "disabled") {
+		t.Error("the level should be checked")
+	}
+	newLogtor.LogItContext(ctx, types.ERROR, types.LogEntry{Message: "override", Fields: map[string]interface{}{"request_id": "req-2"}})
+	newLogtor.LogItContext(nil, types.INFO, "without context")
+
+	if len(entries.entries) != 3 {
+		t.Fatalf("unexpected entries: %+v", entries.entries)
+	}
+	first := entries.entries[0]
+	if first.Level != types.WARN || first.Message != "slow query" || first.Timestamp.IsZero() ||
+		first.Fields["request_id"] != "req-1" || first.Fields["trace_id"] != "trace-9" || len(first.Fields) != 2 {
+		t.Errorf("the context values should be logged as fields: %+v", first)
+	}
+	if !strings.HasSuffix(first.Caller, "context_test.go") || first.Line != line+1 {
+		t.Errorf("the entry should be attributed to the caller, got %s:%d", first.Caller, first.Line)
+	}
+	if entries.entries[1].Fields["request_id"] != "req-2" || entries.entries[1].Fields["trace_id"] != "trace-9" {
+		t.Errorf("fields of the message should take precedence: %+v", entries.entries[1])
+	}
+	if entries.entries[2].Fields != nil {
+		t.Errorf("a nil context should add no fields: %+v", entries.entries[2])
+	}
+}
+
+// logFromHelper logs through LogItContextWithCallDepth on behalf of its caller.
+func logFromHelper(l *logtor.Logtor, ctx context.Context, message string) bool {
+	return l.LogItContextWithCallDepth(ctx, types.INFO, 4, message)
+}
+
+func TestLogtorLogItContextFallsBackToLogIt(t *testing.T) {
+	testCreator := logtor.NewTestCreator("Test")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(testCreator)
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.RegisterContextKey(requestIDKey{}, "request_id")
+
+	ctx := context.WithValue(context.Background(), requestIDKey{}, "req-1")
+	_, _, line, _ := runtime.Caller(0)
+	if !logFromHelper(newLogtor, ctx, "from helper") {
+		t.Fatal("message not logged")
+	}
+
+	captured := newLogtor.Snapshot()
+	if len(captured) != 1 || captured[0].Message != "from helper" || captured[0].Fields["request_id"] != "req-1" {
+		t.Fatalf("log creators without LogItEntry should receive the entry through LogIt: %+v", captured)
+	}
+	if !strings.HasSuffix(captured[0].Caller, "context_test.go") || captured[0].Line != line+1 {
+		t.Errorf("the call depth should attribute the entry to the helper's caller, got %s:%d", captured[0].Caller, captured[0].Line)
+	}
+}
@@ -0,0 +1,183 @@
+package logtor
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// ContextualLogtor logs through a Logtor while attaching a fixed set of fields to every message.
+//
+// It shares the creators and log level of its Logtor, so changing them on the Logtor affects the
+// ContextualLogtor as well. A ContextualLogtor is immutable and safe for concurrent use.
+type ContextualLogtor struct {
+	logtor *Logtor
+	fields map[string]interface{}
+}
+
+// ContextualMessage is the message passed to the log creators by a ContextualLogtor.
+//
+// Text based creators render it as the message followed by sorted key=value pairs, and JSON based
+// creators receive the fields next to the message under the "message" key.
+type ContextualMessage struct {
+	Message interface{}
+	Fields  map[string]interface{}
+}
+
+// String renders the message followed by its fields as sorted key=value pairs.
+func (cm ContextualMessage) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%+v", cm.Message)
+	for _, key := range sortedFieldKeys(cm.Fields) {
+		fmt.Fprintf(&builder, " %s=%+v", key, cm.Fields[key])
+	}
+	return builder.String()
+}
+
+// MarshalJSON renders the fields and the message as a single JSON object.
+func (cm ContextualMessage) MarshalJSON() ([]byte, error) {
+	object := make(map[string]interface{}, len(cm.Fields)+1)
+	for key, value := range cm.Fields {
+		object[key] = value
+	}
+	object["message"] = cm.Message
+	return json.Marshal(object)
+}
+
+func sortedFieldKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// NewContextualLogtor creates a ContextualLogtor attaching the given fields to every message.
+//
+// Parameters:
+//   - fields: The fields attached to every message. The map is copied.
+//
+// Returns:
+//   - *ContextualLogtor: A pointer to the newly created ContextualLogtor.
+func (l *Logtor) NewContextualLogtor(fields map[string]interface{}) *ContextualLogtor {
+	copied := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		copied[key] = value
+	}
+	return &ContextualLogtor{logtor: l, fields: copied}
+}
+
+// WithFields returns a scoped logger attaching the given fields to every message, like NewContextualLogtor.
+//
+// The scoped logger shares the creators and log level of the Logtor. BaseCreator and FileCreator render
+// the fields as key=value pairs after the message, and BrokerCreator sends them as the "fields" object
+// of the BrokerMessage.
+//
+// Parameters:
+//   - fields: The fields attached to every message. The map is copied.
+//
+// Returns:
+//   - *ContextualLogtor: A pointer to the scoped logger.
+func (l *Logtor) WithFields(fields map[string]interface{}) *ContextualLogtor {
+	return l.NewContextualLogtor(fields)
+}
+
+// NewRequestLogger creates a ContextualLogtor carrying the standard fields of an HTTP request.
+//
+// The fields are "method", "path" and "remote_addr", plus "request_id" when the request has an
+// X-Request-ID header.
+//
+// Parameters:
+//   - r: The incoming HTTP request.
+//
+// Returns:
+//   - *ContextualLogtor: A pointer to the newly created ContextualLogtor.
+func (l *Logtor) NewRequestLogger(r *http.Request) *ContextualLogtor {
+	fields := map[string]interface{}{
+		"method":      r.Method,
+		"path":        r.URL.Path,
+		"remote_addr": r.RemoteAddr,
+	}
+	if requestID := r.Header.Get("X-Request-ID"); requestID != "" {
+		fields["request_id"] = requestID
+	}
+	return &ContextualLogtor{logtor: l, fields: fields}
+}
+
+// With returns a new ContextualLogtor with an additional field. An existing field with the same key is replaced.
+//
+// Parameters:
+//   - key: The field name.
+//   - value: The field value.
+//
+// Returns:
+//   - *ContextualLogtor: A pointer to the new ContextualLogtor.
+func (cl *ContextualLogtor) With(key string, value interface{}) *ContextualLogtor {
+	fields := make(map[string]interface{}, len(cl.fields)+1)
+	for k, v := range cl.fields {
+		fields[k] = v
+	}
+	fields[key] = value
+	return &ContextualLogtor{logtor: cl.logtor, fields: fields}
+}
+
+// WithFields returns a new ContextualLogtor with additional fields. Fields with the same keys as attached
+// ones replace them, so nested scopes override the values of their parents.
+//
+// Parameters:
+//   - fields: The fields to add. The map is copied.
+//
+// Returns:
+//   - *ContextualLogtor: A pointer to the new ContextualLogtor.
+func (cl *ContextualLogtor) WithFields(fields map[string]interface{}) *ContextualLogtor {
+	merged := make(map[string]interface{}, len(cl.fields)+len(fields))
+	for key, value := range cl.fields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return &ContextualLogtor{logtor: cl.logtor, fields: merged}
+}
+
+// Fields returns a copy of the fields attached to every message.
+//
+// Returns:
+//   - map[string]interface{}: The attached fields.
+func (cl *ContextualLogtor) Fields() map[string]interface{} {
+	fields := make(map[string]interface{}, len(cl.fields))
+	for key, value := range cl.fields {
+		fields[key] = value
+	}
+	return fields
+}
+
+// LogIt logs a message together with the attached fields.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was logged successfully, false otherwise.
+func (cl *ContextualLogtor) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return cl.logtor.LogIt(level, ContextualMessage{Message: logMessage, Fields: cl.fields})
+}
+
+// LogItWithCallDepth logs a message together with the attached fields using a custom call depth.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for the log message.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was logged successfully, false otherwise.
+func (cl *ContextualLogtor) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	return cl.logtor.LogItWithCallDepth(level, callDepth, ContextualMessage{Message: logMessage, Fields: cl.fields})
+}
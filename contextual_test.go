@@ -0,0 +1,83 @@
+package logtor_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestNewRequestLogger(t *testing.T) {
+	rc := newRecordingCreator("Recording")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(rc)
+	newLogtor.SetLogLevel(types.TRACE)
+
+	req := httptest.NewRequest("GET", "/orders/42?expand=items", nil)
+	req.RemoteAddr = "10.0.0.1:5123"
+	req.Header.Set("X-Request-ID", "abc123")
+
+	requestLogger := newLogtor.NewRequestLogger(req)
+	if !requestLogger.LogIt(types.INFO, "order loaded") {
+		t.Fatal("Log not recorded")
+	}
+	requestLogger.With("user_id", 7).LogIt(types.ERROR, "order failed")
+
+	messages := rc.Messages()
+	expected := []string{
+		"INFO order loaded method=GET path=/orders/42 remote_addr=10.0.0.1:5123 request_id=abc123",
+		"ERROR order failed method=GET path=/orders/42 remote_addr=10.0.0.1:5123 request_id=abc123 user_id=7",
+	}
+	if len(messages) != len(expected) || messages[0] != expected[0] || messages[1] != expected[1] {
+		t.Errorf("unexpected messages: %q", messages)
+	}
+	if _, ok := requestLogger.Fields()["user_id"]; ok {
+		t.Error("With must not modify the parent logger")
+	}
+
+	req = httptest.NewRequest("POST", "/orders", nil)
+	if _, ok := newLogtor.NewRequestLogger(req).Fields()["request_id"]; ok {
+		t.Error("request_id must be omitted without an X-Request-ID header")
+	}
+}
+
+func TestContextualMessageJSON(t *testing.T) {
+	message := logtor.ContextualMessage{Message: "order loaded", Fields: map[string]interface{}{"method": "GET"}}
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"message":"order loaded","method":"GET"}` {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
+
+func TestLogtorWithFields(t *testing.T) {
+	rc := newRecordingCreator("Recording")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(rc)
+	newLogtor.SetLogLevel(types.INFO)
+
+	fields := map[string]interface{}{"request_id": "r-1", "user_id": 7}
+	scoped := newLogtor.WithFields(fields)
+	fields["user_id"] = 8
+	nested := scoped.WithFields(map[string]interface{}{"user_id": 9, "step": "charge"})
+
+	scoped.LogIt(types.INFO, "started")
+	nested.LogIt(types.INFO, "charging")
+	newLogtor.SetLogLevel(types.ERROR)
+	nested.LogIt(types.INFO, "filtered by the parent level")
+
+	expected := []string{
+		"INFO started request_id=r-1 user_id=7",
+		"INFO charging request_id=r-1 step=charge user_id=9",
+	}
+	if messages := rc.Messages(); len(messages) != len(expected) || messages[0] != expected[0] || messages[1] != expected[1] {
+		t.Errorf("unexpected messages: %q", messages)
+	}
+	if _, ok := scoped.Fields()["step"]; ok {
+		t.Error("WithFields must not modify the parent logger")
+	}
+}
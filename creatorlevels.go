@@ -0,0 +1,119 @@
+package logtor
+
+import (
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// SetCreatorLogLevel sets the log level of a single log creator, overriding the global log level for
+// the messages passed to it.
+//
+// The override can be more or less verbose than the global log level: with a global level of WARN and
+// a TRACE override for "File", every message is recorded while "File" is active, but only warnings and
+// above while another log creator is active. The log level of the log creator a message is addressed to
+// applies, also when the default creator stands in for it because it is not ready.
+//
+// The levels are kept apart from the log creators themselves, guarded by the same lock as the list of
+// log creators, and published to the logging path as a snapshot, so log calls never wait for a change.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator.
+//   - level: The log level for the log creator.
+//
+// Returns:
+//   - bool: True if the log level was set; false if the level is invalid or the log creator does not exist.
+func (l *Logtor) SetCreatorLogLevel(logCreatorName types.LogCreatorName, level types.LogLevel) bool {
+	if !level.IsValid() {
+		return false
+	}
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	if _, ok := l.logCreatorList[logCreatorName]; !ok {
+		return false
+	}
+	if l.creatorLevels == nil {
+		l.creatorLevels = make(map[types.LogCreatorName]types.LogLevel)
+	}
+	l.creatorLevels[logCreatorName] = level
+	l.rebuildCreatorLevelRanks()
+	return true
+}
+
+// ClearCreatorLogLevel removes the log level set for a single log creator, so the global log level
+// applies to it again.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator.
+func (l *Logtor) ClearCreatorLogLevel(logCreatorName types.LogCreatorName) {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	if _, ok := l.creatorLevels[logCreatorName]; !ok {
+		return
+	}
+	delete(l.creatorLevels, logCreatorName)
+	l.rebuildCreatorLevelRanks()
+}
+
+// GetCreatorLogLevel returns the log level set for a single log creator with SetCreatorLogLevel.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator.
+//
+// Returns:
+//   - types.LogLevel: The log level of the log creator.
+//   - bool: False if no log level is set for the log creator, in which case the global log level applies.
+func (l *Logtor) GetCreatorLogLevel(logCreatorName types.LogCreatorName) (types.LogLevel, bool) {
+	l.changeMutex.RLock()
+	defer l.changeMutex.RUnlock()
+	level, ok := l.creatorLevels[logCreatorName]
+	return level, ok
+}
+
+// rebuildCreatorLevelRanks publishes the ranks of creatorLevels for the logging path.
+// It must be called with changeMutex held whenever creatorLevels changes.
+func (l *Logtor) rebuildCreatorLevelRanks() {
+	if len(l.creatorLevels) == 0 {
+		l.creatorLevelRanks.Store(nil)
+		return
+	}
+	ranks := make(map[types.LogCreatorName]int32, len(l.creatorLevels))
+	for name, level := range l.creatorLevels {
+		ranks[name] = levelRank(level)
+	}
+	l.creatorLevelRanks.Store(&ranks)
+}
+
+// levelEnabledFor reports whether a message at the given level is recorded by the log creator, using
+// the log creator's own log level if one is set, then the level of the caller's namespace if namespace
+// levels are set, and the global log level otherwise. It does not take changeMutex.
+func (l *Logtor) levelEnabledFor(level types.LogLevel, logCreator LogCreator) bool {
+	if selected, ok := l.creatorRank(logCreator); ok {
+		return rankEnabled(level, selected)
+	}
+	if namespaces := l.namespaceRanks.Load(); namespaces != nil {
+		// Skips runtime.Callers, callerRank, levelEnabledFor and the Logtor method calling it.
+		if selected, ok := namespaces.callerRank(4); ok {
+			return rankEnabled(level, selected)
+		}
+	}
+	return l.IsLevelEnabled(level)
+}
+
+// creatorRank returns the rank of the log level set for the log creator with SetCreatorLogLevel.
+func (l *Logtor) creatorRank(logCreator LogCreator) (int32, bool) {
+	if ranks := l.creatorLevelRanks.Load(); ranks != nil && logCreator != nil {
+		selected, ok := (*ranks)[logCreator.LogName()]
+		return selected, ok
+	}
+	return 0, false
+}
+
+// rankEnabled reports whether a message at the given level is recorded at the selected rank.
+func rankEnabled(level types.LogLevel, selected int32) bool {
+	rank := levelRank(level)
+	return rank > 0 && rank <= selected
+}
+
+// isLoggable reports whether LogIt would pass a message at the given level on to a log creator.
+func (l *Logtor) isLoggable(level types.LogLevel) bool {
+	return l.levelEnabledFor(level, l.creatorFor(level))
+}
@@ -0,0 +1,129 @@
+package logtor_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorCreatorLogLevel(t *testing.T) {
+	console, file := newRecordingCreator("Console"), newRecordingCreator("File")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(console, file)
+	newLogtor.SetLogLevel(types.WARN)
+
+	if newLogtor.SetCreatorLogLevel("Missing", types.TRACE) || newLogtor.SetCreatorLogLevel("File", "LOUD") {
+		t.Error("unknown creators and invalid levels should be rejected")
+	}
+	if !newLogtor.SetCreatorLogLevel("File", types.TRACE) {
+		t.Fatal("level not set")
+	}
+	if _, ok := newLogtor.GetCreatorLogLevel("Console"); ok {
+		t.Error("Console should use the global level")
+	}
+
+	levels := []types.LogLevel{types.FATAL, types.ERROR, types.WARN, types.DEBUG, types.INFO, types.TRACE}
+	for _, level := range levels {
+		newLogtor.LogIt(level, "console")
+	}
+	newLogtor.ChangeLogCreator("File")
+	for _, level := range levels {
+		newLogtor.LogIt(level, "file")
+	}
+	if len(console.Messages()) != 3 {
+		t.Errorf("Console should only receive WARN and above: %v", console.Messages())
+	}
+	if len(file.Messages()) != 6 {
+		t.Errorf("File should receive every level: %v", file.Messages())
+	}
+
+	newLogtor.SetCreatorLogLevel("File", types.NONE)
+	if newLogtor.LogIt(types.FATAL, "dropped") || !newLogtor.LogItAttempt(types.FATAL, "dropped").FilteredByLevel {
+		t.Error("a NONE override should drop every message")
+	}
+}
+
+func TestLogtorClearCreatorLogLevel(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+	newLogtor.SetCreatorLogLevel("Recorder", types.TRACE)
+
+	newLogtor.ClearCreatorLogLevel("Recorder")
+	newLogtor.ClearCreatorLogLevel("Missing")
+	if _, ok := newLogtor.GetCreatorLogLevel("Recorder"); ok {
+		t.Error("the log level should be cleared")
+	}
+	if newLogtor.LogIt(types.INFO, "dropped") || !newLogtor.LogIt(types.WARN, "recorded") {
+		t.Error("the global log level should apply again")
+	}
+}
+
+func TestLogtorCreatorLogLevelConcurrently(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				newLogtor.SetCreatorLogLevel("Recorder", types.TRACE)
+				newLogtor.GetCreatorLogLevel("Recorder")
+				newLogtor.ClearCreatorLogLevel("Recorder")
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				newLogtor.LogIt(types.ERROR, "always")
+				newLogtor.LogIt(types.TRACE, "sometimes")
+			}
+		}()
+	}
+	wg.Wait()
+
+	always := 0
+	for _, message := range recorder.Messages() {
+		if message == "ERROR always" {
+			always++
+		}
+	}
+	if always != 800 {
+		t.Errorf("messages enabled at every level should all be recorded, got %d", always)
+	}
+}
+
+func TestLogtorCreatorLogLevelsWithBroadcast(t *testing.T) {
+	console, kafka := newRecordingCreator("Console"), newRecordingCreator("Kafka")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(console, kafka)
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.SetCreatorLogLevel("Console", types.WARN)
+	newLogtor.SetCreatorLogLevel("Kafka", types.TRACE)
+
+	for _, level := range []types.LogLevel{types.FATAL, types.ERROR, types.WARN, types.DEBUG, types.INFO, types.TRACE} {
+		newLogtor.BroadcastIt(level, "message")
+	}
+	if messages := console.Messages(); fmt.Sprint(messages) != "[FATAL message ERROR message WARN message]" {
+		t.Errorf("unexpected Console messages: %q", messages)
+	}
+	if messages := kafka.Messages(); len(messages) != 6 {
+		t.Errorf("Kafka should receive every level: %q", messages)
+	}
+
+	if err := newLogtor.RemoveLogCreator("Kafka"); err != nil {
+		t.Fatal(err)
+	}
+	newLogtor.AddLogCreators(newRecordingCreator("Kafka"))
+	if level, ok := newLogtor.GetCreatorLogLevel("Kafka"); ok {
+		t.Errorf("a removed log creator's level should not carry over, got %s", level)
+	}
+}
@@ -0,0 +1,114 @@
+package creators
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// adaptiveFlushSmoothing is the weight of the latest window in the smoothed arrival rate.
+const adaptiveFlushSmoothing = 0.5
+
+// NewAdaptiveFlush creates a new instance of AdaptiveFlush, which derives a flush interval from the recent
+// arrival rate of log entries.
+//
+// The effective interval is the time it takes to collect targetBatch entries at the smoothed arrival rate,
+// bounded by minInterval and maxInterval. Bursts therefore flush early, before batches grow oversized,
+// while quiet periods stretch the interval instead of uploading a handful of entries at a time.
+//
+// Parameters:
+//   - minInterval: The shortest effective flush interval.
+//   - maxInterval: The longest effective flush interval, used until entries arrive.
+//   - targetBatch: The number of entries a flush should carry.
+//
+// Returns:
+//   - *AdaptiveFlush: A pointer to the newly created AdaptiveFlush.
+//   - error: An error if the bounds or the target batch size are invalid.
+func NewAdaptiveFlush(minInterval, maxInterval time.Duration, targetBatch int) (*AdaptiveFlush, error) {
+	if minInterval <= 0 || maxInterval < minInterval {
+		return nil, fmt.Errorf("creators: adaptive flush bounds must satisfy 0 < min <= max, got %s and %s", minInterval, maxInterval)
+	}
+	if targetBatch <= 0 {
+		return nil, fmt.Errorf("creators: adaptive flush target batch must be positive, got %d", targetBatch)
+	}
+	return &AdaptiveFlush{
+		minInterval: minInterval,
+		maxInterval: maxInterval,
+		targetBatch: targetBatch,
+		interval:    maxInterval,
+		now:         time.Now,
+	}, nil
+}
+
+// AdaptiveFlush tracks the arrival rate of log entries and adjusts the flush interval of a buffering
+// log creator between configured bounds.
+//
+// Observe is called for every buffered entry and Interval once per flush, when the entries observed since
+// the previous call are folded into the rate. AdaptiveFlush is safe for concurrent use.
+type AdaptiveFlush struct {
+	mutex       sync.Mutex
+	minInterval time.Duration
+	maxInterval time.Duration
+	targetBatch int
+	now         func() time.Time
+	windowStart time.Time
+	windowCount int64
+	rate        float64
+	interval    time.Duration
+}
+
+// Observe records the arrival of entries.
+//
+// Parameters:
+//   - entries: The number of entries that arrived.
+func (af *AdaptiveFlush) Observe(entries int) {
+	af.mutex.Lock()
+	defer af.mutex.Unlock()
+	if af.windowStart.IsZero() {
+		af.windowStart = af.now()
+	}
+	af.windowCount += int64(entries)
+}
+
+// Interval folds the entries observed since the last call into the arrival rate and returns the
+// flush interval to wait before the next flush.
+//
+// Returns:
+//   - time.Duration: The effective flush interval, between the configured bounds.
+func (af *AdaptiveFlush) Interval() time.Duration {
+	af.mutex.Lock()
+	defer af.mutex.Unlock()
+	now := af.now()
+	if !af.windowStart.IsZero() {
+		if elapsed := now.Sub(af.windowStart).Seconds(); elapsed > 0 {
+			windowRate := float64(af.windowCount) / elapsed
+			af.rate = adaptiveFlushSmoothing*windowRate + (1-adaptiveFlushSmoothing)*af.rate
+		}
+	}
+	af.windowStart = now
+	af.windowCount = 0
+
+	af.interval = af.maxInterval
+	if af.rate > 0 {
+		if seconds := float64(af.targetBatch) / af.rate; seconds < af.maxInterval.Seconds() {
+			af.interval = time.Duration(seconds * float64(time.Second))
+		}
+	}
+	if af.interval < af.minInterval {
+		af.interval = af.minInterval
+	}
+	return af.interval
+}
+
+// Stats returns the current effective values of the adaptive flush.
+//
+// Returns:
+//   - map[string]interface{}: The arrival rate in entries per second and the effective flush interval.
+func (af *AdaptiveFlush) Stats() map[string]interface{} {
+	af.mutex.Lock()
+	defer af.mutex.Unlock()
+	return map[string]interface{}{
+		"arrival_rate":   af.rate,
+		"flush_interval": af.interval.String(),
+	}
+}
@@ -0,0 +1,113 @@
+package creators
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// fakeClock is a manually advanced clock for AdaptiveFlush.
+type fakeClock struct {
+	current time.Time
+}
+
+func (fc *fakeClock) now() time.Time { return fc.current }
+
+func (fc *fakeClock) advance(d time.Duration) { fc.current = fc.current.Add(d) }
+
+// simulate feeds entries at the given rate per second for the given number of flush windows and
+// returns the interval after each window.
+func simulate(af *AdaptiveFlush, clock *fakeClock, perSecond int, windows int) []time.Duration {
+	intervals := make([]time.Duration, 0, windows)
+	interval := af.Interval()
+	for w := 0; w < windows; w++ {
+		seconds := int(interval / time.Second)
+		for s := 0; s < seconds; s++ {
+			af.Observe(perSecond)
+			clock.advance(time.Second)
+		}
+		interval = af.Interval()
+		intervals = append(intervals, interval)
+	}
+	return intervals
+}
+
+func TestAdaptiveFlushFollowsArrivalRate(t *testing.T) {
+	clock := &fakeClock{current: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	af, err := NewAdaptiveFlush(2*time.Second, 60*time.Second, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	af.now = clock.now
+
+	if interval := af.Interval(); interval != 60*time.Second {
+		t.Errorf("without entries the interval should be the maximum, got %s", interval)
+	}
+
+	high := simulate(af, clock, 2000, 5)
+	for i := 1; i < len(high); i++ {
+		if high[i] > high[i-1] {
+			t.Errorf("interval should shrink under a high rate: %v", high)
+		}
+	}
+	if last := high[len(high)-1]; last != 2*time.Second {
+		t.Errorf("high rate should reach the minimum interval, got %s", last)
+	}
+
+	low := simulate(af, clock, 5, 10)
+	for i := 1; i < len(low); i++ {
+		if low[i] < low[i-1] {
+			t.Errorf("interval should grow under a low rate: %v", low)
+		}
+	}
+	if last := low[len(low)-1]; last != 60*time.Second {
+		t.Errorf("low rate should reach the maximum interval, got %s", last)
+	}
+
+	stats := af.Stats()
+	if stats["flush_interval"] != "1m0s" || stats["arrival_rate"].(float64) <= 0 {
+		t.Errorf("unexpected stats: %v", stats)
+	}
+}
+
+func TestNewAdaptiveFlushValidates(t *testing.T) {
+	if _, err := NewAdaptiveFlush(0, time.Second, 10); err == nil {
+		t.Error("non-positive minimum should be rejected")
+	}
+	if _, err := NewAdaptiveFlush(time.Minute, time.Second, 10); err == nil {
+		t.Error("maximum below minimum should be rejected")
+	}
+	if _, err := NewAdaptiveFlush(time.Second, time.Minute, 0); err == nil {
+		t.Error("non-positive target batch should be rejected")
+	}
+}
+
+func TestS3CreatorAdaptiveFlushStats(t *testing.T) {
+	af, err := NewAdaptiveFlush(time.Second, time.Hour, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s3Creator := startS3Creator(&fakeS3Client{objects: map[string]string{}}, "archive", "", "", 2, af.maxInterval, af)
+	defer s3Creator.Shutdown()
+
+	s3Creator.LogIt(types.INFO, "Example S3 Log Message")
+	af.mutex.Lock()
+	observed := af.windowCount
+	af.mutex.Unlock()
+	if observed != 1 {
+		t.Errorf("buffered entries should be observed, got %d", observed)
+	}
+	if _, ok := s3Creator.CreatorStats()["arrival_rate"]; !ok {
+		t.Errorf("adaptive stats missing: %v", s3Creator.CreatorStats())
+	}
+
+	fixed, err := newS3Creator(&fakeS3Client{objects: map[string]string{}}, "archive", "", "", 2, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fixed.Shutdown()
+	if fixed.CreatorStats()["flush_interval"] != "1h0m0s" {
+		t.Errorf("unexpected stats: %v", fixed.CreatorStats())
+	}
+}
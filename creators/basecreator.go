@@ -1,9 +1,9 @@
 package creators
 
 import (
-	"fmt"
 	"log"
 	"os"
+	"runtime"
 
 	"github.com/Eyup-Devop/logtor"
 	"github.com/Eyup-Devop/logtor/types"
@@ -12,6 +12,7 @@ import (
 // NewBaseCreator creates a new instance of the BaseCreator.
 //
 // It initializes a BaseCreator with the specified logName, callDepth, and logPrefix.
+// It is a thin wrapper around NewBaseCreatorWithOptions.
 //
 // Parameters:
 //   - logName: The type of log creator (e.g., File, Console).
@@ -24,15 +25,35 @@ import (
 //
 // If logName is an empty string, it defaults to Console.
 func NewBaseCreator(logName types.LogCreatorName, callDepth int, logPrefix int) (logtor.LogCreator, error) {
-	baseCreator := &BaseCreator{
-		log:       log.New(os.Stderr, "", log.LstdFlags|log.Lshortfile),
-		logName:   logName,
-		callDepth: callDepth,
-		logPrefix: logPrefix,
+	// The positional constructors predate option validation and clamp negative values instead of failing.
+	return NewBaseCreatorWithOptions(WithName(logName), WithCallDepth(max(callDepth, 0)), WithPrefixWidth(max(logPrefix, 0)))
+}
+
+// NewBaseCreatorWithOptions creates a new instance of the BaseCreator configured with functional options.
+//
+// Parameters:
+//   - opts: Options such as WithName, WithCallDepth, WithPrefixWidth, WithJSONFormat and WithPrettyJSON.
+//
+// Returns:
+//   - *BaseCreator: A pointer to the newly created BaseCreator.
+//   - error: An error if an option is invalid or not supported by the BaseCreator.
+//
+// The name defaults to Console.
+func NewBaseCreatorWithOptions(opts ...Option) (logtor.LogCreator, error) {
+	options, err := newCreatorOptions(consoleTarget, Console, opts)
+	if err != nil {
+		return nil, err
 	}
 
-	if logName == "" {
-		baseCreator.logName = Console
+	baseCreator := &BaseCreator{
+		log:        log.New(os.Stderr, "", log.LstdFlags|log.Lshortfile),
+		logName:    options.name,
+		callDepth:  options.callDepth,
+		logPrefix:  options.prefixWidth,
+		prefixes:   newLevelPrefixes(options.prefixWidth, true),
+		jsonFormat: options.jsonFormat || options.prettyJSON,
+		prettyJSON: options.prettyJSON,
+		clock:      options.clock,
 	}
 
 	return baseCreator, nil
@@ -44,30 +65,151 @@ const Console types.LogCreatorName = "Console"
 // BaseCreator is a basic implementation of the LogCreator interface.
 // It logs messages with a specified log level, call depth, and log prefix.
 type BaseCreator struct {
-	log       *log.Logger
-	logName   types.LogCreatorName
-	callDepth int
-	logPrefix int
+	log        *log.Logger
+	logName    types.LogCreatorName
+	callDepth  int
+	logPrefix  int
+	prefixes   *levelPrefixes
+	jsonFormat bool
+	prettyJSON bool
+	clock      logtor.Clock
+	formatter  formatterHolder
 }
 
 // LogItWithCallDepth logs a message with the specified log level, call depth, and log message.
 //
 // It formats the log entry with the log level's color, log prefix, and then outputs the log message.
 // The call depth parameter determines how many stack frames to ascend when recording the log entry.
+// Entries have the format of a log.Logger with log.LstdFlags|log.Lshortfile, but the prefix is
+// precomputed per level and written inline, so concurrent calls cannot mix up their prefixes.
+// When the JSON format is enabled, the entry is written as a single BrokerMessage JSON object instead.
 //
 // Parameters:
 //   - level: The log level for the message (e.g., INFO, DEBUG).
-//   - callDepth: The call depth for recording the log entry.
+//   - callDepth: The call depth for recording the log entry, or zero or less for the configured call depth.
 //   - logMessage: The message to be logged, which can be of any type.
 //
 // Returns:
 //   - bool: Always returns true, indicating the message was successfully logged.
 func (br *BaseCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
-	br.log.SetPrefix(fmt.Sprintf("%s%-*s : ", types.GetColorForLogLevel(level), br.logPrefix, level))
-	br.log.Output(callDepth, fmt.Sprintf("%+v%s", logMessage, types.ResetColor))
+	callDepth = resolveCallDepth(callDepth, br.callDepth)
+	if formatter := br.formatter.Load(); formatter != nil {
+		_, file, line, ok := runtime.Caller(callDepth - 1)
+		if !ok {
+			file = "???"
+			line = 0
+		}
+		if output, ok := formatEntry(formatter, level, br.clock.Now(), file, line, logMessage); ok {
+			_, err := br.log.Writer().Write(append(output, '\n'))
+			return err == nil
+		}
+	}
+	if br.jsonFormat {
+		return br.logJSON(level, callDepth, logMessage)
+	}
+	// log.Logger.Output counts its own caller as depth 1, runtime.Caller counts it as 0.
+	_, file, line, ok := runtime.Caller(callDepth - 1)
+	if !ok {
+		file = "???"
+		line = 0
+	}
+
+	buffer := entryBufferPool.Get().(*[]byte)
+	entry := appendTextEntry((*buffer)[:0], br.clock.Now(), br.prefixes.prefix(level), file, line, logMessage, types.ResetColor)
+	br.log.Writer().Write(entry)
+	if cap(entry) <= maxPooledBufferSize {
+		*buffer = entry
+		entryBufferPool.Put(buffer)
+	}
 	return true
 }
 
+func (br *BaseCreator) logJSON(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	// Called from LogItWithCallDepth, so the extra frame matches how log.Logger.Output counts callDepth.
+	_, file, line, ok := runtime.Caller(callDepth)
+	if !ok {
+		file = "UNKNOWN FILE"
+		line = 0
+	}
+
+	buffer := entryBufferPool.Get().(*[]byte)
+	entry := appendEntryJSON((*buffer)[:0], BrokerMessage{
+		LogLevel:   string(level),
+		Created:    formatCreated(br.clock.Now()),
+		File:       file,
+		Line:       line,
+		LogMessage: logMessage,
+	}, br.prettyJSON)
+	entry = append(entry, '\n')
+	_, err := br.log.Writer().Write(entry)
+	if cap(entry) <= maxPooledBufferSize {
+		*buffer = entry
+		entryBufferPool.Put(buffer)
+	}
+	return err == nil
+}
+
+// LogRendered logs an entry rendered by Logtor, with the time, caller and fields resolved by Logtor.
+//
+// Text entries record the message followed by its fields as sorted key=value pairs, while JSON entries
+// record the message as it was logged.
+//
+// Parameters:
+//   - entry: The rendered entry to be logged.
+//
+// Returns:
+//   - bool: True if the entry was logged; false if writing a JSON entry failed.
+func (br *BaseCreator) LogRendered(entry types.RenderedEntry) bool {
+	if formatter := br.formatter.Load(); formatter != nil {
+		if output, ok := formatEntry(formatter, entry.Level, entry.Time, entry.File, entry.Line, entry.Value); ok {
+			_, err := br.log.Writer().Write(append(output, '\n'))
+			return err == nil
+		}
+	}
+	buffer := entryBufferPool.Get().(*[]byte)
+	var output []byte
+	if br.jsonFormat {
+		output = appendEntryJSON((*buffer)[:0], BrokerMessage{
+			LogLevel:   string(entry.Level),
+			Created:    formatCreated(entry.Time),
+			File:       entry.File,
+			Line:       entry.Line,
+			LogMessage: entry.Value,
+		}, br.prettyJSON)
+		output = append(output, '\n')
+	} else {
+		output = appendTextEntry((*buffer)[:0], entry.Time, br.prefixes.prefix(entry.Level), entry.File, entry.Line, entry.Text(), types.ResetColor)
+	}
+	_, err := br.log.Writer().Write(output)
+	if cap(output) <= maxPooledBufferSize {
+		*buffer = output
+		entryBufferPool.Put(buffer)
+	}
+	// Like LogItWithCallDepth, only JSON entries report write failures.
+	return err == nil || !br.jsonFormat
+}
+
+// SetFormatter sets the formatter rendering the entries of the BaseCreator, replacing the colored text
+// and JSON formats. If the formatter fails for an entry, the entry is written in the built-in format.
+//
+// Parameters:
+//   - formatter: The formatter to use, or nil for the built-in format.
+func (br *BaseCreator) SetFormatter(formatter types.Formatter) {
+	br.formatter.Store(formatter)
+}
+
+// LogEntry logs a structured entry with its own level, timestamp, caller and fields, implementing
+// logtor.LogCreatorV2.
+//
+// Parameters:
+//   - entry: The entry to be logged.
+//
+// Returns:
+//   - bool: The result of LogRendered for the entry.
+func (br *BaseCreator) LogEntry(entry types.LogEntry) bool {
+	return br.LogRendered(entry.Rendered())
+}
+
 // LogIt logs a message with the specified log level using the default call depth.
 //
 // This method is a convenience wrapper around LogItWithCallDepth, using the call depth
@@ -122,3 +264,42 @@ func (br *BaseCreator) Shutdown() {
 func (br *BaseCreator) IsReady() bool {
 	return true
 }
+
+// Describe returns the resolved settings of the BaseCreator.
+//
+// Returns:
+//   - map[string]interface{}: The creator type, name, call depth, prefix width and output format.
+func (br *BaseCreator) Describe() map[string]interface{} {
+	return map[string]interface{}{
+		"type":         consoleTarget,
+		"name":         br.logName,
+		"call_depth":   br.callDepth,
+		"prefix_width": br.logPrefix,
+		"json_format":  br.jsonFormat,
+		"json_pretty":  br.prettyJSON,
+	}
+}
+
+// CreatorType returns the kind of the log creator.
+//
+// Returns:
+//   - string: "console".
+func (br *BaseCreator) CreatorType() string {
+	return consoleTarget
+}
+
+// CreatorVersion returns the version of the log creator implementation.
+//
+// Returns:
+//   - string: The implementation version.
+func (br *BaseCreator) CreatorVersion() string {
+	return creatorVersion
+}
+
+// CreatorDescription returns a short description of the log creator.
+//
+// Returns:
+//   - string: A description naming the output stream.
+func (br *BaseCreator) CreatorDescription() string {
+	return "Colored log entries written to standard error"
+}
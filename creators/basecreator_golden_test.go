@@ -0,0 +1,157 @@
+package creators
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// legacyBaseEntry renders an entry the way the BaseCreator did before it precomputed its prefixes.
+// It is the golden reference for the BaseCreator text format.
+func legacyBaseEntry(w io.Writer, width int, level types.LogLevel, callDepth int, logMessage interface{}) {
+	logger := log.New(w, "", log.LstdFlags|log.Lshortfile)
+	logger.SetPrefix(fmt.Sprintf("%s%-*s : ", types.GetColorForLogLevel(level), width, level))
+	logger.Output(callDepth, fmt.Sprintf("%+v%s", logMessage, types.ResetColor))
+}
+
+// renderBaseBoth renders an entry with both implementations, so both report the caller of renderBaseBoth.
+func renderBaseBoth(expected io.Writer, baseCreator *BaseCreator, width int, level types.LogLevel, logMessage interface{}) {
+	legacyBaseEntry(expected, width, level, 3, logMessage)
+	baseCreator.LogItWithCallDepth(level, 3, logMessage)
+}
+
+func TestBaseCreatorMatchesLegacyFormat(t *testing.T) {
+	cases := []struct {
+		name       string
+		width      int
+		level      types.LogLevel
+		logMessage interface{}
+	}{
+		{"string", 5, types.ERROR, "Example Base Log Message"},
+		{"narrow prefix", 0, types.WARN, "Example Base Log Message"},
+		{"wide prefix", 10, types.TRACE, "Example Base Log Message"},
+		{"struct", 5, types.INFO, struct {
+			Name string
+			Age  int
+		}{"Example Name", 25}},
+		{"trailing newline", 5, types.DEBUG, "ends with a newline\n"},
+		{"none level", 5, types.NONE, "Example Base Log Message"},
+		{"custom level", 5, types.LogLevel("AUDIT"), "Example Base Log Message"},
+		{"nil", 5, types.INFO, nil},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logCreator, err := NewBaseCreatorWithOptions(WithPrefixWidth(tc.width))
+			if err != nil {
+				t.Fatal(err)
+			}
+			baseCreator := logCreator.(*BaseCreator)
+			var actual bytes.Buffer
+			baseCreator.log.SetOutput(&actual)
+
+			// Retry if the clock ticks over a second between the two renderings.
+			for attempt := 0; attempt < 3; attempt++ {
+				var expected bytes.Buffer
+				actual.Reset()
+				before := time.Now().Unix()
+				renderBaseBoth(&expected, baseCreator, tc.width, tc.level, tc.logMessage)
+				if time.Now().Unix() != before {
+					continue
+				}
+				if actual.String() != expected.String() {
+					t.Errorf("output differs from log.Logger:\n got %q\nwant %q", actual.String(), expected.String())
+				}
+				return
+			}
+			t.Fatal("clock kept ticking over during the comparison")
+		})
+	}
+}
+
+// lineWriter records every Write call separately, to detect entries split across writes.
+type lineWriter struct {
+	mutex  sync.Mutex
+	writes []string
+}
+
+func (lw *lineWriter) Write(p []byte) (int, error) {
+	lw.mutex.Lock()
+	defer lw.mutex.Unlock()
+	lw.writes = append(lw.writes, string(p))
+	return len(p), nil
+}
+
+func TestBaseCreatorConcurrentPrefixes(t *testing.T) {
+	logCreator, err := NewBaseCreatorWithOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseCreator := logCreator.(*BaseCreator)
+	output := &lineWriter{}
+	baseCreator.log.SetOutput(output)
+
+	var wg sync.WaitGroup
+	for _, level := range []types.LogLevel{types.ERROR, types.INFO} {
+		wg.Add(1)
+		go func(level types.LogLevel) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				baseCreator.LogIt(level, level)
+			}
+		}(level)
+	}
+	wg.Wait()
+
+	for _, entry := range output.writes {
+		level := types.INFO
+		if strings.HasSuffix(entry, ": "+string(types.ERROR)+types.ResetColor+"\n") {
+			level = types.ERROR
+		}
+		if !strings.HasPrefix(entry, baseCreator.prefixes.prefix(level)) {
+			t.Fatalf("entry prefix does not match its message: %q", entry)
+		}
+	}
+	if len(output.writes) != 200 {
+		t.Errorf("expected one write per entry, got %d", len(output.writes))
+	}
+}
+
+// discardWriter drops everything written to it. Unlike io.Discard, log.Logger does not recognize it,
+// so the legacy benchmark still renders its entries.
+type discardWriter struct{}
+
+func (discardWriter) Write(p []byte) (int, error) { return len(p), nil }
+
+// BenchmarkBaseCreatorLegacy measures the SetPrefix based rendering the BaseCreator used before,
+// as the baseline for BenchmarkBaseCreatorLogIt.
+func BenchmarkBaseCreatorLegacy(b *testing.B) {
+	logger := log.New(discardWriter{}, "", log.LstdFlags|log.Lshortfile)
+	var logMessage interface{} = "Example Base Log Message"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logger.SetPrefix(fmt.Sprintf("%s%-*s : ", types.GetColorForLogLevel(types.INFO), 5, types.INFO))
+		logger.Output(2, fmt.Sprintf("%+v%s", logMessage, types.ResetColor))
+	}
+}
+
+func BenchmarkBaseCreatorLogIt(b *testing.B) {
+	logCreator, err := NewBaseCreatorWithOptions()
+	if err != nil {
+		b.Fatal(err)
+	}
+	baseCreator := logCreator.(*BaseCreator)
+	baseCreator.log.SetOutput(discardWriter{})
+	var logMessage interface{} = "Example Base Log Message"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		baseCreator.LogItWithCallDepth(types.INFO, 2, logMessage)
+	}
+}
@@ -0,0 +1,52 @@
+package creators
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestBaseCreatorJSONFormat(t *testing.T) {
+	logCreator, err := NewBaseCreatorWithOptions(WithJSONFormat(true), WithCallDepth(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	baseCreator := logCreator.(*BaseCreator)
+	baseCreator.log.SetOutput(&output)
+
+	if result := baseCreator.LogIt(types.WARN, map[string]int{"retries": 3}); !result {
+		t.Fatal("Log not recorded")
+	}
+
+	var message BrokerMessage
+	if err := json.Unmarshal(output.Bytes(), &message); err != nil {
+		t.Fatalf("output is not a JSON line: %q", output.String())
+	}
+	if message.LogLevel != "WARN" || message.Line == 0 || message.LogMessage.(map[string]interface{})["retries"] != float64(3) {
+		t.Errorf("unexpected message: %+v", message)
+	}
+	if _, err := NewFileCreatorWithOptions("temp.log", WithJSONFormat(true)); err == nil {
+		t.Error("WithJSONFormat should only be supported by the console creator")
+	}
+}
+
+func TestBaseCreatorSetFormatter(t *testing.T) {
+	logCreator, err := NewBaseCreatorWithOptions(WithJSONFormat(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	baseCreator := logCreator.(*BaseCreator)
+	baseCreator.log.SetOutput(&output)
+	baseCreator.SetFormatter(types.LogfmtFormatter{})
+
+	if result := baseCreator.LogRendered(types.RenderedEntry{Level: types.INFO, File: "/src/main.go", Line: 7, Value: "started"}); !result {
+		t.Fatal("Log not recorded")
+	}
+	if expected := "time=0001-01-01T00:00:00Z level=INFO caller=main.go:7 msg=started\n"; output.String() != expected {
+		t.Errorf("the formatter should replace the JSON format:\n got %q\nwant %q", output.String(), expected)
+	}
+}
@@ -7,14 +7,16 @@
 package creators
 
 import (
-	"encoding/base64"
-	"encoding/json"
+	"crypto/tls"
+	"fmt"
 	"io"
 	"log"
-	"os"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/Eyup-Devop/logtor"
 	"github.com/Eyup-Devop/logtor/types"
 	"github.com/IBM/sarama"
 )
@@ -22,6 +24,7 @@ import (
 // NewBrokerCreator creates a new instance of BrokerCreator, which logs messages to a Kafka broker.
 //
 // It initializes a BrokerCreator with the provided Kafka broker addresses, topic, time zone, log creator name, and call depth.
+// It is a thin wrapper around NewBrokerCreatorWithOptions.
 //
 // Parameters:
 //   - brokers: A list of Kafka broker addresses.
@@ -33,6 +36,112 @@ import (
 //   - *BrokerCreator: A pointer to the newly created BrokerCreator.
 //   - error: An error if initialization fails, or nil if successful.
 func NewBrokerCreator(brokers []string, topic string, logName types.LogCreatorName, callDepth int, failWriter io.Writer) (*BrokerCreator, error) {
+	return NewBrokerCreatorWithOptions(brokers, topic, WithName(logName), WithCallDepth(max(callDepth, 0)), WithFailWriter(failWriter))
+}
+
+// NewBrokerCreatorWithOptions creates a new instance of BrokerCreator configured with functional options.
+//
+// Parameters:
+//   - brokers: A list of Kafka broker addresses.
+//   - topic: The Kafka topic to publish log messages.
+//   - opts: Options such as WithName, WithCallDepth, WithFailWriter, WithErrorSink, WithSASLPlain and WithRetry.
+//
+// Returns:
+//   - *BrokerCreator: A pointer to the newly created BrokerCreator.
+//   - error: An error if an option is invalid or not supported, or the producer cannot be created.
+//
+// The name defaults to Broker.
+func NewBrokerCreatorWithOptions(brokers []string, topic string, opts ...Option) (*BrokerCreator, error) {
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("creators: at least one broker address is required")
+	}
+	if topic == "" {
+		return nil, fmt.Errorf("creators: topic must not be empty")
+	}
+	options, err := newCreatorOptions(brokerTarget, Broker, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	config := newBrokerConfig()
+	if options.saslUser != "" {
+		if err := applySASL(config, sarama.SASLTypePlaintext, options.saslUser, options.saslPassword); err != nil {
+			return nil, err
+		}
+	}
+	producer, err := dialProducer(brokers, config)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBrokerCreator(producer, brokers, topic, options), nil
+}
+
+// NewBrokerCreatorWithConfig creates a new instance of BrokerCreator whose producer is built from a
+// sarama configuration, for clusters needing settings such as TLS or SASL.
+//
+// Parameters:
+//   - brokers: A list of Kafka broker addresses.
+//   - topic: The Kafka topic to publish log messages.
+//   - logName: The name representing the log creator (e.g., Broker).
+//   - callDepth: The call depth to be used in log output.
+//   - saramaConfig: The configuration of the producer, or nil for the configuration of NewBrokerCreator.
+//
+// Returns:
+//   - *BrokerCreator: A pointer to the newly created BrokerCreator.
+//   - error: An error if the brokers or topic are missing, the configuration is invalid, or the producer
+//     cannot be created.
+func NewBrokerCreatorWithConfig(brokers []string, topic string, logName types.LogCreatorName, callDepth int, saramaConfig *sarama.Config) (*BrokerCreator, error) {
+	if len(brokers) == 0 {
+		return nil, fmt.Errorf("creators: at least one broker address is required")
+	}
+	if topic == "" {
+		return nil, fmt.Errorf("creators: topic must not be empty")
+	}
+	options, err := newCreatorOptions(brokerTarget, Broker, []Option{WithName(logName), WithCallDepth(max(callDepth, 0))})
+	if err != nil {
+		return nil, err
+	}
+	if saramaConfig == nil {
+		saramaConfig = newBrokerConfig()
+	}
+	if err := saramaConfig.Validate(); err != nil {
+		return nil, fmt.Errorf("creators: invalid sarama config: %w", err)
+	}
+
+	producer, err := dialProducer(brokers, saramaConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBrokerCreator(producer, brokers, topic, options), nil
+}
+
+// NewBrokerCreatorWithTLS creates a new instance of BrokerCreator connecting to the brokers over TLS.
+//
+// It applies the TLS configuration to the configuration of NewBrokerCreator and delegates to
+// NewBrokerCreatorWithConfig.
+//
+// Parameters:
+//   - brokers: A list of Kafka broker addresses.
+//   - topic: The Kafka topic to publish log messages.
+//   - logName: The name representing the log creator (e.g., Broker).
+//   - callDepth: The call depth to be used in log output.
+//   - tlsConfig: The TLS configuration, e.g. with the CA of the cluster and a client certificate, or nil
+//     for the system defaults.
+//
+// Returns:
+//   - *BrokerCreator: A pointer to the newly created BrokerCreator.
+//   - error: An error if initialization fails, or nil if successful.
+func NewBrokerCreatorWithTLS(brokers []string, topic string, logName types.LogCreatorName, callDepth int, tlsConfig *tls.Config) (*BrokerCreator, error) {
+	config := newBrokerConfig()
+	config.Net.TLS.Enable = true
+	config.Net.TLS.Config = tlsConfig
+	return NewBrokerCreatorWithConfig(brokers, topic, logName, callDepth, config)
+}
+
+// newBrokerConfig returns the producer configuration of NewBrokerCreator.
+func newBrokerConfig() *sarama.Config {
 	config := sarama.NewConfig()
 	config.Producer.RequiredAcks = sarama.WaitForLocal
 	config.Producer.Compression = sarama.CompressionSnappy
@@ -40,45 +149,81 @@ func NewBrokerCreator(brokers []string, topic string, logName types.LogCreatorNa
 	config.Producer.MaxMessageBytes = 1024 * 1024 * 10
 	config.Producer.Retry.Max = 10
 	config.Producer.Retry.Backoff = 10 * time.Second
+	return config
+}
 
-	var producer sarama.AsyncProducer
-	var err error
+// newAsyncProducer creates the producers of the BrokerCreator, replaced in tests by a mock producer.
+var newAsyncProducer = sarama.NewAsyncProducer
+
+// dialProducer creates a producer, trying five times, five seconds apart, while the brokers cannot be reached.
+func dialProducer(brokers []string, config *sarama.Config) (sarama.AsyncProducer, error) {
+	var (
+		producer sarama.AsyncProducer
+		err      error
+	)
 	for i := 0; i < 5; i++ {
-		producer, err = sarama.NewAsyncProducer(brokers, config)
+		producer, err = newAsyncProducer(brokers, config)
 		if err == nil {
 			break
 		}
 		time.Sleep(5 * time.Second)
 	}
+	return producer, err
+}
 
+// NewBrokerCreatorWithProducer creates a new instance of BrokerCreator publishing through an existing producer.
+//
+// Use it when the sarama producer needs settings the other constructors do not expose, or to inject
+// a mock producer in tests. The BrokerCreator takes ownership of the producer and closes it on Shutdown.
+//
+// Parameters:
+//   - producer: The sarama producer used to publish log messages.
+//   - topic: The Kafka topic to publish log messages.
+//   - opts: Options such as WithName, WithCallDepth, WithFailWriter, WithErrorSink and WithRetry.
+//
+// Returns:
+//   - *BrokerCreator: A pointer to the newly created BrokerCreator.
+//   - error: An error if the producer is nil, the topic is empty or an option is invalid.
+func NewBrokerCreatorWithProducer(producer sarama.AsyncProducer, topic string, opts ...Option) (*BrokerCreator, error) {
+	if producer == nil {
+		return nil, fmt.Errorf("creators: producer must not be nil")
+	}
+	if topic == "" {
+		return nil, fmt.Errorf("creators: topic must not be empty")
+	}
+	options, err := newCreatorOptions(brokerTarget, Broker, opts)
 	if err != nil {
 		return nil, err
 	}
+	return newBrokerCreator(producer, nil, topic, options), nil
+}
 
-	go func(failWriter io.Writer) {
-		errorLog := log.New(os.Stdout, "", 0)
-		if failWriter != nil {
-			errorLog = log.New(failWriter, "", 0)
-		}
-
-		for err := range producer.Errors() {
-			errorKey := base64.StdEncoding.EncodeToString(err.Msg.Value.(sarama.ByteEncoder))
-			errorLog.Println(errorKey)
-		}
-	}(failWriter)
-
-	if logName == "" {
-		logName = Broker
+func newBrokerCreator(producer sarama.AsyncProducer, brokers []string, topic string, options *creatorOptions) *BrokerCreator {
+	errorSink := options.errorSink
+	if errorSink == nil {
+		errorSink, _ = NewBaseCreatorWithOptions(WithName(options.name))
 	}
-
 	brokerCreator := &BrokerCreator{
-		logName:   logName,
-		topic:     topic,
-		producer:  producer,
-		callDepth: callDepth,
+		logName:    options.name,
+		brokers:    brokers,
+		topic:      topic,
+		producer:   producer,
+		callDepth:  options.callDepth,
+		errorsDone: make(chan struct{}),
+		clock:      options.clock,
+		errorSink:  errorSink,
+		retry:      options.retry,
 	}
-
-	return brokerCreator, nil
+	if options.failWriter != nil {
+		brokerCreator.failLog = log.New(options.failWriter, "", 0)
+	}
+	go func() {
+		defer close(brokerCreator.errorsDone)
+		for err := range producer.Errors() {
+			brokerCreator.retryOrDrop(err)
+		}
+	}()
+	return brokerCreator
 }
 
 // Broker is a constant representing the LogCreatorName for the Broker log creator.
@@ -86,19 +231,56 @@ const Broker types.LogCreatorName = "Broker"
 
 // BrokerCreator is an implementation of the LogCreator interface for logging messages to a Kafka broker.
 type BrokerCreator struct {
-	producer  sarama.AsyncProducer
-	topic     string
-	logName   types.LogCreatorName
-	callDepth int
+	producer   sarama.AsyncProducer
+	brokers    []string
+	topic      string
+	logName    types.LogCreatorName
+	callDepth  int
+	errorsDone chan struct{}
+	clock      logtor.Clock
+	formatter  formatterHolder
+	// topicRouter holds the router set with SetTopicRouter, possibly nil.
+	topicRouter atomic.Pointer[TopicRouter]
+	errorSink   logtor.LogCreator
+	failLog     *log.Logger
+	retry       RetryConfig
+	retries     brokerRetries
+	dropMutex   sync.Mutex
+	// inputMutex guards closed, and is held for reading while a message is handed to the producer.
+	inputMutex sync.RWMutex
+	closed     bool
 }
 
 // BrokerMessage represents the structure of log messages to be sent to the Kafka broker.
+//
+// Fields holds the structured fields of a types.LogEntry or logtor.ContextualMessage, whose message
+// alone is then the LogMessage. EncodingError is only set when the log message could not be encoded as
+// JSON; LogMessage then holds its "%+v" representation, followed by the fields as key=value pairs,
+// instead.
 type BrokerMessage struct {
-	LogLevel   string      `json:"loglevel"`
-	Created    string      `json:"created"`
-	File       string      `json:"file"`
-	Line       int         `json:"line"`
-	LogMessage interface{} `json:"log_message"`
+	LogLevel      string                 `json:"loglevel"`
+	Created       string                 `json:"created"`
+	File          string                 `json:"file"`
+	Line          int                    `json:"line"`
+	LogMessage    interface{}            `json:"log_message"`
+	Fields        map[string]interface{} `json:"fields,omitempty"`
+	EncodingError string                 `json:"encoding_error,omitempty"`
+}
+
+// newBrokerMessage builds the BrokerMessage of a message, moving the fields of a types.LogEntry or
+// logtor.ContextualMessage into Fields.
+func newBrokerMessage(level types.LogLevel, now time.Time, file string, line int, logMessage interface{}) BrokerMessage {
+	message := BrokerMessage{
+		LogLevel:   string(level),
+		Created:    formatCreated(now),
+		File:       file,
+		Line:       line,
+		LogMessage: logMessage,
+	}
+	if entry := logEntryOf(level, now, file, line, logMessage); len(entry.Fields) > 0 {
+		message.LogMessage, message.Fields = entry.Message, entry.Fields
+	}
+	return message
 }
 
 // LogItWithCallDepth logs a message with the specified log level, call depth, and log message to the Kafka broker.
@@ -108,12 +290,13 @@ type BrokerMessage struct {
 //
 // Parameters:
 //   - level: The log level for the message (e.g., INFO, DEBUG).
-//   - callDepth: The call depth for recording the log entry.
+//   - callDepth: The call depth for recording the log entry, or zero or less for the configured call depth.
 //   - logMessage: The message to be logged, which can be of any type.
 //
 // Returns:
-//   - bool: Always returns true, indicating the message was successfully logged.
+//   - bool: True if the message was handed to the producer, false once the BrokerCreator is shut down.
 func (br *BrokerCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	callDepth = resolveCallDepth(callDepth, br.callDepth)
 	var (
 		file string
 		line int
@@ -125,27 +308,101 @@ func (br *BrokerCreator) LogItWithCallDepth(level types.LogLevel, callDepth int,
 		line = 0
 	}
 
-	currentTime := time.Now().UTC()
-	formattedTime := currentTime.Format("2006/01/02 15:04:05")
+	now := br.clock.Now()
+	if formatter := br.formatter.Load(); formatter != nil {
+		if output, ok := formatEntry(formatter, level, now, file, line, logMessage); ok {
+			return br.send(level, output)
+		}
+	}
 
-	message := BrokerMessage{
-		LogLevel:   string(level),
-		Created:    formattedTime,
-		File:       file,
-		Line:       line,
-		LogMessage: logMessage,
+	// The producer keeps the value until the message is delivered, so it gets its own slice
+	// instead of a pooled buffer.
+	jsonMessage := appendEntryJSON(nil, newBrokerMessage(level, now, file, line, logMessage), false)
+
+	return br.send(level, jsonMessage)
+}
+
+// LogRendered sends an entry rendered by Logtor to the Kafka broker, with the time and caller resolved
+// by Logtor. The log message is the message as it was logged, so structured payloads keep their shape.
+//
+// Parameters:
+//   - entry: The rendered entry to be logged.
+//
+// Returns:
+//   - bool: True if the entry was handed to the producer, false once the BrokerCreator is shut down.
+func (br *BrokerCreator) LogRendered(entry types.RenderedEntry) bool {
+	if formatter := br.formatter.Load(); formatter != nil {
+		if output, ok := formatEntry(formatter, entry.Level, entry.Time, entry.File, entry.Line, entry.Value); ok {
+			return br.send(entry.Level, output)
+		}
 	}
+	jsonMessage := appendEntryJSON(nil, newBrokerMessage(entry.Level, entry.Time, entry.File, entry.Line, entry.Value), false)
 
-	jsonMessage, _ := json.Marshal(message)
+	return br.send(entry.Level, jsonMessage)
+}
 
-	br.producer.Input() <- &sarama.ProducerMessage{
-		Topic: br.topic,
+// send hands an encoded entry to the producer, for the topic of its log level. It returns false once the
+// BrokerCreator is shut down.
+func (br *BrokerCreator) send(level types.LogLevel, value []byte) bool {
+	return br.sendMessage(&sarama.ProducerMessage{
+		Topic: br.topicFor(level),
 		Key:   sarama.StringEncoder("0"),
-		Value: sarama.ByteEncoder(jsonMessage),
+		Value: sarama.ByteEncoder(value),
+	})
+}
+
+// sendMessage hands a message to the producer unless the BrokerCreator is shut down, as writing to the
+// input of a closed producer panics.
+func (br *BrokerCreator) sendMessage(msg *sarama.ProducerMessage) bool {
+	br.inputMutex.RLock()
+	defer br.inputMutex.RUnlock()
+	if br.closed {
+		return false
 	}
+	br.producer.Input() <- msg
 	return true
 }
 
+// SetFormatter sets the formatter rendering the messages sent to the broker, replacing the BrokerMessage
+// JSON format. If the formatter fails for an entry, the entry is sent in the built-in format.
+//
+// Parameters:
+//   - formatter: The formatter to use, or nil for the built-in format.
+func (br *BrokerCreator) SetFormatter(formatter types.Formatter) {
+	br.formatter.Store(formatter)
+}
+
+// LogEntry logs a structured entry with its own level, timestamp, caller and fields, implementing
+// logtor.LogCreatorV2.
+//
+// Parameters:
+//   - entry: The entry to be logged.
+//
+// Returns:
+//   - bool: The result of LogRendered for the entry.
+func (br *BrokerCreator) LogEntry(entry types.LogEntry) bool {
+	return br.LogRendered(entry.Rendered())
+}
+
+// createdCache holds the last formatted timestamp. Timestamps have a resolution of one second,
+// so at high message rates the formatted string is shared instead of allocated per message.
+type createdCache struct {
+	unix int64
+	text string
+}
+
+var lastCreated atomic.Pointer[createdCache]
+
+func formatCreated(now time.Time) string {
+	unix := now.Unix()
+	if cached := lastCreated.Load(); cached != nil && cached.unix == unix {
+		return cached.text
+	}
+	text := now.UTC().Format("2006/01/02 15:04:05")
+	lastCreated.Store(&createdCache{unix: unix, text: text})
+	return text
+}
+
 // LogIt logs a message with the specified log level using the default call depth to the Kafka broker.
 //
 // This method is a convenience wrapper around LogItWithCallDepth, using the call depth configured for the BrokerCreator instance.
@@ -155,7 +412,7 @@ func (br *BrokerCreator) LogItWithCallDepth(level types.LogLevel, callDepth int,
 //   - logMessage: The message to be logged, which can be of any type.
 //
 // Returns:
-//   - bool: Always returns true, indicating the message was successfully logged.
+//   - bool: True if the message was handed to the producer, false once the BrokerCreator is shut down.
 func (br *BrokerCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
 	return br.LogItWithCallDepth(level, br.callDepth, logMessage)
 }
@@ -191,10 +448,76 @@ func (br *BrokerCreator) CallDepth() int {
 // Shutdown gracefully shuts down the BrokerCreator by closing the Kafka producer.
 //
 // Use this method to perform any necessary cleanup or shutdown operations for the log creator.
+// Messages waiting for a retry are dropped. It returns once the remaining producer errors have been
+// reported to the error sink.
 func (br *BrokerCreator) Shutdown() {
+	br.stopRetries()
+	br.inputMutex.Lock()
+	if br.closed {
+		br.inputMutex.Unlock()
+		return
+	}
+	br.closed = true
+	br.inputMutex.Unlock()
 	br.producer.Close()
+	<-br.errorsDone
 }
 
 func (br *BrokerCreator) IsReady() bool {
-	return true
+	br.inputMutex.RLock()
+	defer br.inputMutex.RUnlock()
+	return !br.closed
+}
+
+// InputChannelUsage returns the fraction of the producer's input channel that is filled.
+//
+// LogIt blocks once the input channel is full, so a value approaching 1 signals impending backpressure.
+// The value is read without locking and is cheap enough to poll frequently.
+//
+// Returns:
+//   - float64: A value between 0 (empty) and 1 (full).
+func (br *BrokerCreator) InputChannelUsage() float64 {
+	input := br.producer.Input()
+	if cap(input) == 0 {
+		return 0
+	}
+	return float64(len(input)) / float64(cap(input))
+}
+
+// Describe returns the resolved settings of the BrokerCreator.
+//
+// Returns:
+//   - map[string]interface{}: The creator type, name, broker addresses, topic and call depth.
+func (br *BrokerCreator) Describe() map[string]interface{} {
+	return map[string]interface{}{
+		"type":       brokerTarget,
+		"name":       br.logName,
+		"brokers":    br.brokers,
+		"topic":      br.topic,
+		"call_depth": br.callDepth,
+	}
+}
+
+// CreatorType returns the kind of the log creator.
+//
+// Returns:
+//   - string: "kafka".
+func (br *BrokerCreator) CreatorType() string {
+	return kafkaType
+}
+
+// CreatorVersion returns the version of the log creator implementation.
+//
+// Returns:
+//   - string: The implementation version.
+func (br *BrokerCreator) CreatorVersion() string {
+	return creatorVersion
+}
+
+// CreatorDescription returns a short description of the log creator.
+//
+// Returns:
+//   - string: A description naming the Kafka topic.
+func (br *BrokerCreator) CreatorDescription() string {
+	return "JSON log entries published to Kafka topic " + br.topic
 }
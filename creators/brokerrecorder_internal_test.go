@@ -0,0 +1,232 @@
+package creators
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+)
+
+// discardProducer is an AsyncProducer that drops every message, so benchmarks measure the
+// BrokerCreator alone.
+type discardProducer struct {
+	sarama.AsyncProducer
+	input  chan *sarama.ProducerMessage
+	errors chan *sarama.ProducerError
+	last   *sarama.ProducerMessage
+	all    []*sarama.ProducerMessage
+	keep   bool
+	done   chan struct{}
+}
+
+func newDiscardProducer() *discardProducer {
+	dp := &discardProducer{
+		input:  make(chan *sarama.ProducerMessage),
+		errors: make(chan *sarama.ProducerError),
+		done:   make(chan struct{}),
+	}
+	go func() {
+		defer close(dp.done)
+		for msg := range dp.input {
+			dp.last = msg
+			if dp.keep {
+				dp.all = append(dp.all, msg)
+			}
+		}
+	}()
+	return dp
+}
+
+func (dp *discardProducer) Input() chan<- *sarama.ProducerMessage { return dp.input }
+
+func (dp *discardProducer) Errors() <-chan *sarama.ProducerError { return dp.errors }
+
+func (dp *discardProducer) Close() error {
+	close(dp.input)
+	close(dp.errors)
+	<-dp.done
+	return nil
+}
+
+func TestBrokerCreatorEncodingMatchesMarshal(t *testing.T) {
+	producer := newDiscardProducer()
+	brokerCreator, err := NewBrokerCreatorWithProducer(producer, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	logMessage := map[string]interface{}{"html": "<b>&</b>", "count": 3}
+	brokerCreator.LogIt(types.ERROR, logMessage)
+	brokerCreator.LogIt(types.INFO, "reused state")
+	brokerCreator.Shutdown()
+
+	var message BrokerMessage
+	if err := json.Unmarshal(producer.last.Value.(sarama.ByteEncoder), &message); err != nil {
+		t.Fatal(err)
+	}
+	message.LogMessage = "reused state"
+	expected, _ := json.Marshal(message)
+	if string(producer.last.Value.(sarama.ByteEncoder)) != string(expected) {
+		t.Errorf("encoding differs from json.Marshal:\n got %s\nwant %s", producer.last.Value, expected)
+	}
+	if message.LogLevel != "INFO" || message.Created == "" {
+		t.Errorf("unexpected message: %+v", message)
+	}
+}
+
+func TestBrokerCreatorSetFormatter(t *testing.T) {
+	producer := newDiscardProducer()
+	brokerCreator, err := NewBrokerCreatorWithProducer(producer, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	brokerCreator.SetFormatter(types.LogfmtFormatter{})
+	brokerCreator.LogIt(types.ERROR, "payment failed")
+	brokerCreator.Shutdown()
+
+	value := string(producer.last.Value.(sarama.ByteEncoder))
+	if !strings.Contains(value, "level=ERROR ") || !strings.HasSuffix(value, ` msg="payment failed"`) {
+		t.Errorf("the message should be sent in the formatter's format: %q", value)
+	}
+}
+
+func TestBrokerCreatorSendsFields(t *testing.T) {
+	producer := newDiscardProducer()
+	producer.keep = true
+	brokerCreator, err := NewBrokerCreatorWithProducer(producer, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(brokerCreator)
+	newLogtor.SetLogLevel(types.INFO)
+
+	newLogtor.WithFields(map[string]interface{}{"request_id": "r-1"}).LogIt(types.INFO, "order loaded")
+	brokerCreator.LogIt(types.INFO, types.LogEntry{Message: "callback", Fields: map[string]interface{}{"callback": func() {}}})
+	brokerCreator.LogIt(types.INFO, "plain")
+	newLogtor.Shutdown()
+
+	expected := []string{
+		`"log_message":"order loaded","fields":{"request_id":"r-1"}}`,
+		`"log_message":"callback callback=`,
+		`"log_message":"plain"}`,
+	}
+	if len(producer.all) != len(expected) {
+		t.Fatalf("got %d messages, want %d", len(producer.all), len(expected))
+	}
+	for i, msg := range producer.all {
+		if value := string(msg.Value.(sarama.ByteEncoder)); !strings.Contains(value, expected[i]) {
+			t.Errorf("message %d should contain %s: %s", i, expected[i], value)
+		}
+	}
+}
+
+// useMockProducer makes the BrokerCreator constructors create a mock producer expecting one message,
+// and returns the configuration the last producer was created with.
+func useMockProducer(t *testing.T) **sarama.Config {
+	var captured *sarama.Config
+	previous := newAsyncProducer
+	newAsyncProducer = func(addrs []string, config *sarama.Config) (sarama.AsyncProducer, error) {
+		captured = config
+		return mocks.NewAsyncProducer(t, config).ExpectInputAndSucceed(), nil
+	}
+	t.Cleanup(func() { newAsyncProducer = previous })
+	return &captured
+}
+
+func TestNewBrokerCreatorWithConfig(t *testing.T) {
+	captured := useMockProducer(t)
+	config := sarama.NewConfig()
+	config.ClientID = "orders"
+	brokerCreator, err := NewBrokerCreatorWithConfig([]string{"kafka:9092"}, "logs", "Audit", 2, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *captured != config || brokerCreator.LogName() != "Audit" || brokerCreator.CallDepth() != 2 {
+		t.Errorf("unexpected creator: %s %d", brokerCreator.LogName(), brokerCreator.CallDepth())
+	}
+	brokerCreator.LogIt(types.INFO, "sent")
+	brokerCreator.Shutdown()
+
+	if _, err := NewBrokerCreatorWithConfig([]string{"kafka:9092"}, "", "", 2, nil); err == nil {
+		t.Error("an empty topic should be rejected")
+	}
+	invalid := sarama.NewConfig()
+	invalid.Producer.RequiredAcks = sarama.WaitForAll
+	invalid.Producer.Idempotent = true
+	if _, err := NewBrokerCreatorWithConfig([]string{"kafka:9092"}, "logs", "", 2, invalid); err == nil {
+		t.Error("an invalid config should be rejected")
+	}
+}
+
+func TestNewBrokerCreatorWithTLS(t *testing.T) {
+	captured := useMockProducer(t)
+	tlsConfig := &tls.Config{ServerName: "kafka.internal", MinVersion: tls.VersionTLS12}
+	brokerCreator, err := NewBrokerCreatorWithTLS([]string{"kafka:9093"}, "logs", "", 2, tlsConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config := *captured
+	if !config.Net.TLS.Enable || config.Net.TLS.Config != tlsConfig || config.Producer.Compression != sarama.CompressionSnappy {
+		t.Errorf("unexpected config: %+v", config.Net.TLS)
+	}
+	if brokerCreator.LogName() != Broker {
+		t.Errorf("unexpected name: %s", brokerCreator.LogName())
+	}
+	brokerCreator.LogIt(types.INFO, "sent")
+	brokerCreator.Shutdown()
+}
+
+// BenchmarkBrokerMessageMarshal measures the encoding used before the pooled encoder,
+// as the baseline for BenchmarkBrokerCreatorLogIt.
+func BenchmarkBrokerMessageMarshal(b *testing.B) {
+	producer := newDiscardProducer()
+	defer producer.Close()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, file, line, _ := runtime.Caller(1)
+		message := BrokerMessage{
+			LogLevel:   string(types.INFO),
+			Created:    time.Now().UTC().Format("2006/01/02 15:04:05"),
+			File:       file,
+			Line:       line,
+			LogMessage: "Example Broker Log Message",
+		}
+		jsonMessage, _ := json.Marshal(message)
+		producer.Input() <- &sarama.ProducerMessage{
+			Topic: "test",
+			Key:   sarama.StringEncoder("0"),
+			Value: sarama.ByteEncoder(jsonMessage),
+		}
+	}
+}
+
+func BenchmarkBrokerCreatorLogIt(b *testing.B) {
+	brokerCreator, err := NewBrokerCreatorWithProducer(newDiscardProducer(), "test")
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer brokerCreator.Shutdown()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		brokerCreator.LogIt(types.INFO, "Example Broker Log Message")
+	}
+}
+
+func TestBrokerCreatorLogItAfterShutdown(t *testing.T) {
+	brokerCreator, err := NewBrokerCreatorWithProducer(mocks.NewAsyncProducer(t, nil), "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	brokerCreator.Shutdown()
+	if brokerCreator.LogIt(types.INFO, "after shutdown") || brokerCreator.IsReady() {
+		t.Error("the BrokerCreator should not accept messages after Shutdown")
+	}
+	brokerCreator.Shutdown()
+}
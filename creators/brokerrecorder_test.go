@@ -13,12 +13,19 @@
 package creators_test
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Eyup-Devop/logtor/creators"
 	"github.com/Eyup-Devop/logtor/types"
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
 )
 
 var brokers = []string{"127.0.0.1:19092"}
@@ -99,3 +106,69 @@ func TestBrokerCreatorWithJson(t *testing.T) {
 	time.Sleep(time.Second * 2)
 	brokerCreator.Shutdown()
 }
+
+// TestBrokerCreatorInputChannelUsage tests the input channel usage reported for a mock producer.
+//
+// A mock producer is injected with NewBrokerCreatorWithProducer, so no Kafka broker is required.
+// The mock verifies on Shutdown that the expected message was published.
+func TestBrokerCreatorInputChannelUsage(t *testing.T) {
+	config := sarama.NewConfig()
+	config.ChannelBufferSize = 4
+	producer := mocks.NewAsyncProducer(t, config)
+
+	brokerCreator, err := creators.NewBrokerCreatorWithProducer(producer, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage := brokerCreator.InputChannelUsage(); usage != 0 {
+		t.Errorf("unexpected usage for an idle producer: %v", usage)
+	}
+	if brokerCreator.LogName() != creators.Broker {
+		t.Errorf("unexpected default name: %s", brokerCreator.LogName())
+	}
+
+	producer.ExpectInputAndSucceed()
+	if result := brokerCreator.LogIt(types.ERROR, "Example Log Message"); !result {
+		t.Error("Log not recorded")
+	}
+	brokerCreator.Shutdown()
+}
+
+// TestBrokerCreatorErrorSink tests that producer errors are reported to the error sink at ERROR level
+// and that the failed message is written to the fail writer.
+func TestBrokerCreatorErrorSink(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "errors.log")
+	sink, err := creators.NewFileCreatorWithOptions(filename, creators.WithName("Errors"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var failWriter bytes.Buffer
+	producer := mocks.NewAsyncProducer(t, nil)
+	brokerCreator, err := creators.NewBrokerCreatorWithProducer(producer, "test",
+		creators.WithErrorSink(sink),
+		creators.WithFailWriter(&failWriter),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	producer.ExpectInputAndFail(sarama.ErrOutOfBrokers)
+	brokerCreator.LogIt(types.ERROR, "Example Log Message")
+	brokerCreator.Shutdown()
+
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "ERROR") || !strings.Contains(string(content), sarama.ErrOutOfBrokers.Error()) {
+		t.Errorf("producer error not reported to the sink: %q", content)
+	}
+	value, err := base64.StdEncoding.DecodeString(strings.TrimSpace(failWriter.String()))
+	if err != nil || !strings.Contains(string(value), "Example Log Message") {
+		t.Errorf("failed message not written to the fail writer: %q", failWriter.String())
+	}
+
+	if _, err := creators.NewBaseCreatorWithOptions(creators.WithErrorSink(sink)); err == nil {
+		t.Error("WithErrorSink should only be supported by the broker creator")
+	}
+}
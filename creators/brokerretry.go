@@ -0,0 +1,138 @@
+package creators
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+	"github.com/IBM/sarama"
+)
+
+// RetryConfig configures how the BrokerCreator retries the messages its producer failed to deliver.
+//
+// A failed message is sent again after InitialDelay, doubled for every further attempt up to MaxDelay,
+// until it was retried MaxRetries times. The retries of a message are counted in its Metadata field.
+// Messages that exhaust their retries, or are still waiting for a retry when the BrokerCreator is shut
+// down, are dropped: they are reported to the error sink, written to the fail writer and passed to
+// OnDropped.
+//
+// Fields:
+//   - MaxRetries: The number of times a message is retried; zero drops failed messages at once.
+//   - InitialDelay: The delay before the first retry; zero or less means 100 milliseconds.
+//   - MaxDelay: The longest delay between two attempts; zero or less leaves the delay unbounded.
+//   - OnDropped: An optional callback receiving every dropped message and its last error.
+type RetryConfig struct {
+	MaxRetries   int
+	InitialDelay time.Duration
+	MaxDelay     time.Duration
+	OnDropped    func(msg *sarama.ProducerMessage, err error)
+}
+
+// WithRetry makes the BrokerCreator retry the messages its producer failed to deliver. Only the broker
+// creator supports it.
+//
+// Parameters:
+//   - config: The retry configuration. MaxRetries must not be negative.
+func WithRetry(config RetryConfig) Option {
+	return func(o *creatorOptions) error {
+		if err := o.supports("WithRetry", brokerTarget); err != nil {
+			return err
+		}
+		if config.MaxRetries < 0 {
+			return fmt.Errorf("creators: max retries must not be negative, got %d", config.MaxRetries)
+		}
+		if config.InitialDelay <= 0 {
+			config.InitialDelay = 100 * time.Millisecond
+		}
+		o.retry = config
+		return nil
+	}
+}
+
+// delay returns the delay before the given retry, starting at 1.
+func (rc RetryConfig) delay(retry int) time.Duration {
+	delay := rc.InitialDelay
+	for i := 1; i < retry; i++ {
+		if rc.MaxDelay > 0 && delay >= rc.MaxDelay {
+			break
+		}
+		delay *= 2
+	}
+	if rc.MaxDelay > 0 && delay > rc.MaxDelay {
+		delay = rc.MaxDelay
+	}
+	return delay
+}
+
+// brokerRetries holds the messages of a BrokerCreator waiting for a retry.
+type brokerRetries struct {
+	// mutex guards closing and pending.
+	mutex   sync.Mutex
+	closing bool
+	pending map[*sarama.ProducerError]*time.Timer
+}
+
+// retryOrDrop schedules a failed message for another attempt, or drops it once its retries are used up
+// or the BrokerCreator is shutting down. It is called by the goroutine reading the producer errors.
+func (br *BrokerCreator) retryOrDrop(failed *sarama.ProducerError) {
+	retry, _ := failed.Msg.Metadata.(int)
+	if retry >= br.retry.MaxRetries {
+		br.drop(failed)
+		return
+	}
+	br.retries.mutex.Lock()
+	if br.retries.closing {
+		br.retries.mutex.Unlock()
+		br.drop(failed)
+		return
+	}
+	failed.Msg.Metadata = retry + 1
+	if br.retries.pending == nil {
+		br.retries.pending = make(map[*sarama.ProducerError]*time.Timer)
+	}
+	br.retries.pending[failed] = time.AfterFunc(br.retry.delay(retry+1), func() {
+		br.retries.mutex.Lock()
+		if _, ok := br.retries.pending[failed]; !ok {
+			br.retries.mutex.Unlock()
+			return
+		}
+		delete(br.retries.pending, failed)
+		br.retries.mutex.Unlock()
+		if !br.sendMessage(failed.Msg) {
+			br.drop(failed)
+		}
+	})
+	br.retries.mutex.Unlock()
+}
+
+// stopRetries stops scheduling retries and drops the messages still waiting for a retry. It is called by
+// Shutdown before the producer is closed.
+func (br *BrokerCreator) stopRetries() {
+	br.retries.mutex.Lock()
+	br.retries.closing = true
+	pending := br.retries.pending
+	br.retries.pending = nil
+	br.retries.mutex.Unlock()
+	for failed, timer := range pending {
+		timer.Stop()
+		br.drop(failed)
+	}
+}
+
+// drop reports a message that will not be delivered to the error sink, the fail writer and OnDropped.
+// Dropped messages are reported one at a time.
+func (br *BrokerCreator) drop(failed *sarama.ProducerError) {
+	br.dropMutex.Lock()
+	defer br.dropMutex.Unlock()
+	br.errorSink.LogIt(types.ERROR, fmt.Sprintf("%s: %v", br.logName, failed))
+	if br.failLog != nil {
+		if value, ok := failed.Msg.Value.(sarama.ByteEncoder); ok {
+			br.failLog.Println(base64.StdEncoding.EncodeToString(value))
+		}
+	}
+	if br.retry.OnDropped != nil {
+		br.retry.OnDropped(failed.Msg, failed.Err)
+	}
+}
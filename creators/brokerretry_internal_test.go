@@ -0,0 +1,92 @@
+package creators
+
+import (
+	"bytes"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+)
+
+func TestRetryConfigDelay(t *testing.T) {
+	config := RetryConfig{InitialDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	for retry, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 5: time.Second, 60: time.Second} {
+		if delay := config.delay(retry); delay != expected {
+			t.Errorf("retry %d: got %s want %s", retry, delay, expected)
+		}
+	}
+}
+
+func TestBrokerCreatorRetriesFailedMessages(t *testing.T) {
+	brokerErr := errors.New("leader not available")
+	config := mocks.NewTestConfig()
+	config.Producer.Return.Successes = true
+	producer := mocks.NewAsyncProducer(t, config).ExpectInputAndFail(brokerErr).ExpectInputAndFail(brokerErr).ExpectInputAndSucceed()
+	brokerCreator, err := NewBrokerCreatorWithProducer(producer, "logs", WithRetry(RetryConfig{MaxRetries: 2, InitialDelay: time.Millisecond}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	brokerCreator.LogIt(types.INFO, "delivered on the third attempt")
+
+	select {
+	case msg := <-producer.Successes():
+		if msg.Metadata != 2 {
+			t.Errorf("the message should have been retried twice, got %v", msg.Metadata)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the message was not delivered")
+	}
+	brokerCreator.Shutdown()
+}
+
+func TestBrokerCreatorDropsExhaustedMessages(t *testing.T) {
+	brokerErr := errors.New("leader not available")
+	producer := mocks.NewAsyncProducer(t, nil).ExpectInputAndFail(brokerErr).ExpectInputAndFail(brokerErr)
+	var (
+		mutex   sync.Mutex
+		dropped []error
+	)
+	failWriter := &bytes.Buffer{}
+	brokerCreator, err := NewBrokerCreatorWithProducer(producer, "logs", WithFailWriter(failWriter), WithRetry(RetryConfig{
+		MaxRetries:   1,
+		InitialDelay: time.Millisecond,
+		OnDropped: func(msg *sarama.ProducerMessage, err error) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			dropped = append(dropped, err)
+		},
+	}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	brokerCreator.LogIt(types.INFO, "never delivered")
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		mutex.Lock()
+		count := len(dropped)
+		mutex.Unlock()
+		if count > 0 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	brokerCreator.Shutdown()
+	if len(dropped) != 1 || !errors.Is(dropped[0], brokerErr) {
+		t.Errorf("unexpected dropped messages: %v", dropped)
+	}
+	if failWriter.Len() == 0 {
+		t.Error("the dropped message should be written to the fail writer")
+	}
+
+	if _, err := NewBrokerCreatorWithProducer(mocks.NewAsyncProducer(t, nil), "logs", WithRetry(RetryConfig{MaxRetries: -1})); err == nil {
+		t.Error("negative max retries should be rejected")
+	}
+	if _, err := NewBaseCreatorWithOptions(WithRetry(RetryConfig{MaxRetries: 1})); err == nil {
+		t.Error("WithRetry should only be supported by the broker creator")
+	}
+}
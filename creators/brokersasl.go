@@ -0,0 +1,196 @@
+package creators
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"strconv"
+	"strings"
+
+	"github.com/Eyup-Devop/logtor/types"
+	"github.com/IBM/sarama"
+)
+
+// NewBrokerCreatorWithSASL creates a new instance of BrokerCreator authenticating to the brokers with SASL.
+//
+// It applies the SASL settings to the configuration of NewBrokerCreator and delegates to
+// NewBrokerCreatorWithConfig. SCRAM-SHA-256 and SCRAM-SHA-512 use the SCRAM client of this package;
+// PLAIN sends the password as is and should only be used over TLS.
+//
+// Parameters:
+//   - brokers: A list of Kafka broker addresses.
+//   - topic: The Kafka topic to publish log messages.
+//   - mechanism: The SASL mechanism: "SCRAM-SHA-256", "SCRAM-SHA-512" or "PLAIN".
+//   - username: The user name to authenticate as.
+//   - password: The password of the user.
+//   - logName: The name representing the log creator (e.g., Broker).
+//   - callDepth: The call depth to be used in log output.
+//
+// Returns:
+//   - *BrokerCreator: A pointer to the newly created BrokerCreator.
+//   - error: An error if the mechanism is not supported, the user name is empty, or initialization fails.
+func NewBrokerCreatorWithSASL(brokers []string, topic string, mechanism, username, password string, logName types.LogCreatorName, callDepth int) (*BrokerCreator, error) {
+	config := newBrokerConfig()
+	if err := applySASL(config, mechanism, username, password); err != nil {
+		return nil, err
+	}
+	return NewBrokerCreatorWithConfig(brokers, topic, logName, callDepth, config)
+}
+
+// applySASL enables SASL authentication with the mechanism in a producer configuration.
+func applySASL(config *sarama.Config, mechanism, username, password string) error {
+	if username == "" {
+		return fmt.Errorf("creators: SASL user name must not be empty")
+	}
+	switch mechanism {
+	case sarama.SASLTypeSCRAMSHA256:
+		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &scramClient{hash: sha256.New} }
+	case sarama.SASLTypeSCRAMSHA512:
+		config.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &scramClient{hash: sha512.New} }
+	case sarama.SASLTypePlaintext:
+	default:
+		return fmt.Errorf("creators: unsupported SASL mechanism %q", mechanism)
+	}
+	config.Net.SASL.Enable = true
+	config.Net.SASL.Mechanism = sarama.SASLMechanism(mechanism)
+	config.Net.SASL.User = username
+	config.Net.SASL.Password = password
+	return nil
+}
+
+// scramClient is a sarama.SCRAMClient implementing the client side of the SCRAM exchange of RFC 5802.
+// Passwords are used as given, without SASLprep normalization.
+type scramClient struct {
+	hash func() hash.Hash
+	// nonce is the client nonce, generated by Begin unless it is already set.
+	nonce string
+	// gs2Header and clientFirstBare are the parts of the client-first message the proof is computed over.
+	gs2Header       string
+	clientFirstBare string
+	username        string
+	password        string
+	serverSignature []byte
+	step            int
+	done            bool
+}
+
+// Begin prepares the exchange for the user.
+func (sc *scramClient) Begin(userName, password, authzID string) error {
+	if sc.nonce == "" {
+		random := make([]byte, 24)
+		if _, err := rand.Read(random); err != nil {
+			return err
+		}
+		sc.nonce = base64.RawStdEncoding.EncodeToString(random)
+	}
+	sc.username, sc.password = userName, password
+	sc.gs2Header = "n,,"
+	if authzID != "" {
+		sc.gs2Header = "n,a=" + scramName(authzID) + ","
+	}
+	sc.step, sc.done = 0, false
+	return nil
+}
+
+// Step answers a challenge of the server: the empty challenge starting the exchange, the server-first
+// message, and the server-final message.
+func (sc *scramClient) Step(challenge string) (string, error) {
+	sc.step++
+	switch sc.step {
+	case 1:
+		sc.clientFirstBare = "n=" + scramName(sc.username) + ",r=" + sc.nonce
+		return sc.gs2Header + sc.clientFirstBare, nil
+	case 2:
+		return sc.clientFinal(challenge)
+	case 3:
+		sc.done = true
+		attributes := scramAttributes(challenge)
+		if message, ok := attributes["e"]; ok {
+			return "", fmt.Errorf("creators: SCRAM authentication failed: %s", message)
+		}
+		signature, err := base64.StdEncoding.DecodeString(attributes["v"])
+		if err != nil || !hmac.Equal(signature, sc.serverSignature) {
+			return "", fmt.Errorf("creators: SCRAM server signature does not match")
+		}
+		return "", nil
+	}
+	return "", fmt.Errorf("creators: unexpected SCRAM challenge")
+}
+
+// clientFinal computes the client-final message with the proof for the server-first message.
+func (sc *scramClient) clientFinal(serverFirst string) (string, error) {
+	attributes := scramAttributes(serverFirst)
+	nonce := attributes["r"]
+	if !strings.HasPrefix(nonce, sc.nonce) || len(nonce) == len(sc.nonce) {
+		return "", fmt.Errorf("creators: SCRAM server nonce does not extend the client nonce")
+	}
+	salt, err := base64.StdEncoding.DecodeString(attributes["s"])
+	if err != nil {
+		return "", fmt.Errorf("creators: invalid SCRAM salt: %w", err)
+	}
+	iterations, err := strconv.Atoi(attributes["i"])
+	if err != nil || iterations < 1 {
+		return "", fmt.Errorf("creators: invalid SCRAM iteration count %q", attributes["i"])
+	}
+
+	saltedPassword := sc.hi([]byte(sc.password), salt, iterations)
+	clientKey := sc.hmac(saltedPassword, []byte("Client Key"))
+	storedKey := sc.hash()
+	storedKey.Write(clientKey)
+	withoutProof := "c=" + base64.StdEncoding.EncodeToString([]byte(sc.gs2Header)) + ",r=" + nonce
+	authMessage := []byte(sc.clientFirstBare + "," + serverFirst + "," + withoutProof)
+	proof := sc.hmac(storedKey.Sum(nil), authMessage)
+	for i := range proof {
+		proof[i] ^= clientKey[i]
+	}
+	sc.serverSignature = sc.hmac(sc.hmac(saltedPassword, []byte("Server Key")), authMessage)
+	return withoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof), nil
+}
+
+// Done reports whether the exchange is over.
+func (sc *scramClient) Done() bool {
+	return sc.done
+}
+
+func (sc *scramClient) hmac(key, message []byte) []byte {
+	mac := hmac.New(sc.hash, key)
+	mac.Write(message)
+	return mac.Sum(nil)
+}
+
+// hi is the Hi function of RFC 5802, PBKDF2 with the HMAC of the hash and an output of one hash length.
+func (sc *scramClient) hi(password, salt []byte, iterations int) []byte {
+	block := make([]byte, len(salt)+4)
+	copy(block, salt)
+	binary.BigEndian.PutUint32(block[len(salt):], 1)
+	u := sc.hmac(password, block)
+	result := append([]byte(nil), u...)
+	for i := 1; i < iterations; i++ {
+		u = sc.hmac(password, u)
+		for j := range result {
+			result[j] ^= u[j]
+		}
+	}
+	return result
+}
+
+// scramName escapes a user name for a SCRAM message.
+func scramName(name string) string {
+	return strings.NewReplacer("=", "=3D", ",", "=2C").Replace(name)
+}
+
+// scramAttributes parses the comma-separated key=value attributes of a SCRAM message.
+func scramAttributes(message string) map[string]string {
+	attributes := make(map[string]string)
+	for _, attribute := range strings.Split(message, ",") {
+		if key, value, ok := strings.Cut(attribute, "="); ok {
+			attributes[key] = value
+		}
+	}
+	return attributes
+}
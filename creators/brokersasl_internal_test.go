@@ -0,0 +1,94 @@
+package creators
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor/types"
+	"github.com/IBM/sarama"
+)
+
+// TestSCRAMClient runs the SCRAM-SHA-256 example exchange of RFC 7677.
+func TestSCRAMClient(t *testing.T) {
+	client := &scramClient{hash: sha256.New, nonce: "rOprNGfwEbeRWgbNEkqO"}
+	if err := client.Begin("user", "pencil", ""); err != nil {
+		t.Fatal(err)
+	}
+	exchange := []struct{ challenge, response string }{
+		{"", "n,,n=user,r=rOprNGfwEbeRWgbNEkqO"},
+		{
+			"r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096",
+			"c=biws,r=rOprNGfwEbeRWgbNEkqO%hvYDpWUa2RaTCAfuxFIlj)hNlF$k0,p=dHzbZapWIk4jUhN+Ute9ytag9zjfMHgsqmmiz7AndVQ=",
+		},
+		{"v=6rriTRBi23WpRR/wtup+mMhUZUn/dB5nLTJRsjl95G4=", ""},
+	}
+	for i, step := range exchange {
+		if client.Done() {
+			t.Fatalf("step %d: the exchange should not be over", i)
+		}
+		response, err := client.Step(step.challenge)
+		if err != nil || response != step.response {
+			t.Fatalf("step %d: got %q, %v want %q", i, response, err, step.response)
+		}
+	}
+	if !client.Done() {
+		t.Error("the exchange should be over")
+	}
+
+	client = &scramClient{hash: sha256.New, nonce: "rOprNGfwEbeRWgbNEkqO"}
+	client.Begin("user", "pencil", "")
+	client.Step("")
+	client.Step(exchange[1].challenge)
+	if _, err := client.Step("v=AAAA"); err == nil {
+		t.Error("a wrong server signature should fail the exchange")
+	}
+	client = &scramClient{hash: sha256.New, nonce: "rOprNGfwEbeRWgbNEkqO"}
+	client.Begin("user", "pencil", "")
+	client.Step("")
+	if _, err := client.Step("r=forged,s=W22ZaJ0SNY7soEsUEjb6gQ==,i=4096"); err == nil {
+		t.Error("a server nonce not extending the client nonce should fail the exchange")
+	}
+}
+
+func TestNewBrokerCreatorWithSASL(t *testing.T) {
+	for _, mechanism := range []string{sarama.SASLTypeSCRAMSHA256, sarama.SASLTypeSCRAMSHA512, sarama.SASLTypePlaintext} {
+		captured := useMockProducer(t)
+		brokerCreator, err := NewBrokerCreatorWithSASL([]string{"kafka:9092"}, "logs", mechanism, "logger", "secret", "", 2)
+		if err != nil {
+			t.Fatalf("%s: %v", mechanism, err)
+		}
+		sasl := (*captured).Net.SASL
+		if !sasl.Enable || string(sasl.Mechanism) != mechanism || sasl.User != "logger" || sasl.Password != "secret" {
+			t.Errorf("%s: unexpected SASL config: %+v", mechanism, sasl)
+		}
+		if (sasl.SCRAMClientGeneratorFunc != nil) != (mechanism != sarama.SASLTypePlaintext) {
+			t.Errorf("%s: the SCRAM client should be set for SCRAM mechanisms only", mechanism)
+		}
+		brokerCreator.LogIt(types.INFO, "sent")
+		brokerCreator.Shutdown()
+	}
+
+	if _, err := NewBrokerCreatorWithSASL([]string{"kafka:9092"}, "logs", "GSSAPI", "logger", "secret", "", 2); err == nil {
+		t.Error("an unsupported mechanism should be rejected")
+	}
+	if _, err := NewBrokerCreatorWithSASL([]string{"kafka:9092"}, "logs", sarama.SASLTypePlaintext, "", "secret", "", 2); err == nil {
+		t.Error("an empty user name should be rejected")
+	}
+}
+
+func TestWithSASLPlain(t *testing.T) {
+	captured := useMockProducer(t)
+	brokerCreator, err := NewBrokerCreatorWithOptions([]string{"kafka:9092"}, "logs", WithSASLPlain("logger", "secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sasl := (*captured).Net.SASL; !sasl.Enable || sasl.Mechanism != sarama.SASLTypePlaintext || sasl.User != "logger" {
+		t.Errorf("unexpected SASL config: %+v", sasl)
+	}
+	brokerCreator.LogIt(types.INFO, "sent")
+	brokerCreator.Shutdown()
+
+	if _, err := NewBaseCreatorWithOptions(WithSASLPlain("logger", "secret")); err == nil {
+		t.Error("WithSASLPlain should only be supported by the broker creator")
+	}
+}
@@ -0,0 +1,49 @@
+package creators
+
+import (
+	"strings"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// TopicRouter returns the Kafka topic the BrokerCreator publishes the messages of a log level to.
+type TopicRouter func(level types.LogLevel) string
+
+// LevelTopicRouter returns a TopicRouter publishing every log level to its own topic, named after the
+// prefix and the lower-case log level, e.g. "logs-error" for the prefix "logs".
+//
+// Parameters:
+//   - prefix: The prefix of the topic names.
+//
+// Returns:
+//   - func(types.LogLevel) string: The topic router.
+func LevelTopicRouter(prefix string) func(types.LogLevel) string {
+	return func(level types.LogLevel) string {
+		return prefix + "-" + strings.ToLower(string(level))
+	}
+}
+
+// SetTopicRouter sets the router choosing the topic of every message by its log level. Messages the router
+// returns an empty topic for, and all messages without a router, are published to the topic the
+// BrokerCreator was created with.
+//
+// Parameters:
+//   - router: The topic router to use, or nil to publish every message to the static topic.
+func (br *BrokerCreator) SetTopicRouter(router func(types.LogLevel) string) {
+	if router == nil {
+		br.topicRouter.Store(nil)
+		return
+	}
+	topicRouter := TopicRouter(router)
+	br.topicRouter.Store(&topicRouter)
+}
+
+// topicFor returns the topic of a message of the log level.
+func (br *BrokerCreator) topicFor(level types.LogLevel) string {
+	if router := br.topicRouter.Load(); router != nil {
+		if topic := (*router)(level); topic != "" {
+			return topic
+		}
+	}
+	return br.topic
+}
@@ -0,0 +1,46 @@
+package creators_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+)
+
+func TestLevelTopicRouter(t *testing.T) {
+	router := creators.LevelTopicRouter("logs")
+	if topic := router(types.ERROR); topic != "logs-error" {
+		t.Errorf("unexpected topic: %s", topic)
+	}
+}
+
+func TestBrokerCreatorSetTopicRouter(t *testing.T) {
+	expectTopic := func(topic string) mocks.MessageChecker {
+		return func(msg *sarama.ProducerMessage) error {
+			if msg.Topic != topic {
+				return fmt.Errorf("got topic %s want %s", msg.Topic, topic)
+			}
+			return nil
+		}
+	}
+	producer := mocks.NewAsyncProducer(t, nil).
+		ExpectInputWithMessageCheckerFunctionAndSucceed(expectTopic("logs")).
+		ExpectInputWithMessageCheckerFunctionAndSucceed(expectTopic("logs-error")).
+		ExpectInputWithMessageCheckerFunctionAndSucceed(expectTopic("logs-info")).
+		ExpectInputWithMessageCheckerFunctionAndSucceed(expectTopic("logs"))
+	brokerCreator, err := creators.NewBrokerCreatorWithProducer(producer, "logs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	brokerCreator.LogIt(types.ERROR, "static topic")
+	brokerCreator.SetTopicRouter(creators.LevelTopicRouter("logs"))
+	brokerCreator.LogIt(types.ERROR, "error topic")
+	brokerCreator.LogRendered(types.RenderedEntry{Level: types.INFO, Message: "info topic"})
+	brokerCreator.SetTopicRouter(nil)
+	brokerCreator.LogIt(types.WARN, "static topic again")
+	brokerCreator.Shutdown()
+}
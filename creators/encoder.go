@@ -0,0 +1,62 @@
+package creators
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// maxPooledBufferSize keeps occasional huge messages from pinning large buffers in the pools.
+const maxPooledBufferSize = 64 * 1024
+
+// jsonEncoderState is the reusable encoding state of a single BrokerMessage.
+type jsonEncoderState struct {
+	message BrokerMessage
+	buffer  bytes.Buffer
+	encoder *json.Encoder
+}
+
+var jsonEncoderPool = sync.Pool{
+	New: func() interface{} {
+		state := &jsonEncoderState{}
+		state.encoder = json.NewEncoder(&state.buffer)
+		return state
+	},
+}
+
+// appendEntryJSON appends the JSON encoding of message to dst, without a trailing newline, and returns
+// the extended slice. It is the encoder shared by every creator that writes BrokerMessage JSON.
+//
+// The output is the same as json.Marshal, or json.MarshalIndent with two spaces when pretty is set.
+// If the log message cannot be encoded, it is replaced by its "%+v" representation and the error is
+// reported in the encoding_error field, so the entry is never lost or emitted as null.
+func appendEntryJSON(dst []byte, message BrokerMessage, pretty bool) []byte {
+	state := jsonEncoderPool.Get().(*jsonEncoderState)
+	if pretty {
+		state.encoder.SetIndent("", "  ")
+	} else {
+		state.encoder.SetIndent("", "")
+	}
+	state.message = message
+	state.buffer.Reset()
+	if err := state.encoder.Encode(&state.message); err != nil {
+		state.message.LogMessage = fmt.Sprintf("%+v", message.LogMessage)
+		if message.Fields != nil {
+			state.message.LogMessage = types.LogEntry{Message: message.LogMessage, Fields: message.Fields}.String()
+			state.message.Fields = nil
+		}
+		state.message.EncodingError = err.Error()
+		state.buffer.Reset()
+		state.encoder.Encode(&state.message)
+	}
+	dst = append(dst, bytes.TrimSuffix(state.buffer.Bytes(), []byte("\n"))...)
+
+	state.message = BrokerMessage{}
+	if state.buffer.Cap() <= maxPooledBufferSize {
+		jsonEncoderPool.Put(state)
+	}
+	return dst
+}
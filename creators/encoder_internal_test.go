@@ -0,0 +1,114 @@
+package creators
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// orderPayload is a typical structured log payload.
+type orderPayload struct {
+	OrderID  string   `json:"order_id"`
+	Customer string   `json:"customer"`
+	Items    []string `json:"items"`
+	Total    float64  `json:"total"`
+	Retries  int      `json:"retries"`
+}
+
+var examplePayload = orderPayload{
+	OrderID:  "ord-1029",
+	Customer: "c-77",
+	Items:    []string{"book", "pen"},
+	Total:    42.5,
+	Retries:  1,
+}
+
+func TestAppendEntryJSONMatchesMarshal(t *testing.T) {
+	message := BrokerMessage{LogLevel: "INFO", Created: "2024/01/02 03:04:05", File: "main.go", Line: 7, LogMessage: examplePayload}
+
+	compact := appendEntryJSON([]byte("prefix:"), message, false)
+	expected, _ := json.Marshal(message)
+	if string(compact) != "prefix:"+string(expected) {
+		t.Errorf("compact encoding differs from json.Marshal:\n got %s\nwant prefix:%s", compact, expected)
+	}
+
+	pretty := appendEntryJSON(nil, message, true)
+	expected, _ = json.MarshalIndent(message, "", "  ")
+	if string(pretty) != string(expected) {
+		t.Errorf("pretty encoding differs from json.MarshalIndent:\n got %s\nwant %s", pretty, expected)
+	}
+}
+
+func TestAppendEntryJSONFallsBackOnError(t *testing.T) {
+	message := BrokerMessage{LogLevel: "ERROR", LogMessage: map[string]interface{}{"callback": func() {}}}
+
+	var decoded BrokerMessage
+	if err := json.Unmarshal(appendEntryJSON(nil, message, false), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if text, ok := decoded.LogMessage.(string); !ok || !strings.HasPrefix(text, "map[callback:") {
+		t.Errorf("log message should fall back to its text form, got %#v", decoded.LogMessage)
+	}
+	if !strings.Contains(decoded.EncodingError, "unsupported type") {
+		t.Errorf("missing encoding error note: %q", decoded.EncodingError)
+	}
+}
+
+func TestBaseCreatorPrettyJSON(t *testing.T) {
+	logCreator, err := NewBaseCreatorWithOptions(WithPrettyJSON(true), WithCallDepth(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var output bytes.Buffer
+	baseCreator := logCreator.(*BaseCreator)
+	baseCreator.log.SetOutput(&output)
+
+	if result := baseCreator.LogIt(types.INFO, examplePayload); !result {
+		t.Fatal("Log not recorded")
+	}
+	if !strings.Contains(output.String(), "\n  \"loglevel\": \"INFO\"") {
+		t.Errorf("output is not indented: %q", output.String())
+	}
+	var message BrokerMessage
+	if err := json.Unmarshal(output.Bytes(), &message); err != nil {
+		t.Fatalf("output is not JSON: %q", output.String())
+	}
+}
+
+// BenchmarkBaseCreatorJSONMarshal measures the json.Marshal based encoding used before the shared
+// encoder, as the baseline for BenchmarkBaseCreatorJSON.
+func BenchmarkBaseCreatorJSONMarshal(b *testing.B) {
+	logger := log.New(io.Discard, "", 0)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_, file, line, _ := runtime.Caller(1)
+		jsonMessage, _ := json.Marshal(BrokerMessage{
+			LogLevel:   string(types.INFO),
+			Created:    time.Now().UTC().Format("2006/01/02 15:04:05"),
+			File:       file,
+			Line:       line,
+			LogMessage: examplePayload,
+		})
+		logger.Writer().Write(append(jsonMessage, '\n'))
+	}
+}
+
+func BenchmarkBaseCreatorJSON(b *testing.B) {
+	logCreator, err := NewBaseCreatorWithOptions(WithJSONFormat(true))
+	if err != nil {
+		b.Fatal(err)
+	}
+	baseCreator := logCreator.(*BaseCreator)
+	baseCreator.log.SetOutput(io.Discard)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		baseCreator.LogIt(types.INFO, examplePayload)
+	}
+}
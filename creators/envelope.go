@@ -0,0 +1,117 @@
+package creators
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// EnvelopeFormatter renders a batch of log entries into the request body expected by a log ingestion API.
+//
+// Formatters let a batching creator talk to different ingestors (a plain JSON endpoint, Logstash, Loki)
+// without changing how the entries themselves are built.
+type EnvelopeFormatter interface {
+	// FormatBatch renders the entries into a single request body.
+	FormatBatch(msgs []BrokerMessage) ([]byte, error)
+}
+
+// JSONArrayEnvelope is an EnvelopeFormatter that renders the batch as a flat JSON array of BrokerMessage.
+type JSONArrayEnvelope struct{}
+
+// FormatBatch renders the entries as a JSON array.
+//
+// Parameters:
+//   - msgs: The entries of the batch.
+//
+// Returns:
+//   - []byte: The JSON array, "[]" for an empty batch.
+//   - error: An error if an entry cannot be marshaled.
+func (JSONArrayEnvelope) FormatBatch(msgs []BrokerMessage) ([]byte, error) {
+	if msgs == nil {
+		msgs = []BrokerMessage{}
+	}
+	return json.Marshal(msgs)
+}
+
+// NDJSONEnvelope is an EnvelopeFormatter that renders the batch as newline-delimited JSON, one BrokerMessage
+// per line, as accepted by the Logstash http input and the Splunk raw endpoint.
+type NDJSONEnvelope struct{}
+
+// FormatBatch renders the entries as newline-delimited JSON.
+//
+// Parameters:
+//   - msgs: The entries of the batch.
+//
+// Returns:
+//   - []byte: One JSON object per line, each terminated by a newline.
+//   - error: An error if an entry cannot be marshaled.
+func (NDJSONEnvelope) FormatBatch(msgs []BrokerMessage) ([]byte, error) {
+	var buffer bytes.Buffer
+	encoder := json.NewEncoder(&buffer)
+	for _, msg := range msgs {
+		if err := encoder.Encode(msg); err != nil {
+			return nil, err
+		}
+	}
+	return buffer.Bytes(), nil
+}
+
+// LokiPushEnvelope returns an EnvelopeFormatter rendering the batch as a Loki push API request
+// (POST /loki/api/v1/push).
+//
+// All entries are sent in a single stream identified by the labels. Each entry becomes a value whose
+// timestamp is taken from BrokerMessage.Created and whose line is the JSON encoded BrokerMessage, so it
+// can be queried with Loki's json parser.
+//
+// Parameters:
+//   - labels: The stream labels, e.g. {"app": "orders", "env": "prod"}. The map is copied.
+//
+// Returns:
+//   - EnvelopeFormatter: The Loki push formatter.
+func LokiPushEnvelope(labels map[string]string) EnvelopeFormatter {
+	copied := make(map[string]string, len(labels))
+	for key, value := range labels {
+		copied[key] = value
+	}
+	return &lokiPushEnvelope{labels: copied}
+}
+
+type lokiPushEnvelope struct {
+	labels map[string]string
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// FormatBatch renders the entries as a Loki push request.
+//
+// Entries whose Created field cannot be parsed are stamped with the current time.
+//
+// Parameters:
+//   - msgs: The entries of the batch.
+//
+// Returns:
+//   - []byte: The Loki push request body.
+//   - error: An error if an entry cannot be marshaled.
+func (le *lokiPushEnvelope) FormatBatch(msgs []BrokerMessage) ([]byte, error) {
+	stream := lokiStream{Stream: le.labels, Values: make([][2]string, 0, len(msgs))}
+	for _, msg := range msgs {
+		line, err := json.Marshal(msg)
+		if err != nil {
+			return nil, err
+		}
+		created, err := time.Parse("2006/01/02 15:04:05", msg.Created)
+		if err != nil {
+			created = time.Now()
+		}
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(created.UnixNano(), 10), string(line)})
+	}
+	return json.Marshal(struct {
+		Streams []lokiStream `json:"streams"`
+	}{
+		Streams: []lokiStream{stream},
+	})
+}
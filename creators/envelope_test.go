@@ -0,0 +1,76 @@
+package creators_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor/creators"
+)
+
+var envelopeBatch = []creators.BrokerMessage{
+	{LogLevel: "INFO", Created: "2024/01/02 03:04:05", File: "main.go", Line: 10, LogMessage: "first"},
+	{LogLevel: "ERROR", Created: "2024/01/02 03:04:06", File: "main.go", Line: 11, LogMessage: "second"},
+}
+
+func TestJSONArrayEnvelope(t *testing.T) {
+	body, err := creators.JSONArrayEnvelope{}.FormatBatch(envelopeBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []creators.BrokerMessage
+	if err := json.Unmarshal(body, &decoded); err != nil || len(decoded) != 2 || decoded[1].LogMessage != "second" {
+		t.Errorf("unexpected body %s: %v", body, err)
+	}
+
+	body, _ = creators.JSONArrayEnvelope{}.FormatBatch(nil)
+	if string(body) != "[]" {
+		t.Errorf("empty batch should be an empty array, got %s", body)
+	}
+}
+
+func TestNDJSONEnvelope(t *testing.T) {
+	body, err := creators.NDJSONEnvelope{}.FormatBatch(envelopeBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", body)
+	}
+	var decoded creators.BrokerMessage
+	if err := json.Unmarshal([]byte(lines[0]), &decoded); err != nil || decoded.LogMessage != "first" {
+		t.Errorf("unexpected first line %s: %v", lines[0], err)
+	}
+}
+
+func TestLokiPushEnvelope(t *testing.T) {
+	labels := map[string]string{"app": "orders"}
+	formatter := creators.LokiPushEnvelope(labels)
+	labels["app"] = "changed"
+
+	body, err := formatter.FormatBatch(envelopeBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		Streams []struct {
+			Stream map[string]string `json:"stream"`
+			Values [][]string        `json:"values"`
+		} `json:"streams"`
+	}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded.Streams) != 1 || decoded.Streams[0].Stream["app"] != "orders" || len(decoded.Streams[0].Values) != 2 {
+		t.Fatalf("unexpected body: %s", body)
+	}
+	value := decoded.Streams[0].Values[0]
+	if value[0] != "1704164645000000000" {
+		t.Errorf("unexpected timestamp: %s", value[0])
+	}
+	var line creators.BrokerMessage
+	if err := json.Unmarshal([]byte(value[1]), &line); err != nil || line.LogMessage != "first" {
+		t.Errorf("unexpected line %s: %v", value[1], err)
+	}
+}
@@ -0,0 +1,161 @@
+package creators
+
+import (
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// The built-in creators are registered as "console", "file", "broker" and "s3" so they can be
+// declared in a logtor.Config, with the logtor.Builder or through the AddLogCreator handler.
+// Their settings validators are registered for logtor.ValidateConfig.
+func init() {
+	mustRegister := func(typeName string, factory func(settings map[string]interface{}) (logtor.LogCreator, error)) {
+		if err := RegisterFactory(typeName, factory); err != nil {
+			panic(err)
+		}
+	}
+	mustRegister(consoleTarget, newConsoleFromSettings)
+	mustRegister(fileTarget, newFileFromSettings)
+	mustRegister(brokerTarget, newBrokerFromSettings)
+	mustRegister(s3Target, newS3FromSettings)
+
+	mustRegisterValidator := func(typeName string, validator logtor.CreatorValidator) {
+		if err := logtor.RegisterCreatorValidator(typeName, validator); err != nil {
+			panic(err)
+		}
+	}
+	mustRegisterValidator(consoleTarget, validateConsoleSettings)
+	mustRegisterValidator(fileTarget, validateFileSettings)
+	mustRegisterValidator(brokerTarget, validateBrokerSettings)
+	mustRegisterValidator(s3Target, validateS3Settings)
+}
+
+const s3Target = "s3"
+
+// RegisterFactory registers a factory that constructs log creators of the given type name, making the
+// type available to logtor.Config, the logtor.Builder and the AddLogCreator handler. User-defined
+// creators outside this package can be registered the same way as the built-in ones, and DecodeSettings
+// helps turning the settings into a typed struct.
+//
+// Parameters:
+//   - typeName: The type name referenced by logtor.CreatorConfig.Type.
+//   - f: The function constructing the log creator from its settings.
+//
+// Returns:
+//   - error: An error if the type name is empty or already registered.
+func RegisterFactory(typeName string, f func(settings map[string]interface{}) (logtor.LogCreator, error)) error {
+	return logtor.RegisterCreatorFactory(typeName, f)
+}
+
+// Build constructs a log creator using the factory registered for the given type name.
+//
+// Parameters:
+//   - typeName: The registered factory type name, e.g. "console" or "file".
+//   - settings: The settings passed to the factory.
+//
+// Returns:
+//   - logtor.LogCreator: The constructed log creator.
+//   - error: An error if the type is unknown or the settings are invalid.
+func Build(typeName string, settings map[string]interface{}) (logtor.LogCreator, error) {
+	return logtor.BuildCreator(typeName, settings)
+}
+
+// commonSettings holds the settings shared by all built-in creators.
+type commonSettings struct {
+	Name      string `setting:"name"`
+	CallDepth *int   `setting:"call_depth"`
+}
+
+func (cs commonSettings) options() []Option {
+	opts := []Option{WithName(types.LogCreatorName(cs.Name))}
+	if cs.CallDepth != nil {
+		opts = append(opts, WithCallDepth(*cs.CallDepth))
+	}
+	return opts
+}
+
+// consoleSettings holds the settings of the console creator.
+type consoleSettings struct {
+	commonSettings
+	PrefixWidth *int `setting:"prefix_width"`
+	JSONFormat  bool `setting:"json_format"`
+	PrettyJSON  bool `setting:"json_pretty"`
+}
+
+func (cs consoleSettings) options() []Option {
+	opts := cs.commonSettings.options()
+	if cs.PrefixWidth != nil {
+		opts = append(opts, WithPrefixWidth(*cs.PrefixWidth))
+	}
+	return append(opts, WithJSONFormat(cs.JSONFormat), WithPrettyJSON(cs.PrettyJSON))
+}
+
+// fileSettings holds the settings of the file creator.
+type fileSettings struct {
+	commonSettings
+	PrefixWidth *int   `setting:"prefix_width"`
+	FileName    string `setting:"file_name"`
+	NFSReopen   bool   `setting:"nfs_reopen"`
+}
+
+func (fs fileSettings) options() []Option {
+	opts := fs.commonSettings.options()
+	if fs.PrefixWidth != nil {
+		opts = append(opts, WithPrefixWidth(*fs.PrefixWidth))
+	}
+	return append(opts, WithNFSReopen(fs.NFSReopen))
+}
+
+// brokerSettings holds the settings of the broker creator.
+type brokerSettings struct {
+	commonSettings
+	Brokers []string `setting:"brokers"`
+	Topic   string   `setting:"topic"`
+}
+
+// s3Settings holds the settings of the S3 creator.
+type s3Settings struct {
+	commonSettings
+	Bucket        string        `setting:"bucket"`
+	KeyPrefix     string        `setting:"key_prefix"`
+	Region        string        `setting:"region"`
+	FlushInterval time.Duration `setting:"flush_interval"`
+}
+
+func newConsoleFromSettings(settings map[string]interface{}) (logtor.LogCreator, error) {
+	var decoded consoleSettings
+	if err := DecodeSettings(settings, &decoded); err != nil {
+		return nil, err
+	}
+	return NewBaseCreatorWithOptions(decoded.options()...)
+}
+
+func newFileFromSettings(settings map[string]interface{}) (logtor.LogCreator, error) {
+	var decoded fileSettings
+	if err := DecodeSettings(settings, &decoded); err != nil {
+		return nil, err
+	}
+	return NewFileCreatorWithOptions(decoded.FileName, decoded.options()...)
+}
+
+func newBrokerFromSettings(settings map[string]interface{}) (logtor.LogCreator, error) {
+	var decoded brokerSettings
+	if err := DecodeSettings(settings, &decoded); err != nil {
+		return nil, err
+	}
+	return NewBrokerCreatorWithOptions(decoded.Brokers, decoded.Topic, decoded.options()...)
+}
+
+func newS3FromSettings(settings map[string]interface{}) (logtor.LogCreator, error) {
+	var decoded s3Settings
+	if err := DecodeSettings(settings, &decoded); err != nil {
+		return nil, err
+	}
+	callDepth := 3
+	if decoded.CallDepth != nil {
+		callDepth = *decoded.CallDepth
+	}
+	return NewS3Creator(decoded.Bucket, decoded.KeyPrefix, decoded.Region, types.LogCreatorName(decoded.Name), callDepth, decoded.FlushInterval)
+}
@@ -0,0 +1,81 @@
+package creators_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestBuildFileCreatorFromSettings(t *testing.T) {
+	fileCreator, err := creators.Build("file", map[string]interface{}{
+		"name":         "Audit",
+		"file_name":    filepath.Join(t.TempDir(), "audit.log"),
+		"call_depth":   float64(4),
+		"prefix_width": float64(6),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fileCreator.LogName() != "Audit" || fileCreator.CallDepth() != 4 {
+		t.Errorf("settings not applied: name=%s callDepth=%d", fileCreator.LogName(), fileCreator.CallDepth())
+	}
+	if result := fileCreator.LogIt(types.INFO, "Example File Log Message"); !result {
+		t.Error("Log not recorded")
+	}
+}
+
+func TestBuildRejectsInvalidSettings(t *testing.T) {
+	_, err := creators.Build("console", map[string]interface{}{"colour": true})
+	if err == nil || !strings.Contains(err.Error(), `unknown setting "colour"`) || !strings.Contains(err.Error(), "prefix_width") {
+		t.Errorf("expected descriptive unknown key error, got %v", err)
+	}
+	_, err = creators.Build("console", map[string]interface{}{"call_depth": "deep"})
+	if err == nil || !strings.Contains(err.Error(), `setting "call_depth"`) {
+		t.Errorf("expected descriptive type error, got %v", err)
+	}
+	if _, err := creators.Build("unknown", nil); err == nil {
+		t.Error("expected error for unknown creator type")
+	}
+}
+
+// factoryRuns numbers the factories registered by the tests, as registrations are global and cannot be
+// undone, so each run, such as with -count=2, registers a type name of its own.
+var factoryRuns atomic.Int64
+
+func TestRegisterFactory(t *testing.T) {
+	typeName := fmt.Sprintf("prefixed-console-%d", factoryRuns.Add(1))
+	if err := creators.RegisterFactory("console", func(map[string]interface{}) (logtor.LogCreator, error) { return nil, nil }); err == nil {
+		t.Error("registering a built-in type twice should fail")
+	}
+	err := creators.RegisterFactory(typeName, func(settings map[string]interface{}) (logtor.LogCreator, error) {
+		var decoded struct {
+			Name   string         `setting:"name"`
+			Tags   []string       `setting:"tags"`
+			Period *time.Duration `setting:"period"`
+		}
+		if err := creators.DecodeSettings(settings, &decoded); err != nil {
+			return nil, err
+		}
+		if len(decoded.Tags) != 2 || decoded.Period == nil || *decoded.Period != 2*time.Second {
+			t.Errorf("settings not decoded: %+v", decoded)
+		}
+		return creators.NewBaseCreatorWithOptions(creators.WithName(types.LogCreatorName(decoded.Name)))
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	logCreator, err := creators.Build(typeName, map[string]interface{}{"name": "Tagged", "tags": []interface{}{"a", "b"}, "period": "2s"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if logCreator.LogName() != "Tagged" {
+		t.Errorf("unexpected name: %s", logCreator.LogName())
+	}
+}
@@ -5,9 +5,17 @@
 package creators
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"io"
 	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
 
 	"github.com/Eyup-Devop/logtor"
 	"github.com/Eyup-Devop/logtor/types"
@@ -16,66 +24,367 @@ import (
 // NewFileCreator creates a new instance of FileCreator, which logs messages to a file.
 //
 // It initializes a FileCreator with the provided file name, log creator name, call depth, and log prefix.
+// It is a thin wrapper around NewFileCreatorWithOptions.
 //
 // Parameters:
 //   - filename: The name of the log file.
 //   - logName: The name representing the log creator (e.g., File).
 //   - callDepth: The call depth to be used in log output.
 //   - logPrefix: An integer representing log prefix settings.
+//   - opts: Optional FileCreatorOption values such as WithNFSReopen.
 //
 // Returns:
 //   - *FileCreator: A pointer to the newly created FileCreator.
 //   - error: An error if initialization fails, or nil if successful.
 //
 // If logName is an empty string, it defaults to File.
-func NewFileCreator(filename string, logName types.LogCreatorName, callDepth int, logPrefix int) (logtor.LogCreator, error) {
-	logFile, err := os.OpenFile(filename, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o644)
+func NewFileCreator(filename string, logName types.LogCreatorName, callDepth int, logPrefix int, opts ...FileCreatorOption) (logtor.LogCreator, error) {
+	return NewFileCreatorWithOptions(filename, append([]Option{WithName(logName), WithCallDepth(max(callDepth, 0)), WithPrefixWidth(max(logPrefix, 0))}, opts...)...)
+}
+
+// NewFileCreatorWithRotation creates a new instance of FileCreator that rotates its log file by size.
+//
+// It is a thin wrapper around NewFileCreator with the WithRotation option. The returned log creator is a
+// *FileCreator, whose Rotated channel reports every rotation.
+//
+// Parameters:
+//   - filename: The name of the log file.
+//   - maxBytes: The size the log file may grow to before it is rotated.
+//   - maxBackups: The number of rotated files to keep as filename.1 to filename.N.
+//   - logName: The name representing the log creator (e.g., File).
+//   - callDepth: The call depth to be used in log output.
+//   - logPrefix: An integer representing log prefix settings.
+//
+// Returns:
+//   - *FileCreator: A pointer to the newly created FileCreator.
+//   - error: An error if the rotation settings are invalid or the file cannot be opened.
+func NewFileCreatorWithRotation(filename string, maxBytes int64, maxBackups int, logName types.LogCreatorName, callDepth int, logPrefix int) (logtor.LogCreator, error) {
+	return NewFileCreator(filename, logName, callDepth, logPrefix, WithRotation(maxBytes, maxBackups))
+}
+
+// NewFileCreatorWithOptions creates a new instance of FileCreator configured with functional options.
+//
+// Parameters:
+//   - filename: The name of the log file.
+//   - opts: Options such as WithName, WithCallDepth, WithPrefixWidth, WithNFSReopen and WithRotation.
+//
+// Returns:
+//   - *FileCreator: A pointer to the newly created FileCreator.
+//   - error: An error if an option is invalid or not supported, or the file cannot be opened.
+//
+// The name defaults to File.
+func NewFileCreatorWithOptions(filename string, opts ...Option) (logtor.LogCreator, error) {
+	if filename == "" {
+		return nil, fmt.Errorf("creators: file name must not be empty")
+	}
+	options, err := newCreatorOptions(fileTarget, File, opts)
 	if err != nil {
 		return nil, err
 	}
 
 	fileCreator := &FileCreator{
-		log:       log.New(logFile, "", log.LstdFlags|log.Lshortfile),
-		fileName:  filename,
-		logName:   logName,
-		callDepth: callDepth,
-		logPrefix: logPrefix,
+		fileName:   filename,
+		logName:    options.name,
+		callDepth:  options.callDepth,
+		logPrefix:  options.prefixWidth,
+		prefixes:   newLevelPrefixes(options.prefixWidth, false),
+		nfsReopen:  options.nfsReopen,
+		clock:      options.clock,
+		maxBytes:   options.maxBytes,
+		maxBackups: options.maxBackups,
+		interval:   options.rotationInterval,
 	}
-	// Set default log name if not provided
-	if logName == "" {
-		fileCreator.logName = File
+	if options.maxBytes > 0 || options.rotationInterval > 0 {
+		fileCreator.rotated = make(chan string, 1)
+	}
+
+	logFile, err := openFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	fileCreator.file = logFile
+	if info, err := os.Stat(filename); err == nil {
+		fileCreator.size = info.Size()
+	}
+	fileCreator.writer = &fileWriter{creator: fileCreator}
+	if fileCreator.interval > 0 {
+		fileCreator.startTimeRotation()
 	}
 
 	return fileCreator, nil
 }
 
+// openFile opens a log file for appending. It is a variable so tests can simulate filesystem failures.
+var openFile = func(filename string) (io.WriteCloser, error) {
+	return os.OpenFile(filename, os.O_CREATE|os.O_APPEND|os.O_RDWR, 0o644)
+}
+
+// fileWriter is the io.Writer the FileCreator writes its entries to. It serializes writes, rotates
+// the file when WithRotation is enabled and re-opens stale file handles when WithNFSReopen is enabled.
+type fileWriter struct {
+	creator *FileCreator
+}
+
+func (w *fileWriter) Write(p []byte) (int, error) {
+	fr := w.creator
+	fr.fileMutex.Lock()
+	defer fr.fileMutex.Unlock()
+
+	if fr.closed.Load() {
+		return 0, os.ErrClosed
+	}
+	if fr.maxBytes > 0 && fr.size > 0 && fr.size+int64(len(p)) > fr.maxBytes {
+		// A failed rotation is retried on the next write; the entry goes to the current file meanwhile.
+		fr.rotate()
+	}
+	n, err := fr.file.Write(p)
+	if err == nil || !fr.nfsReopen || !errors.Is(err, syscall.ESTALE) {
+		fr.size += int64(n)
+		return n, err
+	}
+
+	logFile, reopenErr := openFile(fr.fileName)
+	if reopenErr != nil {
+		return n, err
+	}
+	fr.file.Close()
+	fr.file = logFile
+	n, err = fr.file.Write(p)
+	fr.size += int64(n)
+	return n, err
+}
+
+// rotate moves the log file to the first backup, shifting the older backups and removing those past
+// maxBackups, and reopens the log file. It must be called with fileMutex held. The current file is only
+// closed once the new one is open, so a failed rotation leaves the FileCreator writing to it.
+func (fr *FileCreator) rotate() error {
+	if fr.maxBackups > 0 {
+		if err := os.Remove(fr.backupName(fr.maxBackups)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		for i := fr.maxBackups - 1; i > 0; i-- {
+			if err := os.Rename(fr.backupName(i), fr.backupName(i+1)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		if err := os.Rename(fr.fileName, fr.backupName(1)); err != nil {
+			return err
+		}
+	} else if err := os.Remove(fr.fileName); err != nil {
+		return err
+	}
+
+	return fr.reopen()
+}
+
+// reopen replaces the file handle with a new one for the log file after it was moved away by a rotation,
+// and reports the rotation on the Rotated channel. It must be called with fileMutex held.
+func (fr *FileCreator) reopen() error {
+	logFile, err := openFile(fr.fileName)
+	if err != nil {
+		return err
+	}
+	fr.file.Close()
+	fr.file = logFile
+	fr.size = 0
+	select {
+	case fr.rotated <- fr.fileName:
+	default:
+	}
+	return nil
+}
+
+// backupName returns the name of the i-th rotated log file.
+func (fr *FileCreator) backupName(i int) string {
+	return fr.fileName + "." + strconv.Itoa(i)
+}
+
 // File is a constant representing the LogCreatorName for the File log creator.
 const File types.LogCreatorName = "File"
 
 // FileCreator is an implementation of the LogCreator interface for logging messages to a file.
 type FileCreator struct {
-	log       *log.Logger
-	fileName  string
-	logName   types.LogCreatorName
-	callDepth int
-	logPrefix int
+	writer     io.Writer
+	file       io.WriteCloser
+	fileMutex  sync.Mutex
+	fileName   string
+	logName    types.LogCreatorName
+	callDepth  int
+	logPrefix  int
+	prefixes   *levelPrefixes
+	nfsReopen  bool
+	clock      logtor.Clock
+	maxBytes   int64
+	maxBackups int
+	formatter  formatterHolder
+	size       int64
+	rotated    chan string
+	interval   time.Duration
+	done       chan struct{}
+	stopped    chan struct{}
+	stopOnce   sync.Once
+	closed     atomic.Bool
 }
 
 // LogItWithCallDepth logs a message with the specified log level, call depth, and log message to the file.
 //
 // It formats the log entry with the log level's prefix and then outputs the log message.
+// Entries have the format of a log.Logger with log.LstdFlags|log.Lshortfile, but are rendered
+// into a pooled buffer and written with a single Write.
 //
 // Parameters:
 //   - level: The log level for the message (e.g., INFO, DEBUG).
-//   - callDepth: The call depth for recording the log entry.
+//   - callDepth: The call depth for recording the log entry, or zero or less for the configured call depth.
 //   - logMessage: The message to be logged, which can be of any type.
 //
 // Returns:
-//   - bool: Always returns true, indicating the message was successfully logged.
+//   - bool: True if the message was written to the file; false if the write failed.
 func (fr *FileCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
-	fr.log.SetPrefix(fmt.Sprintf("%-*s : ", fr.logPrefix, level))
-	fr.log.Output(callDepth, fmt.Sprintf("%+v", logMessage))
-	return true
+	callDepth = resolveCallDepth(callDepth, fr.callDepth)
+	// log.Logger.Output counts its own caller as depth 1, runtime.Caller counts it as 0.
+	_, file, line, ok := runtime.Caller(callDepth - 1)
+	if !ok {
+		file = "???"
+		line = 0
+	}
+	if formatter := fr.formatter.Load(); formatter != nil {
+		if output, ok := formatEntry(formatter, level, fr.clock.Now(), file, line, logMessage); ok {
+			_, err := fr.writer.Write(append(output, '\n'))
+			return err == nil
+		}
+	}
+
+	buffer := entryBufferPool.Get().(*[]byte)
+	entry := appendTextEntry((*buffer)[:0], fr.clock.Now(), fr.prefixes.prefix(level), file, line, logMessage, "")
+	_, err := fr.writer.Write(entry)
+	if cap(entry) <= maxPooledBufferSize {
+		*buffer = entry
+		entryBufferPool.Put(buffer)
+	}
+	return err == nil
+}
+
+// LogRendered logs an entry rendered by Logtor to the file, with the time, caller and fields resolved
+// by Logtor. The message is followed by its fields as sorted key=value pairs.
+//
+// Parameters:
+//   - entry: The rendered entry to be logged.
+//
+// Returns:
+//   - bool: True if the entry was written to the file; false if the write failed.
+func (fr *FileCreator) LogRendered(entry types.RenderedEntry) bool {
+	return fr.LogRenderedE(entry) == nil
+}
+
+// LogRenderedE logs an entry rendered by Logtor to the file like LogRendered, implementing
+// logtor.ErrorLogCreator.
+//
+// Parameters:
+//   - entry: The rendered entry to be logged.
+//
+// Returns:
+//   - error: The error of the write, or nil if the entry was written to the file.
+func (fr *FileCreator) LogRenderedE(entry types.RenderedEntry) error {
+	if formatter := fr.formatter.Load(); formatter != nil {
+		if output, ok := formatEntry(formatter, entry.Level, entry.Time, entry.File, entry.Line, entry.Value); ok {
+			_, err := fr.writer.Write(append(output, '\n'))
+			return err
+		}
+	}
+	buffer := entryBufferPool.Get().(*[]byte)
+	output := appendTextEntry((*buffer)[:0], entry.Time, fr.prefixes.prefix(entry.Level), entry.File, entry.Line, entry.Text(), "")
+	_, err := fr.writer.Write(output)
+	if cap(output) <= maxPooledBufferSize {
+		*buffer = output
+		entryBufferPool.Put(buffer)
+	}
+	return err
+}
+
+// SetFormatter sets the formatter rendering the entries of the FileCreator, replacing the built-in text
+// format. If the formatter fails for an entry, the entry is written in the built-in format.
+//
+// Parameters:
+//   - formatter: The formatter to use, or nil for the built-in format.
+func (fr *FileCreator) SetFormatter(formatter types.Formatter) {
+	fr.formatter.Store(formatter)
+}
+
+// LogEntry logs a structured entry with its own level, timestamp, caller and fields, implementing
+// logtor.LogCreatorV2.
+//
+// Parameters:
+//   - entry: The entry to be logged.
+//
+// Returns:
+//   - bool: The result of LogRendered for the entry.
+func (fr *FileCreator) LogEntry(entry types.LogEntry) bool {
+	return fr.LogRendered(entry.Rendered())
+}
+
+// appendTimestamp appends "2006/01/02 15:04:05 " in local time, as log.LstdFlags does, without
+// the layout parsing of time.Time.AppendFormat.
+func appendTimestamp(b []byte, now time.Time) []byte {
+	year, month, day := now.Date()
+	hour, minute, second := now.Clock()
+	b = appendPadded(b, year, 4)
+	b = append(b, '/')
+	b = appendPadded(b, int(month), 2)
+	b = append(b, '/')
+	b = appendPadded(b, day, 2)
+	b = append(b, ' ')
+	b = appendPadded(b, hour, 2)
+	b = append(b, ':')
+	b = appendPadded(b, minute, 2)
+	b = append(b, ':')
+	b = appendPadded(b, second, 2)
+	return append(b, ' ')
+}
+
+// appendPadded appends a non-negative number zero-padded to width digits.
+func appendPadded(b []byte, value int, width int) []byte {
+	var digits [20]byte
+	i := len(digits)
+	for value >= 10 || width > 1 {
+		i--
+		digits[i] = byte('0' + value%10)
+		value /= 10
+		width--
+	}
+	i--
+	digits[i] = byte('0' + value)
+	return append(b, digits[i:]...)
+}
+
+var entryBufferPool = sync.Pool{
+	New: func() interface{} {
+		buffer := make([]byte, 0, 1024)
+		return &buffer
+	},
+}
+
+// appendTextEntry renders an entry the way log.Logger does with the given prefix and the flags
+// log.LstdFlags|log.Lshortfile, for a message formatted as "%+v" followed by suffix.
+func appendTextEntry(b []byte, now time.Time, prefix string, file string, line int, logMessage interface{}, suffix string) []byte {
+	b = append(b, prefix...)
+	b = appendTimestamp(b, now)
+	if slash := strings.LastIndexByte(file, '/'); slash > 0 {
+		file = file[slash+1:]
+	}
+	b = append(b, file...)
+	b = append(b, ':')
+	b = strconv.AppendInt(b, int64(line), 10)
+	b = append(b, ": "...)
+	if message, ok := logMessage.(string); ok {
+		b = append(b, message...)
+	} else {
+		b = fmt.Appendf(b, "%+v", logMessage)
+	}
+	b = append(b, suffix...)
+	if len(b) == 0 || b[len(b)-1] != '\n' {
+		b = append(b, '\n')
+	}
+	return b
 }
 
 // LogIt logs a message with the specified log level using the default call depth to the file.
@@ -88,7 +397,7 @@ func (fr *FileCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, l
 //   - logMessage: The message to be logged, which can be of any type.
 //
 // Returns:
-//   - bool: Always returns true, indicating the message was successfully logged.
+//   - bool: True if the message was written to the file; false if the write failed.
 func (fr *FileCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
 	return fr.LogItWithCallDepth(level, fr.callDepth, logMessage)
 }
@@ -121,14 +430,79 @@ func (fr *FileCreator) CallDepth() int {
 	return fr.callDepth
 }
 
+// Rotated returns a channel that receives the path of the log file after every rotation, once the file
+// has been reopened, for callers that need to react, e.g. by shipping the backups.
+//
+// Rotations by size and by time are both reported. The channel buffers a single rotation and further rotations are dropped until it is received, so a
+// slow reader never blocks logging. It is nil if neither WithRotation nor WithTimeRotation is enabled.
+//
+// Returns:
+//   - <-chan string: The channel reporting rotations.
+func (fr *FileCreator) Rotated() <-chan string {
+	return fr.rotated
+}
+
 // Shutdown performs any necessary cleanup or shutdown operations for the log creator.
 //
-// This method is present to satisfy the LogCreator interface, but it does not perform any actions
-// in the case of the FileCreator. It is left empty intentionally.
+// It stops the goroutine rotating the log file by time, if WithTimeRotation is enabled, waits for it to
+// return and closes the log file. Later writes fail and IsReady reports false. Calling Shutdown again
+// does nothing.
 func (fr *FileCreator) Shutdown() {
-	// No cleanup or shutdown actions needed for FileCreator.
+	if fr.done != nil {
+		fr.stopOnce.Do(func() { close(fr.done) })
+		<-fr.stopped
+	}
+	fr.fileMutex.Lock()
+	defer fr.fileMutex.Unlock()
+	if fr.closed.Swap(true) {
+		return
+	}
+	fr.file.Close()
 }
 
+// IsReady reports whether the FileCreator can write, which it can until it is shut down.
 func (fr *FileCreator) IsReady() bool {
-	return true
+	return !fr.closed.Load()
+}
+
+// Describe returns the resolved settings of the FileCreator.
+//
+// Returns:
+//   - map[string]interface{}: The creator type, name, file name, call depth, prefix width, NFS reopen and rotation settings.
+func (fr *FileCreator) Describe() map[string]interface{} {
+	return map[string]interface{}{
+		"type":         fileTarget,
+		"name":         fr.logName,
+		"file_name":    fr.fileName,
+		"call_depth":   fr.callDepth,
+		"prefix_width": fr.logPrefix,
+		"nfs_reopen":   fr.nfsReopen,
+		"max_bytes":    fr.maxBytes,
+		"max_backups":  fr.maxBackups,
+		"interval":     fr.interval.String(),
+	}
+}
+
+// CreatorType returns the kind of the log creator.
+//
+// Returns:
+//   - string: "file".
+func (fr *FileCreator) CreatorType() string {
+	return fileTarget
+}
+
+// CreatorVersion returns the version of the log creator implementation.
+//
+// Returns:
+//   - string: The implementation version.
+func (fr *FileCreator) CreatorVersion() string {
+	return creatorVersion
+}
+
+// CreatorDescription returns a short description of the log creator.
+//
+// Returns:
+//   - string: A description naming the log file.
+func (fr *FileCreator) CreatorDescription() string {
+	return "Log entries appended to " + fr.fileName
 }
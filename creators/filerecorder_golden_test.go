@@ -0,0 +1,137 @@
+package creators
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// legacyFileEntry renders an entry the way the FileCreator did before it bypassed log.Logger.
+// It is the golden reference for appendTextEntry.
+func legacyFileEntry(w io.Writer, width int, level types.LogLevel, callDepth int, logMessage interface{}) {
+	logger := log.New(w, "", log.LstdFlags|log.Lshortfile)
+	logger.SetPrefix(fmt.Sprintf("%-*s : ", width, level))
+	logger.Output(callDepth, fmt.Sprintf("%+v", logMessage))
+}
+
+// renderBoth renders an entry with both implementations, so both report the caller of renderBoth.
+func renderBoth(expected io.Writer, fileCreator *FileCreator, width int, level types.LogLevel, logMessage interface{}) {
+	legacyFileEntry(expected, width, level, 3, logMessage)
+	fileCreator.LogItWithCallDepth(level, 3, logMessage)
+}
+
+type captureFile struct {
+	bytes.Buffer
+}
+
+func (cf *captureFile) Close() error { return nil }
+
+func TestFileCreatorMatchesLegacyFormat(t *testing.T) {
+	cases := []struct {
+		name       string
+		width      int
+		level      types.LogLevel
+		logMessage interface{}
+	}{
+		{"string", 5, types.ERROR, "Example File Log Message"},
+		{"narrow prefix", 0, types.WARN, "Example File Log Message"},
+		{"wide prefix", 10, types.TRACE, "Example File Log Message"},
+		{"struct", 5, types.INFO, struct {
+			Name string
+			Age  int
+		}{"Example Name", 25}},
+		{"trailing newline", 5, types.DEBUG, "ends with a newline\n"},
+		{"multi line", 5, types.FATAL, "first line\nsecond line"},
+		{"empty", 5, types.INFO, ""},
+		{"nil", 5, types.INFO, nil},
+		{"large", 5, types.INFO, strings.Repeat("x", 1024)},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			logCreator, err := NewFileCreatorWithOptions(filepath.Join(t.TempDir(), "temp.log"), WithPrefixWidth(tc.width))
+			if err != nil {
+				t.Fatal(err)
+			}
+			fileCreator := logCreator.(*FileCreator)
+			var actual captureFile
+			fileCreator.file = &actual
+
+			// Retry if the clock ticks over a second between the two renderings.
+			for attempt := 0; attempt < 3; attempt++ {
+				var expected bytes.Buffer
+				actual.Reset()
+				before := time.Now().Unix()
+				renderBoth(&expected, fileCreator, tc.width, tc.level, tc.logMessage)
+				if time.Now().Unix() != before {
+					continue
+				}
+				if actual.String() != expected.String() {
+					t.Errorf("output differs from log.Logger:\n got %q\nwant %q", actual.String(), expected.String())
+				}
+				return
+			}
+			t.Fatal("clock kept ticking over during the comparison")
+		})
+	}
+}
+
+func TestAppendTimestamp(t *testing.T) {
+	for _, now := range []time.Time{
+		time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local),
+		time.Date(999, 12, 31, 23, 59, 59, 0, time.Local),
+		time.Date(2024, 10, 10, 10, 10, 10, 0, time.Local),
+	} {
+		expected := now.Format("2006/01/02 15:04:05 ")
+		if actual := string(appendTimestamp(nil, now)); actual != expected {
+			t.Errorf("got %q want %q", actual, expected)
+		}
+	}
+}
+
+func benchmarkFileCreatorMessage() string {
+	return strings.Repeat("m", 1024)
+}
+
+func newBenchmarkFileCreator(b *testing.B) *FileCreator {
+	logCreator, err := NewFileCreatorWithOptions(filepath.Join(b.TempDir(), "temp.log"))
+	if err != nil {
+		b.Fatal(err)
+	}
+	fileCreator := logCreator.(*FileCreator)
+	fileCreator.file = &captureFile{}
+	return fileCreator
+}
+
+// BenchmarkFileCreatorLegacy measures the log.Logger based rendering the FileCreator used before,
+// writing to the same file writer, as the baseline for BenchmarkFileCreatorLogIt.
+func BenchmarkFileCreatorLegacy(b *testing.B) {
+	fileCreator := newBenchmarkFileCreator(b)
+	logger := log.New(fileCreator.writer, "", log.LstdFlags|log.Lshortfile)
+	var logMessage interface{} = benchmarkFileCreatorMessage()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fileCreator.file.(*captureFile).Reset()
+		logger.SetPrefix(fmt.Sprintf("%-*s : ", 5, types.INFO))
+		logger.Output(2, fmt.Sprintf("%+v", logMessage))
+	}
+}
+
+func BenchmarkFileCreatorLogIt(b *testing.B) {
+	fileCreator := newBenchmarkFileCreator(b)
+	var logMessage interface{} = benchmarkFileCreatorMessage()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		fileCreator.file.(*captureFile).Reset()
+		fileCreator.LogItWithCallDepth(types.INFO, 2, logMessage)
+	}
+}
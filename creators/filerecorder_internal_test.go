@@ -0,0 +1,98 @@
+package creators
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// staleFile simulates a file handle that went stale after an NFS server reboot.
+type staleFile struct {
+	stale  bool
+	writes int
+	closed bool
+}
+
+func (sf *staleFile) Write(p []byte) (int, error) {
+	if sf.stale {
+		return 0, &os.PathError{Op: "write", Path: "temp.log", Err: syscall.ESTALE}
+	}
+	sf.writes++
+	return len(p), nil
+}
+
+func (sf *staleFile) Close() error {
+	sf.closed = true
+	return nil
+}
+
+func withFakeFiles(t *testing.T, files ...*staleFile) {
+	t.Helper()
+	original := openFile
+	t.Cleanup(func() { openFile = original })
+	openFile = func(filename string) (io.WriteCloser, error) {
+		file := files[0]
+		files = files[1:]
+		return file, nil
+	}
+}
+
+func TestFileCreatorReopensStaleFile(t *testing.T) {
+	first, second := &staleFile{stale: true}, &staleFile{}
+	withFakeFiles(t, first, second)
+
+	fileCreator, err := NewFileCreator(filepath.Join(t.TempDir(), "temp.log"), "File", 2, 5, WithNFSReopen(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fileCreator.LogIt(types.ERROR, "Example File Log Message") {
+		t.Error("Log not recorded after reopening stale file")
+	}
+	if !first.closed || second.writes != 1 {
+		t.Errorf("stale file was not replaced: closed=%v writes=%d", first.closed, second.writes)
+	}
+}
+
+func TestFileCreatorWithoutNFSReopenFails(t *testing.T) {
+	first := &staleFile{stale: true}
+	withFakeFiles(t, first)
+
+	fileCreator, err := NewFileCreator(filepath.Join(t.TempDir(), "temp.log"), "File", 2, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fileCreator.LogIt(types.ERROR, "Example File Log Message") {
+		t.Error("Log reported as recorded although the write failed")
+	}
+	if first.closed {
+		t.Error("file must not be reopened when WithNFSReopen is disabled")
+	}
+}
+
+func TestFileCreatorShutdownClosesFile(t *testing.T) {
+	file := &staleFile{}
+	withFakeFiles(t, file)
+
+	fileCreator, err := NewFileCreator(filepath.Join(t.TempDir(), "temp.log"), "File", 2, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fileCreator.LogIt(types.INFO, "before shutdown") {
+		t.Fatal("Log not recorded before shutdown")
+	}
+	fileCreator.Shutdown()
+	fileCreator.Shutdown()
+	if !file.closed {
+		t.Error("Shutdown should close the log file")
+	}
+	if fileCreator.IsReady() {
+		t.Error("a shut down FileCreator should not be ready")
+	}
+	if fileCreator.LogIt(types.INFO, "after shutdown") || file.writes != 1 {
+		t.Errorf("writes after Shutdown should fail: writes=%d", file.writes)
+	}
+}
@@ -2,6 +2,11 @@ package creators_test
 
 import (
 	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/Eyup-Devop/logtor/creators"
@@ -63,3 +68,113 @@ func TestFileRecorderWithJson(t *testing.T) {
 		t.Error("Log not recorded")
 	}
 }
+
+func TestFileCreatorWithRotation(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "rotating.log")
+	fileCreator, err := creators.NewFileCreatorWithRotation(fileName, 1, 2, "File", 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rotated := fileCreator.(*creators.FileCreator).Rotated()
+	for i := 1; i <= 4; i++ {
+		if !fileCreator.LogIt(types.INFO, fmt.Sprintf("message %d", i)) {
+			t.Fatal("Log not recorded")
+		}
+	}
+	select {
+	case path := <-rotated:
+		if path != fileName {
+			t.Errorf("unexpected rotated path: %s", path)
+		}
+	default:
+		t.Error("rotation not reported")
+	}
+
+	for name, expected := range map[string]string{fileName: "message 4", fileName + ".1": "message 3", fileName + ".2": "message 2"} {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lines := strings.Count(string(content), "\n"); lines != 1 || !strings.HasSuffix(string(content), expected+"\n") {
+			t.Errorf("%s should only hold %q: %q", name, expected, content)
+		}
+	}
+	if _, err := os.Stat(fileName + ".3"); !os.IsNotExist(err) {
+		t.Errorf("backups past maxBackups should be removed: %v", err)
+	}
+}
+
+func TestFileCreatorWithRotationConcurrently(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "rotating.log")
+	fileCreator, err := creators.NewFileCreatorWithRotation(fileName, 512, 1000, "File", 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				if !fileCreator.LogIt(types.INFO, "Example File Log Message") {
+					t.Error("Log not recorded")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	files, err := filepath.Glob(fileName + "*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for _, name := range files {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(content) > 512 {
+			t.Errorf("%s grew past the rotation size: %d bytes", name, len(content))
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(string(content), "\n"), "\n") {
+			if !strings.HasSuffix(line, ": Example File Log Message") {
+				t.Errorf("corrupted line in %s: %q", name, line)
+			}
+			total++
+		}
+	}
+	if total != 400 {
+		t.Errorf("unexpected line count: %d", total)
+	}
+}
+
+func TestWithRotationValidation(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "rotating.log")
+	if _, err := creators.NewFileCreatorWithRotation(fileName, 0, 1, "File", 3, 5); err == nil {
+		t.Error("a rotation size of zero should be rejected")
+	}
+	if _, err := creators.NewFileCreatorWithRotation(fileName, 1024, -1, "File", 3, 5); err == nil {
+		t.Error("a negative number of backups should be rejected")
+	}
+	if _, err := creators.NewBaseCreatorWithOptions(creators.WithRotation(1024, 1)); err == nil {
+		t.Error("the console creator should not support rotation")
+	}
+}
+
+func TestFileCreatorLogRenderedE(t *testing.T) {
+	logCreator, err := creators.NewFileCreatorWithOptions(filepath.Join(t.TempDir(), "file.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileCreator := logCreator.(*creators.FileCreator)
+	entry := types.RenderedEntry{Level: types.INFO, Message: "written"}
+	if err := fileCreator.LogRenderedE(entry); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	fileCreator.Shutdown()
+	if err := fileCreator.LogRenderedE(entry); err == nil {
+		t.Error("writing to the closed file should fail")
+	}
+}
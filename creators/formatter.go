@@ -0,0 +1,53 @@
+package creators
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// formatterHolder holds the formatter set with SetFormatter, possibly nil, so it can be swapped while
+// other goroutines log.
+type formatterHolder struct {
+	pointer atomic.Pointer[types.Formatter]
+}
+
+// Load returns the held formatter, or nil if none was set.
+func (h *formatterHolder) Load() types.Formatter {
+	if formatter := h.pointer.Load(); formatter != nil {
+		return *formatter
+	}
+	return nil
+}
+
+// Store replaces the held formatter. Storing nil clears it.
+func (h *formatterHolder) Store(formatter types.Formatter) {
+	if formatter == nil {
+		h.pointer.Store(nil)
+		return
+	}
+	h.pointer.Store(&formatter)
+}
+
+// formatEntry renders a message with a formatter, unwrapping the fields of a types.LogEntry or
+// logtor.ContextualMessage. It reports false if the formatter failed, so the creator can fall back to
+// its built-in format instead of losing the entry.
+func formatEntry(formatter types.Formatter, level types.LogLevel, now time.Time, file string, line int, logMessage interface{}) ([]byte, bool) {
+	output, err := formatter.Format(logEntryOf(level, now, file, line, logMessage))
+	return output, err == nil
+}
+
+// logEntryOf builds the entry of a message, unwrapping the message and fields of a types.LogEntry or
+// logtor.ContextualMessage.
+func logEntryOf(level types.LogLevel, now time.Time, file string, line int, logMessage interface{}) types.LogEntry {
+	entry := types.LogEntry{Level: level, Message: logMessage, Timestamp: now, Caller: file, Line: line}
+	switch typed := logMessage.(type) {
+	case types.LogEntry:
+		entry.Message, entry.Fields = typed.Message, typed.Fields
+	case logtor.ContextualMessage:
+		entry.Message, entry.Fields = typed.Message, typed.Fields
+	}
+	return entry
+}
@@ -0,0 +1,93 @@
+package creators_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// failingFormatter is a Formatter that always fails.
+type failingFormatter struct{}
+
+func (failingFormatter) Format(entry types.LogEntry) ([]byte, error) {
+	return nil, errors.New("format failed")
+}
+
+// logFormatted logs an entry through a FileCreator using the formatter and returns the file content.
+func logFormatted(t *testing.T, formatter types.Formatter, logMessage interface{}) string {
+	t.Helper()
+	fileName := filepath.Join(t.TempDir(), "formatted.log")
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	fileCreator, err := creators.NewFileCreatorWithOptions(fileName, creators.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileCreator.(*creators.FileCreator).SetFormatter(formatter)
+	if !fileCreator.LogIt(types.WARN, logMessage) {
+		t.Fatal("Log not recorded")
+	}
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestJSONFormatter(t *testing.T) {
+	output := logFormatted(t, types.JSONFormatter{}, types.LogEntry{Message: "disk full", Fields: map[string]interface{}{"disk": "sda", "level": "ignored"}})
+	var object map[string]interface{}
+	if err := json.Unmarshal([]byte(output), &object); err != nil {
+		t.Fatalf("output is not JSON: %q", output)
+	}
+	if object["level"] != "WARN" || object["message"] != "disk full" || object["disk"] != "sda" ||
+		object["time"] != "2024-01-02T03:04:05Z" || !strings.HasPrefix(object["caller"].(string), "formatter_test.go:") {
+		t.Errorf("unexpected object: %v", object)
+	}
+}
+
+func TestLogfmtFormatter(t *testing.T) {
+	output := logFormatted(t, types.LogfmtFormatter{}, types.LogEntry{Message: "disk full", Fields: map[string]interface{}{"disk": "sda", "usage": 0.93}})
+	if !strings.HasPrefix(output, "time=2024-01-02T03:04:05Z level=WARN caller=formatter_test.go:") ||
+		!strings.HasSuffix(output, ` msg="disk full" disk=sda usage=0.93`+"\n") {
+		t.Errorf("unexpected logfmt line: %q", output)
+	}
+}
+
+func TestTextFormatter(t *testing.T) {
+	formatter, err := types.NewTextFormatter("[{{.Level}}] {{.Time}} {{.Text}}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	output := logFormatted(t, formatter, types.LogEntry{Message: "disk full", Fields: map[string]interface{}{"disk": "sda"}})
+	if output != "[WARN] 2024/01/02 03:04:05 disk full disk=sda\n" {
+		t.Errorf("unexpected text: %q", output)
+	}
+
+	defaultFormatter, err := types.NewTextFormatter("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if output := logFormatted(t, defaultFormatter, "plain"); !strings.HasPrefix(output, "WARN  : 2024/01/02 03:04:05 formatter_test.go:") {
+		t.Errorf("unexpected default text: %q", output)
+	}
+	if _, err := types.NewTextFormatter("{{.Level"); err == nil {
+		t.Error("an invalid template should be rejected")
+	}
+}
+
+func TestFormatterFallsBackToBuiltInFormat(t *testing.T) {
+	if output := logFormatted(t, failingFormatter{}, "plain"); !strings.HasPrefix(output, "WARN  : 2024/01/02 03:04:05 ") {
+		t.Errorf("a failing formatter should fall back to the built-in format: %q", output)
+	}
+	if output := logFormatted(t, nil, "plain"); !strings.HasSuffix(output, ": plain\n") {
+		t.Errorf("a nil formatter should keep the built-in format: %q", output)
+	}
+}
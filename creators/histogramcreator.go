@@ -0,0 +1,227 @@
+package creators
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync/atomic"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// OverflowBucket is the upper bound under which HistogramCreator.Buckets reports the entries larger
+// than the largest configured bucket.
+const OverflowBucket int64 = math.MaxInt64
+
+// DefaultHistogramBuckets are the bucket upper bounds, in bytes, used when NewHistogramCreator gets no buckets.
+var DefaultHistogramBuckets = []int64{128, 256, 512, 1024, 4096, 16384, 65536}
+
+// NewHistogramCreator creates a new instance of HistogramCreator, which records the size distribution
+// of the messages logged through another log creator.
+//
+// The size of a message is the length of its "%+v" representation in bytes. Each entry is counted in
+// the first bucket whose upper bound is greater than or equal to its size.
+//
+// Parameters:
+//   - inner: The log creator the messages are passed on to.
+//   - buckets: The bucket upper bounds in bytes. They are sorted and deduplicated; non-positive bounds are ignored.
+//
+// Returns:
+//   - *HistogramCreator: A pointer to the newly created HistogramCreator.
+//
+// If buckets is empty, DefaultHistogramBuckets is used. The name and readiness are those of inner.
+func NewHistogramCreator(inner logtor.LogCreator, buckets []int64) *HistogramCreator {
+	bounds := make([]int64, 0, len(buckets))
+	for _, bound := range buckets {
+		if bound > 0 {
+			bounds = append(bounds, bound)
+		}
+	}
+	if len(bounds) == 0 {
+		bounds = append(bounds, DefaultHistogramBuckets...)
+	}
+	sort.Slice(bounds, func(i, j int) bool { return bounds[i] < bounds[j] })
+	unique := bounds[:1]
+	for _, bound := range bounds[1:] {
+		if bound != unique[len(unique)-1] {
+			unique = append(unique, bound)
+		}
+	}
+
+	return &HistogramCreator{
+		inner:  inner,
+		bounds: unique,
+		counts: make([]atomic.Int64, len(unique)+1),
+	}
+}
+
+// HistogramCreator is an implementation of the LogCreator interface that counts message sizes per bucket
+// before passing the messages on to another log creator.
+type HistogramCreator struct {
+	inner   logtor.LogCreator
+	bounds  []int64
+	counts  []atomic.Int64
+	maxSize atomic.Int64
+}
+
+func (hc *HistogramCreator) observe(logMessage interface{}) {
+	size := int64(len(fmt.Sprintf("%+v", logMessage)))
+	index := sort.Search(len(hc.bounds), func(i int) bool { return hc.bounds[i] >= size })
+	hc.counts[index].Add(1)
+	for {
+		current := hc.maxSize.Load()
+		if size <= current || hc.maxSize.CompareAndSwap(current, size) {
+			return
+		}
+	}
+}
+
+// LogItWithCallDepth records the size of the message and logs it with the inner log creator.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for recording the log entry, or zero or less for the configured call depth.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: The result of the inner log creator.
+func (hc *HistogramCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	hc.observe(logMessage)
+	if callDepth <= 0 {
+		// This frame stands in for the inner creator's LogIt, so its configured depth applies unchanged.
+		return hc.inner.LogItWithCallDepth(level, hc.inner.CallDepth(), logMessage)
+	}
+	return hc.inner.LogItWithCallDepth(level, callDepth+1, logMessage)
+}
+
+// LogIt records the size of the message and logs it with the inner log creator using its call depth.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: The result of the inner log creator.
+func (hc *HistogramCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	hc.observe(logMessage)
+	return hc.inner.LogItWithCallDepth(level, hc.inner.CallDepth(), logMessage)
+}
+
+// Buckets returns the number of entries counted per bucket upper bound.
+//
+// Entries larger than the largest bucket are reported under OverflowBucket.
+//
+// Returns:
+//   - map[int64]int64: The entry count per bucket upper bound in bytes.
+func (hc *HistogramCreator) Buckets() map[int64]int64 {
+	result := make(map[int64]int64, len(hc.counts))
+	for i := range hc.counts {
+		result[hc.bound(i)] = hc.counts[i].Load()
+	}
+	return result
+}
+
+func (hc *HistogramCreator) bound(index int) int64 {
+	if index < len(hc.bounds) {
+		return hc.bounds[index]
+	}
+	return OverflowBucket
+}
+
+// Percentile returns the bucket upper bound below which the given percentage of entries falls,
+// e.g. Percentile(99) for the p99 message size.
+//
+// Since sizes are only known per bucket, the result is the upper bound of the bucket containing
+// the percentile. For the overflow bucket the largest size seen is returned instead.
+//
+// Parameters:
+//   - p: The percentile, between 0 and 100.
+//
+// Returns:
+//   - int64: The size in bytes, or 0 if no entries were recorded.
+func (hc *HistogramCreator) Percentile(p float64) int64 {
+	counts := make([]int64, len(hc.counts))
+	var total int64
+	for i := range hc.counts {
+		counts[i] = hc.counts[i].Load()
+		total += counts[i]
+	}
+	if total == 0 {
+		return 0
+	}
+	p = math.Max(0, math.Min(100, p))
+	rank := int64(math.Ceil(p / 100 * float64(total)))
+	if rank < 1 {
+		rank = 1
+	}
+	var seen int64
+	for i, count := range counts {
+		seen += count
+		if seen >= rank {
+			if i == len(hc.bounds) {
+				return hc.maxSize.Load()
+			}
+			return hc.bounds[i]
+		}
+	}
+	return hc.maxSize.Load()
+}
+
+// CreatorStats returns the bucket counts and the p50, p95 and p99 message sizes, for Logtor.Stats.
+//
+// Returns:
+//   - map[string]interface{}: The collected statistics.
+func (hc *HistogramCreator) CreatorStats() map[string]interface{} {
+	return map[string]interface{}{
+		"size_buckets": hc.Buckets(),
+		"size_p50":     hc.Percentile(50),
+		"size_p95":     hc.Percentile(95),
+		"size_p99":     hc.Percentile(99),
+	}
+}
+
+// LogName returns the name of the inner log creator.
+//
+// Returns:
+//   - LogCreatorName: The name of the log creator.
+func (hc *HistogramCreator) LogName() types.LogCreatorName {
+	return hc.inner.LogName()
+}
+
+// SetCallDepth sets the call depth of the inner log creator.
+//
+// Parameters:
+//   - callDepth: The depth to set for recording log entries.
+func (hc *HistogramCreator) SetCallDepth(callDepth int) {
+	hc.inner.SetCallDepth(callDepth)
+}
+
+// CallDepth returns the call depth of the inner log creator.
+//
+// Returns:
+//   - int: The current call depth setting for recording log entries.
+func (hc *HistogramCreator) CallDepth() int {
+	return hc.inner.CallDepth()
+}
+
+// IsReady reports whether the inner log creator is ready.
+func (hc *HistogramCreator) IsReady() bool {
+	return hc.inner.IsReady()
+}
+
+// Flush flushes the inner log creator if it buffers entries.
+//
+// Returns:
+//   - error: The error of the inner log creator's Flush, or nil if it does not implement logtor.Flusher.
+func (hc *HistogramCreator) Flush() error {
+	if flusher, ok := hc.inner.(logtor.Flusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// Shutdown shuts down the inner log creator.
+func (hc *HistogramCreator) Shutdown() {
+	hc.inner.Shutdown()
+}
@@ -0,0 +1,57 @@
+package creators_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestHistogramCreator(t *testing.T) {
+	fileCreator, err := creators.NewFileCreatorWithOptions(filepath.Join(t.TempDir(), "temp.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	histogramCreator := creators.NewHistogramCreator(fileCreator, []int64{100, 10, 1000, 10})
+	if histogramCreator.Percentile(99) != 0 {
+		t.Error("empty histogram should report 0")
+	}
+
+	for i := 0; i < 90; i++ {
+		histogramCreator.LogIt(types.INFO, "short")
+	}
+	for i := 0; i < 9; i++ {
+		histogramCreator.LogIt(types.INFO, strings.Repeat("m", 50))
+	}
+	histogramCreator.LogItWithCallDepth(types.INFO, 2, strings.Repeat("l", 5000))
+
+	buckets := histogramCreator.Buckets()
+	expected := map[int64]int64{10: 90, 100: 9, 1000: 0, creators.OverflowBucket: 1}
+	if len(buckets) != len(expected) {
+		t.Fatalf("unexpected buckets: %v", buckets)
+	}
+	for bound, count := range expected {
+		if buckets[bound] != count {
+			t.Errorf("bucket %d: got %d want %d", bound, buckets[bound], count)
+		}
+	}
+	if p := histogramCreator.Percentile(50); p != 10 {
+		t.Errorf("unexpected p50: %d", p)
+	}
+	if p := histogramCreator.Percentile(95); p != 100 {
+		t.Errorf("unexpected p95: %d", p)
+	}
+	if p := histogramCreator.Percentile(100); p != 5000 {
+		t.Errorf("unexpected p100: %d", p)
+	}
+
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(histogramCreator)
+	stats := newLogtor.Stats().Creators[creators.File]
+	if stats == nil || stats["size_p99"] != int64(100) {
+		t.Errorf("histogram missing from Logtor.Stats: %v", newLogtor.Stats())
+	}
+}
@@ -0,0 +1,285 @@
+package creators
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// HTTP is a constant representing the LogCreatorName for the HTTP log creator.
+const HTTP types.LogCreatorName = "HTTP"
+
+const (
+	// defaultHTTPMaxRetries is the number of times a failed request is retried unless SetMaxRetries is called.
+	defaultHTTPMaxRetries = 3
+	// defaultHTTPRetryBackoff is the wait before the first retry, doubled for every further retry.
+	defaultHTTPRetryBackoff = 100 * time.Millisecond
+)
+
+// NewHTTPCreator creates a new instance of HTTPCreator, which posts log messages as JSON to an HTTP endpoint.
+//
+// It sends a preflight HEAD request to the endpoint, and an OPTIONS request if the HEAD request fails;
+// the HTTPCreator is ready once one of them succeeds. An unreachable endpoint does not make the creation
+// fail, so a webhook that starts later can be probed again with Preflight.
+//
+// Parameters:
+//   - endpoint: The absolute http or https URL the log messages are posted to.
+//   - headers: The headers sent with every request, e.g. an Authorization header. The map is copied.
+//   - timeout: The timeout of every request, each retry getting its own; zero or less means 5 seconds.
+//   - logName: The name representing the log creator (e.g., HTTP).
+//   - callDepth: The call depth to be used in log output.
+//
+// Returns:
+//   - *HTTPCreator: A pointer to the newly created HTTPCreator.
+//   - error: An error if the endpoint is not an absolute http or https URL.
+//
+// If logName is an empty string, it defaults to HTTP.
+func NewHTTPCreator(endpoint string, headers map[string]string, timeout time.Duration, logName types.LogCreatorName, callDepth int) (*HTTPCreator, error) {
+	return NewHTTPCreatorWithOptions(endpoint, headers, timeout, WithName(logName), WithCallDepth(max(callDepth, 0)))
+}
+
+// NewHTTPCreatorWithOptions creates a new instance of HTTPCreator configured with functional options.
+//
+// Parameters:
+//   - endpoint: The absolute http or https URL the log messages are posted to.
+//   - headers: The headers sent with every request, e.g. an Authorization header. The map is copied.
+//   - timeout: The timeout of every request, each retry getting its own; zero or less means 5 seconds.
+//   - opts: Options such as WithName, WithCallDepth and WithClock.
+//
+// Returns:
+//   - *HTTPCreator: A pointer to the newly created HTTPCreator.
+//   - error: An error if an option is invalid or not supported, or the endpoint is not an absolute http
+//     or https URL.
+//
+// The name defaults to HTTP. The clock stamps the messages posted by LogIt and LogItWithCallDepth.
+func NewHTTPCreatorWithOptions(endpoint string, headers map[string]string, timeout time.Duration, opts ...Option) (*HTTPCreator, error) {
+	options, err := newCreatorOptions(httpTarget, HTTP, opts)
+	if err != nil {
+		return nil, err
+	}
+	parsed, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("creators: invalid endpoint %q: %w", endpoint, err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("creators: endpoint %q must be an absolute http or https URL", endpoint)
+	}
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	copied := make(map[string]string, len(headers))
+	for key, value := range headers {
+		copied[key] = value
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	httpCreator := &HTTPCreator{
+		endpoint:  endpoint,
+		headers:   copied,
+		timeout:   timeout,
+		logName:   options.name,
+		callDepth: options.callDepth,
+		clock:     options.clock,
+		client:    &http.Client{},
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	httpCreator.maxRetries.Store(defaultHTTPMaxRetries)
+	httpCreator.retryBackoff.Store(int64(defaultHTTPRetryBackoff))
+	httpCreator.Preflight()
+	return httpCreator, nil
+}
+
+// HTTPCreator is an implementation of the LogCreator interface that posts log messages as JSON to an
+// HTTP endpoint, such as the webhook of an alerting pipeline.
+//
+// Every message is posted on its own, in the BrokerMessage format, by the goroutine logging it. Requests
+// failing with a network error, a 429 or a 5xx status are retried with exponential backoff; Shutdown
+// cancels the requests and backoffs in flight.
+type HTTPCreator struct {
+	endpoint     string
+	headers      map[string]string
+	timeout      time.Duration
+	logName      types.LogCreatorName
+	callDepth    int
+	clock        logtor.Clock
+	client       *http.Client
+	maxRetries   atomic.Int32
+	retryBackoff atomic.Int64
+	ready        atomic.Bool
+	// ctx is the parent of every request, canceled by Shutdown through cancel.
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// SetMaxRetries sets how often a failed request is retried, and the wait before the first retry, which is
+// doubled for every further retry.
+//
+// Parameters:
+//   - maxRetries: The number of retries after the first attempt; negative values are treated as zero.
+//   - backoff: The wait before the first retry; zero or less keeps the current backoff.
+func (hc *HTTPCreator) SetMaxRetries(maxRetries int, backoff time.Duration) {
+	hc.maxRetries.Store(int32(max(maxRetries, 0)))
+	if backoff > 0 {
+		hc.retryBackoff.Store(int64(backoff))
+	}
+}
+
+// Preflight probes the endpoint with a HEAD request, and an OPTIONS request if the HEAD request fails,
+// and makes the HTTPCreator ready if one of them is answered with a 2xx or 3xx status.
+//
+// Returns:
+//   - bool: True if the endpoint answered the preflight.
+func (hc *HTTPCreator) Preflight() bool {
+	for _, method := range []string{http.MethodHead, http.MethodOptions} {
+		if status, err := hc.do(method, nil); err == nil && status < 400 {
+			hc.ready.Store(true)
+			return true
+		}
+	}
+	return false
+}
+
+// do sends a single request with the configured headers and timeout and returns its status code.
+func (hc *HTTPCreator) do(method string, body []byte) (int, error) {
+	ctx, cancel := context.WithTimeout(hc.ctx, hc.timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, method, hc.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range hc.headers {
+		request.Header.Set(key, value)
+	}
+	response, err := hc.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	response.Body.Close()
+	return response.StatusCode, nil
+}
+
+// post sends a JSON body, retrying network errors, 429 and 5xx statuses with exponential backoff.
+func (hc *HTTPCreator) post(body []byte) bool {
+	backoff := time.Duration(hc.retryBackoff.Load())
+	for retry := int32(0); ; retry++ {
+		status, err := hc.do(http.MethodPost, body)
+		if err == nil && status < 300 {
+			return true
+		}
+		if err == nil && status != http.StatusTooManyRequests && status < 500 {
+			return false
+		}
+		if retry >= hc.maxRetries.Load() {
+			return false
+		}
+		timer := time.NewTimer(backoff)
+		select {
+		case <-hc.ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+// LogItWithCallDepth posts a message with the specified log level and call depth to the endpoint.
+//
+// It blocks until the message is accepted by the endpoint, or until the retries are exhausted.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for recording the log entry, or zero or less for the configured call depth.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the endpoint accepted the message with a 2xx status.
+func (hc *HTTPCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	_, file, line, ok := runtime.Caller(resolveCallDepth(callDepth, hc.callDepth) - 1)
+	if !ok {
+		file = "UNKNOWN FILE"
+		line = 0
+	}
+	return hc.post(appendEntryJSON(nil, newBrokerMessage(level, hc.clock.Now(), file, line, logMessage), false))
+}
+
+// LogIt posts a message with the specified log level to the endpoint using the configured call depth.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the endpoint accepted the message with a 2xx status.
+func (hc *HTTPCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return hc.LogItWithCallDepth(level, hc.callDepth, logMessage)
+}
+
+// LogRendered posts an entry rendered by Logtor to the endpoint, with the time and caller resolved by
+// Logtor.
+//
+// Parameters:
+//   - entry: The rendered entry to be logged.
+//
+// Returns:
+//   - bool: True if the endpoint accepted the entry with a 2xx status.
+func (hc *HTTPCreator) LogRendered(entry types.RenderedEntry) bool {
+	return hc.post(appendEntryJSON(nil, newBrokerMessage(entry.Level, entry.Time, entry.File, entry.Line, entry.Value), false))
+}
+
+// LogEntry posts a structured entry with its own level, timestamp, caller and fields, implementing
+// logtor.LogCreatorV2.
+//
+// Parameters:
+//   - entry: The entry to be logged.
+//
+// Returns:
+//   - bool: The result of LogRendered for the entry.
+func (hc *HTTPCreator) LogEntry(entry types.LogEntry) bool {
+	return hc.LogRendered(entry.Rendered())
+}
+
+// LogName returns the name of the HTTPCreator.
+//
+// Returns:
+//   - LogCreatorName: The name of the log creator.
+func (hc *HTTPCreator) LogName() types.LogCreatorName {
+	return hc.logName
+}
+
+// SetCallDepth sets the call depth for recording log entries.
+//
+// Parameters:
+//   - callDepth: The depth to set for recording log entries.
+func (hc *HTTPCreator) SetCallDepth(callDepth int) {
+	hc.callDepth = callDepth
+}
+
+// CallDepth returns the call depth of the HTTPCreator.
+//
+// Returns:
+//   - int: The call depth.
+func (hc *HTTPCreator) CallDepth() int {
+	return hc.callDepth
+}
+
+// IsReady reports whether the endpoint answered a preflight and the HTTPCreator has not been shut down.
+func (hc *HTTPCreator) IsReady() bool {
+	return hc.ready.Load() && hc.ctx.Err() == nil
+}
+
+// Shutdown cancels the requests in flight and makes every later request fail.
+func (hc *HTTPCreator) Shutdown() {
+	hc.cancel()
+}
@@ -0,0 +1,173 @@
+package creators_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestHTTPCreator(t *testing.T) {
+	var (
+		mutex    sync.Mutex
+		received []creators.BrokerMessage
+		failures atomic.Int32
+	)
+	failures.Store(2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer token" || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if failures.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		var message creators.BrokerMessage
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Errorf("invalid body %s: %v", body, err)
+		}
+		mutex.Lock()
+		received = append(received, message)
+		mutex.Unlock()
+	}))
+	defer server.Close()
+
+	httpCreator, err := creators.NewHTTPCreator(server.URL, map[string]string{"Authorization": "Bearer token"}, time.Second, "", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if httpCreator.LogName() != creators.HTTP || !httpCreator.IsReady() {
+		t.Fatalf("unexpected creator state: %s ready=%t", httpCreator.LogName(), httpCreator.IsReady())
+	}
+	httpCreator.SetMaxRetries(2, time.Millisecond)
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(httpCreator)
+	newLogtor.SetLogLevel(types.INFO)
+
+	if !newLogtor.LogIt(types.ERROR, types.LogEntry{Message: "payment failed", Fields: map[string]interface{}{"order": "o-1"}}) {
+		t.Fatal("the message should be delivered after two retries")
+	}
+	mutex.Lock()
+	if len(received) != 1 || received[0].LogLevel != "ERROR" || received[0].LogMessage != "payment failed" || received[0].Fields["order"] != "o-1" {
+		t.Errorf("unexpected messages: %+v", received)
+	}
+	if !strings.HasSuffix(received[0].File, "httpcreator_test.go") {
+		t.Errorf("the message should be attributed to the caller: %s", received[0].File)
+	}
+	mutex.Unlock()
+
+	failures.Store(3)
+	if newLogtor.LogIt(types.ERROR, "lost") {
+		t.Error("the message should fail once the retries are exhausted")
+	}
+
+	httpCreator.Shutdown()
+	if httpCreator.IsReady() || httpCreator.LogIt(types.INFO, "closed") {
+		t.Error("a shut down creator should not be ready or log")
+	}
+}
+
+func TestHTTPCreatorShutdownCancelsRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	httpCreator, err := creators.NewHTTPCreator(server.URL, nil, time.Second, "Webhook", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpCreator.SetMaxRetries(5, time.Hour)
+	done := make(chan bool)
+	go func() {
+		done <- httpCreator.LogIt(types.ERROR, "retried")
+	}()
+	time.Sleep(50 * time.Millisecond)
+	httpCreator.Shutdown()
+	select {
+	case logged := <-done:
+		if logged {
+			t.Error("a canceled message should not be reported as logged")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown should cancel the backoff in flight")
+	}
+}
+
+func TestNewHTTPCreatorPreflight(t *testing.T) {
+	if _, err := creators.NewHTTPCreator("localhost:8080/logs", nil, time.Second, "", 3); err == nil {
+		t.Error("an endpoint without a scheme should be rejected")
+	}
+
+	allowOptions := atomic.Bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodOptions || !allowOptions.Load() {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer server.Close()
+
+	httpCreator, err := creators.NewHTTPCreator(server.URL, nil, time.Second, "", 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if httpCreator.IsReady() {
+		t.Error("a creator whose preflight failed should not be ready")
+	}
+	allowOptions.Store(true)
+	if !httpCreator.Preflight() || !httpCreator.IsReady() {
+		t.Error("an OPTIONS preflight should make the creator ready")
+	}
+}
+
+func TestHTTPCreatorWithOptions(t *testing.T) {
+	created := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			return
+		}
+		var message creators.BrokerMessage
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Errorf("invalid body %s: %v", body, err)
+		}
+		created <- message.Created
+	}))
+	defer server.Close()
+
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	httpCreator, err := creators.NewHTTPCreatorWithOptions(server.URL, nil, time.Second, creators.WithName("Webhook"), creators.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer httpCreator.Shutdown()
+	if httpCreator.LogName() != "Webhook" || httpCreator.CallDepth() != 3 {
+		t.Errorf("options not applied: name=%s callDepth=%d", httpCreator.LogName(), httpCreator.CallDepth())
+	}
+	if !httpCreator.LogIt(types.INFO, "clocked") {
+		t.Fatal("the message should be delivered")
+	}
+	if stamp := <-created; stamp != "2024/01/02 03:04:05" {
+		t.Errorf("the message should be stamped by the clock: %s", stamp)
+	}
+
+	if _, err := creators.NewHTTPCreatorWithOptions(server.URL, nil, time.Second, creators.WithPrefixWidth(3)); err == nil {
+		t.Error("options the HTTPCreator does not support should be rejected")
+	}
+}
@@ -0,0 +1,251 @@
+package creators
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// NewMemoryCreator creates a new instance of MemoryCreator, which keeps the latest log entries in memory
+// for tests that inspect what was logged.
+//
+// Parameters:
+//   - logName: The name representing the log creator (e.g., Memory).
+//   - capacity: The number of entries kept. Once it is reached, every new entry drops the oldest one.
+//
+// Returns:
+//   - *MemoryCreator: A pointer to the newly created MemoryCreator.
+//
+// A capacity less than 1 is treated as 1, and an empty logName defaults to Memory. The call depth
+// attributes entries to the caller of the Logtor method logging them; use SetCallDepth when calling the
+// MemoryCreator directly.
+func NewMemoryCreator(logName types.LogCreatorName, capacity int) *MemoryCreator {
+	// WithName is the only option passed, and it cannot fail.
+	memoryCreator, _ := NewMemoryCreatorWithOptions(capacity, WithName(logName))
+	return memoryCreator
+}
+
+// NewMemoryCreatorWithOptions creates a new instance of MemoryCreator configured with functional options.
+//
+// Parameters:
+//   - capacity: The number of entries kept. Once it is reached, every new entry drops the oldest one.
+//   - opts: Options such as WithName, WithCallDepth and WithClock.
+//
+// Returns:
+//   - *MemoryCreator: A pointer to the newly created MemoryCreator.
+//   - error: An error if an option is invalid or not supported by the MemoryCreator.
+//
+// The name defaults to Memory, and a capacity less than 1 is treated as 1.
+func NewMemoryCreatorWithOptions(capacity int, opts ...Option) (*MemoryCreator, error) {
+	options, err := newCreatorOptions(memoryTarget, Memory, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &MemoryCreator{
+		logName:   options.name,
+		callDepth: options.callDepth,
+		clock:     options.clock,
+		entries:   make([]types.LogEntry, max(capacity, 1)),
+	}, nil
+}
+
+// Memory is a constant representing the LogCreatorName for the Memory log creator.
+const Memory types.LogCreatorName = "Memory"
+
+// MemoryCreator is an implementation of the LogCreator interface that keeps the latest entries in a
+// circular buffer of fixed capacity.
+//
+// Messages that are not a types.LogEntry or a logtor.ContextualMessage are kept as the message of an
+// entry without fields. The fields map of an entry is copied when the entry is kept, so later changes to
+// the map passed to the log call do not change kept entries.
+type MemoryCreator struct {
+	mutex     sync.Mutex
+	logName   types.LogCreatorName
+	callDepth int
+	clock     logtor.Clock
+	entries   []types.LogEntry
+	start     int
+	length    int
+}
+
+// keep stores an entry, dropping the oldest one if the buffer is full.
+func (mc *MemoryCreator) keep(entry types.LogEntry) {
+	if entry.Fields != nil {
+		fields := make(map[string]interface{}, len(entry.Fields))
+		for key, value := range entry.Fields {
+			fields[key] = value
+		}
+		entry.Fields = fields
+	}
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	if mc.length < len(mc.entries) {
+		mc.entries[(mc.start+mc.length)%len(mc.entries)] = entry
+		mc.length++
+		return
+	}
+	mc.entries[mc.start] = entry
+	mc.start = (mc.start + 1) % len(mc.entries)
+}
+
+// LogItWithCallDepth keeps a message as an entry, with the caller found at the specified call depth.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for recording the log entry, or zero or less for the configured call depth.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: Always returns true, indicating the message was kept.
+func (mc *MemoryCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	_, file, line, ok := runtime.Caller(resolveCallDepth(callDepth, mc.CallDepth()) - 1)
+	if !ok {
+		file = "???"
+		line = 0
+	}
+	mc.keep(logEntryOf(level, mc.clock.Now(), file, line, logMessage))
+	return true
+}
+
+// LogIt keeps a message as an entry using the configured call depth.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: Always returns true, indicating the message was kept.
+func (mc *MemoryCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return mc.LogItWithCallDepth(level, mc.CallDepth(), logMessage)
+}
+
+// LogRendered keeps an entry rendered by Logtor, with the time and caller resolved by Logtor.
+//
+// Parameters:
+//   - entry: The rendered entry to be kept.
+//
+// Returns:
+//   - bool: Always returns true, indicating the entry was kept.
+func (mc *MemoryCreator) LogRendered(entry types.RenderedEntry) bool {
+	mc.keep(logEntryOf(entry.Level, entry.Time, entry.File, entry.Line, entry.Value))
+	return true
+}
+
+// LogEntry keeps a structured entry as it is, implementing logtor.LogCreatorV2.
+//
+// Parameters:
+//   - entry: The entry to be kept.
+//
+// Returns:
+//   - bool: Always returns true, indicating the entry was kept.
+func (mc *MemoryCreator) LogEntry(entry types.LogEntry) bool {
+	mc.keep(entry)
+	return true
+}
+
+// Entries returns the kept entries.
+//
+// Returns:
+//   - []types.LogEntry: The kept entries, from the oldest to the newest.
+func (mc *MemoryCreator) Entries() []types.LogEntry {
+	return mc.filter(func(types.LogEntry) bool { return true })
+}
+
+// EntriesForLevel returns the kept entries logged at the specified level.
+//
+// Parameters:
+//   - level: The log level of the entries to return.
+//
+// Returns:
+//   - []types.LogEntry: The kept entries at the level, from the oldest to the newest.
+func (mc *MemoryCreator) EntriesForLevel(level types.LogLevel) []types.LogEntry {
+	return mc.filter(func(entry types.LogEntry) bool { return entry.Level == level })
+}
+
+func (mc *MemoryCreator) filter(match func(types.LogEntry) bool) []types.LogEntry {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	entries := make([]types.LogEntry, 0, mc.length)
+	for i := 0; i < mc.length; i++ {
+		if entry := mc.entries[(mc.start+i)%len(mc.entries)]; match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Contains reports whether the message of a kept entry contains a substring. Messages that are not
+// strings are matched in their "%+v" representation.
+//
+// Parameters:
+//   - substring: The text to look for.
+//
+// Returns:
+//   - bool: True if a kept message contains the substring.
+func (mc *MemoryCreator) Contains(substring string) bool {
+	return len(mc.filter(func(entry types.LogEntry) bool {
+		message, ok := entry.Message.(string)
+		if !ok {
+			message = fmt.Sprintf("%+v", entry.Message)
+		}
+		return strings.Contains(message, substring)
+	})) > 0
+}
+
+// Clear drops the kept entries.
+func (mc *MemoryCreator) Clear() {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	clear(mc.entries)
+	mc.start, mc.length = 0, 0
+}
+
+// Len returns the number of kept entries.
+//
+// Returns:
+//   - int: The number of kept entries, at most the capacity.
+func (mc *MemoryCreator) Len() int {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	return mc.length
+}
+
+// LogName returns the name of the MemoryCreator.
+//
+// Returns:
+//   - LogCreatorName: The name of the log creator.
+func (mc *MemoryCreator) LogName() types.LogCreatorName {
+	return mc.logName
+}
+
+// SetCallDepth sets the call depth of the MemoryCreator.
+//
+// Parameters:
+//   - callDepth: The call depth to set.
+func (mc *MemoryCreator) SetCallDepth(callDepth int) {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	mc.callDepth = callDepth
+}
+
+// CallDepth returns the call depth of the MemoryCreator.
+//
+// Returns:
+//   - int: The call depth.
+func (mc *MemoryCreator) CallDepth() int {
+	mc.mutex.Lock()
+	defer mc.mutex.Unlock()
+	return mc.callDepth
+}
+
+// IsReady reports whether the MemoryCreator is ready, which it always is.
+func (mc *MemoryCreator) IsReady() bool {
+	return true
+}
+
+// Shutdown does nothing; the kept entries stay available.
+func (mc *MemoryCreator) Shutdown() {}
@@ -0,0 +1,87 @@
+package creators_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestMemoryCreator(t *testing.T) {
+	memoryCreator := creators.NewMemoryCreator("Memory", 3)
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(memoryCreator)
+	newLogtor.SetLogLevel(types.TRACE)
+
+	newLogtor.LogIt(types.INFO, "first")
+	newLogtor.LogIt(types.ERROR, "second")
+	newLogtor.LogIt(types.INFO, types.LogEntry{Message: "third", Fields: map[string]interface{}{"user": "u-1"}})
+	newLogtor.LogIt(types.INFO, struct{ ID int }{ID: 4})
+
+	entries := memoryCreator.Entries()
+	if memoryCreator.Len() != 3 || len(entries) != 3 {
+		t.Fatalf("unexpected entries: %v", entries)
+	}
+	if messages := fmt.Sprintf("%v %v %v", entries[0].Message, entries[1].Message, entries[2].Message); messages != "second third {4}" {
+		t.Errorf("the oldest entry should be dropped, got %s", messages)
+	}
+	if entries[1].Fields["user"] != "u-1" || entries[1].Level != types.INFO {
+		t.Errorf("unexpected entry: %+v", entries[1])
+	}
+	if !strings.HasSuffix(entries[0].Caller, "memorycreator_test.go") {
+		t.Errorf("entry should be attributed to the caller: %s", entries[0].Caller)
+	}
+
+	if infos := memoryCreator.EntriesForLevel(types.INFO); len(infos) != 2 {
+		t.Errorf("unexpected INFO entries: %v", infos)
+	}
+	if !memoryCreator.Contains("thi") || !memoryCreator.Contains("{ID:4}") || memoryCreator.Contains("first") {
+		t.Error("Contains should match the kept messages only")
+	}
+
+	memoryCreator.Clear()
+	if memoryCreator.Len() != 0 || len(memoryCreator.Entries()) != 0 {
+		t.Error("Clear should drop every entry")
+	}
+	memoryCreator.LogIt(types.WARN, "after clear")
+	if entries := memoryCreator.Entries(); len(entries) != 1 || entries[0].Message != "after clear" {
+		t.Errorf("unexpected entries after Clear: %v", entries)
+	}
+
+	memoryCreator.Shutdown()
+	if !memoryCreator.IsReady() {
+		t.Error("MemoryCreator should stay ready")
+	}
+}
+
+func TestMemoryCreatorMinimumCapacity(t *testing.T) {
+	memoryCreator := creators.NewMemoryCreator("Memory", 0)
+	memoryCreator.LogIt(types.INFO, "first")
+	memoryCreator.LogIt(types.INFO, "second")
+	if entries := memoryCreator.Entries(); len(entries) != 1 || entries[0].Message != "second" {
+		t.Errorf("unexpected entries: %v", entries)
+	}
+}
+
+func TestMemoryCreatorWithOptions(t *testing.T) {
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	memoryCreator, err := creators.NewMemoryCreatorWithOptions(5, creators.WithClock(clock), creators.WithCallDepth(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+	memoryCreator.LogIt(types.INFO, "frozen")
+	entries := memoryCreator.Entries()
+	if memoryCreator.LogName() != creators.Memory || len(entries) != 1 || !entries[0].Timestamp.Equal(clock.Now()) {
+		t.Errorf("the entry should be stamped by the clock: %s %+v", memoryCreator.LogName(), entries)
+	}
+	if !strings.HasSuffix(entries[0].Caller, "memorycreator_test.go") {
+		t.Errorf("entry should be attributed to the caller: %s", entries[0].Caller)
+	}
+	if _, err := creators.NewMemoryCreatorWithOptions(5, creators.WithPrefixWidth(3)); err == nil {
+		t.Error("options the MemoryCreator does not support should be rejected")
+	}
+}
@@ -0,0 +1,321 @@
+package creators
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// Option configures a log creator built with NewBaseCreatorWithOptions, NewFileCreatorWithOptions,
+// NewBrokerCreatorWithOptions, NewMemoryCreatorWithOptions, NewSyslogCreatorWithOptions or
+// NewHTTPCreatorWithOptions.
+//
+// Options are validated eagerly: an invalid value, or an option the creator does not support,
+// makes the constructor return an error.
+type Option func(*creatorOptions) error
+
+// FileCreatorOption is kept as an alias of Option for options that only apply to the FileCreator.
+type FileCreatorOption = Option
+
+const (
+	consoleTarget = "console"
+	fileTarget    = "file"
+	brokerTarget  = "broker"
+	memoryTarget  = "memory"
+	syslogTarget  = "syslog"
+	httpTarget    = "http"
+)
+
+// creatorVersion is the version reported by the built-in creators through logtor.CreatorMetadata.
+const creatorVersion = "1.0.0"
+
+// kafkaType is the creator type the BrokerCreator reports through logtor.CreatorMetadata.
+const kafkaType = "kafka"
+
+// creatorOptions holds the resolved options of a log creator.
+type creatorOptions struct {
+	target           string
+	name             types.LogCreatorName
+	callDepth        int
+	prefixWidth      int
+	nfsReopen        bool
+	maxBytes         int64
+	maxBackups       int
+	rotationInterval time.Duration
+	jsonFormat       bool
+	prettyJSON       bool
+	failWriter       io.Writer
+	errorSink        logtor.LogCreator
+	saslUser         string
+	saslPassword     string
+	retry            RetryConfig
+	clock            logtor.Clock
+}
+
+// newCreatorOptions resolves the options of a creator. The default call depth points at the caller
+// of the creator's LogIt method and the default prefix width fits the longest built-in log level.
+func newCreatorOptions(target string, defaultName types.LogCreatorName, opts []Option) (*creatorOptions, error) {
+	options := &creatorOptions{
+		target:      target,
+		name:        defaultName,
+		callDepth:   3,
+		prefixWidth: 5,
+		clock:       logtor.SystemClock{},
+	}
+	switch target {
+	case brokerTarget:
+		options.callDepth = 2
+	case memoryTarget:
+		// The MemoryCreator attributes entries to the caller of the Logtor method logging them.
+		options.callDepth = 4
+	}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+	return options, nil
+}
+
+func (o *creatorOptions) supports(option string, targets ...string) error {
+	for _, target := range targets {
+		if o.target == target {
+			return nil
+		}
+	}
+	return fmt.Errorf("creators: %s is not supported by the %s creator", option, o.target)
+}
+
+// resolveCallDepth returns the call depth a LogItWithCallDepth call records its entry with.
+//
+// A call depth of zero or less falls back to the configured one. The configured depth is meant for
+// LogIt, which adds a frame of its own on top of LogItWithCallDepth, so one frame less is used for
+// a direct call and both attribute the entry to the same caller.
+func resolveCallDepth(callDepth, configured int) int {
+	if callDepth > 0 {
+		return callDepth
+	}
+	if configured > 1 {
+		return configured - 1
+	}
+	return 1
+}
+
+// WithName sets the name the log creator is registered under. An empty name keeps the creator's default name.
+func WithName(name types.LogCreatorName) Option {
+	return func(o *creatorOptions) error {
+		if name != "" {
+			o.name = name
+		}
+		return nil
+	}
+}
+
+// WithCallDepth sets the call depth used to find the caller's file and line. It must not be negative.
+func WithCallDepth(callDepth int) Option {
+	return func(o *creatorOptions) error {
+		if callDepth < 0 {
+			return fmt.Errorf("creators: call depth must not be negative, got %d", callDepth)
+		}
+		o.callDepth = callDepth
+		return nil
+	}
+}
+
+// WithPrefixWidth sets the width the log level prefix is padded to. It must not be negative.
+// Only the console and file creators support it.
+func WithPrefixWidth(width int) Option {
+	return func(o *creatorOptions) error {
+		if err := o.supports("WithPrefixWidth", consoleTarget, fileTarget); err != nil {
+			return err
+		}
+		if width < 0 {
+			return fmt.Errorf("creators: prefix width must not be negative, got %d", width)
+		}
+		o.prefixWidth = width
+		return nil
+	}
+}
+
+// WithJSONFormat makes the console creator write every entry as a single JSON object per line,
+// using the same fields as BrokerMessage, instead of the colored text format.
+// Only the console creator supports it.
+//
+// Parameters:
+//   - enabled: Whether entries are written as JSON.
+func WithJSONFormat(enabled bool) Option {
+	return func(o *creatorOptions) error {
+		if err := o.supports("WithJSONFormat", consoleTarget); err != nil {
+			return err
+		}
+		o.jsonFormat = enabled
+		return nil
+	}
+}
+
+// WithPrettyJSON makes the console creator write every entry as indented JSON, which is easier to read
+// during development than one object per line. It implies WithJSONFormat(true).
+// Only the console creator supports it.
+//
+// Parameters:
+//   - enabled: Whether JSON entries are indented.
+func WithPrettyJSON(enabled bool) Option {
+	return func(o *creatorOptions) error {
+		if err := o.supports("WithPrettyJSON", consoleTarget); err != nil {
+			return err
+		}
+		o.prettyJSON = enabled
+		return nil
+	}
+}
+
+// WithNFSReopen enables re-opening the log file when a write fails with ESTALE.
+//
+// On network filesystems such as NFS, writes can fail with a stale file handle after a server reboot.
+// When enabled, the FileCreator re-opens the file and retries the write once before reporting a failure.
+// It is disabled by default since the extra error handling is not needed on local disks.
+//
+// Parameters:
+//   - enabled: Whether stale file handles should be re-opened.
+func WithNFSReopen(enabled bool) FileCreatorOption {
+	return func(o *creatorOptions) error {
+		if err := o.supports("WithNFSReopen", fileTarget); err != nil {
+			return err
+		}
+		o.nfsReopen = enabled
+		return nil
+	}
+}
+
+// WithRotation enables size based rotation of the log file.
+//
+// When a write would grow the file past maxBytes, the FileCreator renames the file to filename.1, shifting
+// existing backups to filename.2 and so on and removing those past maxBackups, and reopens filename. With
+// no backups the file is removed instead. Rotation is disabled by default.
+//
+// Parameters:
+//   - maxBytes: The size the log file may grow to, which must be positive.
+//   - maxBackups: The number of rotated files to keep, which must not be negative.
+func WithRotation(maxBytes int64, maxBackups int) FileCreatorOption {
+	return func(o *creatorOptions) error {
+		if err := o.supports("WithRotation", fileTarget); err != nil {
+			return err
+		}
+		if maxBytes <= 0 {
+			return fmt.Errorf("creators: rotation size must be positive, got %d", maxBytes)
+		}
+		if maxBackups < 0 {
+			return fmt.Errorf("creators: rotation backups must not be negative, got %d", maxBackups)
+		}
+		if o.rotationInterval > 0 {
+			return errRotationCombined
+		}
+		o.maxBytes = maxBytes
+		o.maxBackups = maxBackups
+		return nil
+	}
+}
+
+// WithTimeRotation enables time based rotation of the log file, regardless of its size.
+//
+// At every multiple of interval, counted from the zero time of the creator's clock so hourly and daily
+// rotations happen on the hour and at midnight UTC, the FileCreator renames the file to include the
+// start of the period it covers, e.g. app-2024-01-15T13:00:00.log for app.log, removes the oldest such
+// files past maxBackups, and reopens filename. The rotation runs on a goroutine stopped by Shutdown and
+// waits on the creator's clock, so a logtor.FakeClock implementing logtor.TimerClock drives it in tests.
+// It cannot be combined with WithRotation.
+//
+// Parameters:
+//   - interval: The length of the period a log file covers, which must be at least a second.
+//   - maxBackups: The number of rotated files to keep, which must not be negative.
+func WithTimeRotation(interval time.Duration, maxBackups int) FileCreatorOption {
+	return func(o *creatorOptions) error {
+		if err := o.supports("WithTimeRotation", fileTarget); err != nil {
+			return err
+		}
+		if interval < time.Second {
+			return fmt.Errorf("creators: rotation interval must be at least a second, got %s", interval)
+		}
+		if maxBackups < 0 {
+			return fmt.Errorf("creators: rotation backups must not be negative, got %d", maxBackups)
+		}
+		if o.maxBytes > 0 {
+			return errRotationCombined
+		}
+		o.rotationInterval = interval
+		o.maxBackups = maxBackups
+		return nil
+	}
+}
+
+// errRotationCombined is returned when both WithRotation and WithTimeRotation are given.
+var errRotationCombined = fmt.Errorf("creators: WithRotation and WithTimeRotation cannot be combined")
+
+// WithClock sets the clock the log creator takes the timestamps of its entries from, so tests can freeze
+// time and compare exact output. Defaults to logtor.SystemClock.
+//
+// Parameters:
+//   - clock: The clock to use.
+func WithClock(clock logtor.Clock) Option {
+	return func(o *creatorOptions) error {
+		if clock == nil {
+			return fmt.Errorf("creators: clock must not be nil")
+		}
+		o.clock = clock
+		return nil
+	}
+}
+
+// WithFailWriter sets the writer that receives the base64 encoded messages the BrokerCreator failed
+// to deliver, one per line, so they can be replayed. Only the broker creator supports it.
+func WithFailWriter(failWriter io.Writer) Option {
+	return func(o *creatorOptions) error {
+		if err := o.supports("WithFailWriter", brokerTarget); err != nil {
+			return err
+		}
+		o.failWriter = failWriter
+		return nil
+	}
+}
+
+// WithErrorSink sets the log creator that reports the errors of the BrokerCreator's producer at ERROR level,
+// so delivery failures show up alongside the application logs. Pass the Logtor's default creator to
+// report them where the Logtor falls back to. Defaults to a console creator. Only the broker creator supports it.
+//
+// Parameters:
+//   - sink: The log creator receiving the producer errors.
+func WithErrorSink(sink logtor.LogCreator) Option {
+	return func(o *creatorOptions) error {
+		if err := o.supports("WithErrorSink", brokerTarget); err != nil {
+			return err
+		}
+		o.errorSink = sink
+		return nil
+	}
+}
+
+// WithSASLPlain makes the BrokerCreator authenticate with SASL/PLAIN, which sends the password as is and
+// should only be used over TLS. Only the broker creator supports it; NewBrokerCreatorWithProducer
+// ignores it, as the producer is already configured. Use NewBrokerCreatorWithSASL for SCRAM.
+//
+// Parameters:
+//   - username: The user name to authenticate as, which must not be empty.
+//   - password: The password of the user.
+func WithSASLPlain(username, password string) Option {
+	return func(o *creatorOptions) error {
+		if err := o.supports("WithSASLPlain", brokerTarget); err != nil {
+			return err
+		}
+		if username == "" {
+			return fmt.Errorf("creators: SASL user name must not be empty")
+		}
+		o.saslUser, o.saslPassword = username, password
+		return nil
+	}
+}
@@ -0,0 +1,189 @@
+package creators
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+var callerPattern = regexp.MustCompile(`(\w+\.go):(\d+): `)
+
+// textCallers extracts the "file.go:line" caller of every text entry in the output.
+func textCallers(t *testing.T, output string) []string {
+	t.Helper()
+	var callers []string
+	for _, match := range callerPattern.FindAllStringSubmatch(output, -1) {
+		callers = append(callers, match[1]+":"+match[2])
+	}
+	return callers
+}
+
+// assertNextLine checks that two callers name this file on consecutive lines.
+func assertNextLine(t *testing.T, callers []string) {
+	t.Helper()
+	if len(callers) != 2 {
+		t.Fatalf("expected 2 callers, got %v", callers)
+	}
+	firstFile, firstLine, _ := strings.Cut(callers[0], ":")
+	secondFile, secondLine, _ := strings.Cut(callers[1], ":")
+	first, _ := strconv.Atoi(firstLine)
+	second, _ := strconv.Atoi(secondLine)
+	if firstFile != "options_internal_test.go" || secondFile != firstFile || second != first+1 {
+		t.Errorf("call depth 0 should attribute the caller like LogIt: %v", callers)
+	}
+}
+
+func TestResolveCallDepth(t *testing.T) {
+	for _, tc := range []struct{ callDepth, configured, expected int }{
+		{4, 3, 4},
+		{0, 3, 2},
+		{-1, 2, 1},
+		{0, 0, 1},
+	} {
+		if actual := resolveCallDepth(tc.callDepth, tc.configured); actual != tc.expected {
+			t.Errorf("resolveCallDepth(%d, %d) = %d, want %d", tc.callDepth, tc.configured, actual, tc.expected)
+		}
+	}
+}
+
+func TestZeroCallDepthMatchesLogIt(t *testing.T) {
+	t.Run("console", func(t *testing.T) {
+		logCreator, err := NewBaseCreatorWithOptions(WithCallDepth(3))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var output bytes.Buffer
+		baseCreator := logCreator.(*BaseCreator)
+		baseCreator.log.SetOutput(&output)
+		baseCreator.LogIt(types.INFO, "LogIt")
+		baseCreator.LogItWithCallDepth(types.INFO, 0, "LogItWithCallDepth")
+		assertNextLine(t, textCallers(t, output.String()))
+	})
+
+	t.Run("file", func(t *testing.T) {
+		logCreator, err := NewFileCreatorWithOptions(filepath.Join(t.TempDir(), "temp.log"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		fileCreator := logCreator.(*FileCreator)
+		var output captureFile
+		fileCreator.file = &output
+		fileCreator.LogIt(types.INFO, "LogIt")
+		fileCreator.LogItWithCallDepth(types.INFO, -1, "LogItWithCallDepth")
+		assertNextLine(t, textCallers(t, output.String()))
+	})
+
+	t.Run("histogram", func(t *testing.T) {
+		logCreator, err := NewFileCreatorWithOptions(filepath.Join(t.TempDir(), "temp.log"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var output captureFile
+		logCreator.(*FileCreator).file = &output
+		histogramCreator := NewHistogramCreator(logCreator, nil)
+		histogramCreator.LogIt(types.INFO, "LogIt")
+		histogramCreator.LogItWithCallDepth(types.INFO, 0, "LogItWithCallDepth")
+		assertNextLine(t, textCallers(t, output.String()))
+	})
+
+	t.Run("broker", func(t *testing.T) {
+		producer := newDiscardProducer()
+		producer.keep = true
+		brokerCreator, err := NewBrokerCreatorWithProducer(producer, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		brokerCreator.LogIt(types.INFO, "LogIt")
+		brokerCreator.LogItWithCallDepth(types.INFO, 0, "LogItWithCallDepth")
+		brokerCreator.Shutdown()
+
+		var callers []string
+		for _, msg := range producer.all {
+			var message BrokerMessage
+			if err := json.Unmarshal(msg.Value.(sarama.ByteEncoder), &message); err != nil {
+				t.Fatal(err)
+			}
+			callers = append(callers, filepath.Base(message.File)+":"+strconv.Itoa(message.Line))
+		}
+		assertNextLine(t, callers)
+	})
+}
+
+// nextLine returns the line following the caller's line.
+func nextLine() int {
+	_, _, line, _ := runtime.Caller(1)
+	return line + 1
+}
+
+func TestCreatorsWithFrozenClock(t *testing.T) {
+	clock := logtor.NewFakeClock(time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC))
+	text := clock.Now().Local().Format("2006/01/02 15:04:05")
+
+	t.Run("file", func(t *testing.T) {
+		logCreator, err := NewFileCreatorWithOptions(filepath.Join(t.TempDir(), "temp.log"), WithClock(clock))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var output captureFile
+		logCreator.(*FileCreator).file = &output
+		line := nextLine()
+		logCreator.LogIt(types.WARN, "disk nearly full")
+
+		expected := fmt.Sprintf("WARN  : %s options_internal_test.go:%d: disk nearly full\n", text, line)
+		if output.String() != expected {
+			t.Errorf("unexpected output:\n got %q\nwant %q", output.String(), expected)
+		}
+	})
+
+	t.Run("console json", func(t *testing.T) {
+		logCreator, err := NewBaseCreatorWithOptions(WithJSONFormat(true), WithClock(clock))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var output bytes.Buffer
+		logCreator.(*BaseCreator).log.SetOutput(&output)
+		_, file, _, _ := runtime.Caller(0)
+		line := nextLine()
+		logCreator.LogIt(types.INFO, "order placed")
+
+		expected := fmt.Sprintf(`{"loglevel":"INFO","created":"2024/03/04 05:06:07","file":%q,"line":%d,"log_message":"order placed"}`+"\n", file, line)
+		if output.String() != expected {
+			t.Errorf("unexpected output:\n got %q\nwant %q", output.String(), expected)
+		}
+	})
+
+	t.Run("broker", func(t *testing.T) {
+		clock.Advance(time.Hour)
+		producer := newDiscardProducer()
+		brokerCreator, err := NewBrokerCreatorWithProducer(producer, "test", WithClock(clock))
+		if err != nil {
+			t.Fatal(err)
+		}
+		brokerCreator.LogIt(types.ERROR, "payment failed")
+		brokerCreator.Shutdown()
+
+		var message BrokerMessage
+		if err := json.Unmarshal(producer.last.Value.(sarama.ByteEncoder), &message); err != nil {
+			t.Fatal(err)
+		}
+		if message.Created != "2024/03/04 06:06:07" {
+			t.Errorf("unexpected created time: %s", message.Created)
+		}
+	})
+
+	if _, err := NewBaseCreatorWithOptions(WithClock(nil)); err == nil {
+		t.Error("a nil clock should be rejected")
+	}
+}
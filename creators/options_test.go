@@ -0,0 +1,87 @@
+package creators_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestFileCreatorWithOptions(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "temp.log")
+	fileCreator, err := creators.NewFileCreatorWithOptions(filename,
+		creators.WithName("AuditFile"),
+		creators.WithCallDepth(4),
+		creators.WithPrefixWidth(7),
+		creators.WithNFSReopen(true),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fileCreator.LogName() != "AuditFile" || fileCreator.CallDepth() != 4 {
+		t.Errorf("options not applied: name=%s callDepth=%d", fileCreator.LogName(), fileCreator.CallDepth())
+	}
+
+	settings := fileCreator.(logtor.CreatorDescriber).Describe()
+	if settings["type"] != "file" || settings["file_name"] != filename || settings["prefix_width"] != 7 || settings["nfs_reopen"] != true {
+		t.Errorf("unexpected description: %v", settings)
+	}
+}
+
+func TestBaseCreatorWithOptionsDefaults(t *testing.T) {
+	baseCreator, err := creators.NewBaseCreatorWithOptions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if baseCreator.LogName() != creators.Console {
+		t.Errorf("unexpected default name: %s", baseCreator.LogName())
+	}
+	if result := baseCreator.LogIt(types.INFO, "Example Log Message"); !result {
+		t.Error("Log not recorded")
+	}
+}
+
+func TestPositionalConstructorsClampNegativeValues(t *testing.T) {
+	baseCreator, err := creators.NewBaseCreator("Console", -1, -5)
+	if err != nil {
+		t.Fatalf("negative values should be clamped, got %v", err)
+	}
+	if baseCreator.CallDepth() != 0 {
+		t.Errorf("unexpected call depth: %d", baseCreator.CallDepth())
+	}
+	fileCreator, err := creators.NewFileCreator(filepath.Join(t.TempDir(), "temp.log"), "File", -1, -5)
+	if err != nil {
+		t.Fatalf("negative values should be clamped, got %v", err)
+	}
+	if !fileCreator.LogIt(types.INFO, "Example File Log Message") {
+		t.Error("Log not recorded")
+	}
+	fileCreator.Shutdown()
+}
+
+func TestCreatorOptionsValidateEagerly(t *testing.T) {
+	if _, err := creators.NewBaseCreatorWithOptions(creators.WithCallDepth(-1)); err == nil {
+		t.Error("negative call depth should be rejected")
+	}
+	if _, err := creators.NewBaseCreatorWithOptions(creators.WithPrefixWidth(-1)); err == nil {
+		t.Error("negative prefix width should be rejected")
+	}
+	if _, err := creators.NewBaseCreatorWithOptions(creators.WithNFSReopen(true)); err == nil {
+		t.Error("WithNFSReopen should not be supported by the console creator")
+	}
+	if _, err := creators.NewFileCreatorWithOptions(filepath.Join(t.TempDir(), "temp.log"), creators.WithFailWriter(&bytes.Buffer{})); err == nil {
+		t.Error("WithFailWriter should not be supported by the file creator")
+	}
+	if _, err := creators.NewFileCreatorWithOptions(""); err == nil {
+		t.Error("empty file name should be rejected")
+	}
+	if _, err := creators.NewBrokerCreatorWithOptions(nil, "test"); err == nil {
+		t.Error("missing broker addresses should be rejected")
+	}
+	if _, err := creators.NewBrokerCreatorWithOptions(brokers, "", creators.WithPrefixWidth(5)); err == nil {
+		t.Error("empty topic should be rejected")
+	}
+}
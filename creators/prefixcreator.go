@@ -0,0 +1,130 @@
+package creators
+
+import (
+	"fmt"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// NewPrefixCreator creates a new instance of PrefixCreator, which marks every message with the name of
+// a component before passing it on to another log creator.
+//
+// Messages are rendered with "%+v" and prefixed with "[prefix] ". A types.LogEntry, or a
+// logtor.ContextualMessage, gets a "component" field instead, so structured consumers receive the
+// component as a field rather than as part of the message text.
+//
+// Parameters:
+//   - prefix: The name of the component, such as "auth" or "billing".
+//   - inner: The log creator the messages are passed on to.
+//
+// Returns:
+//   - logtor.LogCreator: The PrefixCreator. Its name and readiness are those of inner.
+func NewPrefixCreator(prefix string, inner logtor.LogCreator) logtor.LogCreator {
+	return &PrefixCreator{
+		prefix: prefix,
+		marker: "[" + prefix + "] ",
+		inner:  inner,
+	}
+}
+
+// PrefixCreator is an implementation of the LogCreator interface that marks messages with the name of
+// a component before passing them on to another log creator.
+type PrefixCreator struct {
+	prefix string
+	marker string
+	inner  logtor.LogCreator
+}
+
+func (pc *PrefixCreator) mark(logMessage interface{}) interface{} {
+	var entry types.LogEntry
+	switch typed := logMessage.(type) {
+	case types.LogEntry:
+		entry = typed
+	case logtor.ContextualMessage:
+		entry = types.LogEntry{Message: typed.Message, Fields: typed.Fields}
+	default:
+		return pc.marker + fmt.Sprintf("%+v", logMessage)
+	}
+	fields := make(map[string]interface{}, len(entry.Fields)+1)
+	for key, value := range entry.Fields {
+		fields[key] = value
+	}
+	fields["component"] = pc.prefix
+	entry.Fields = fields
+	return entry
+}
+
+// LogItWithCallDepth marks the message and logs it with the inner log creator.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for recording the log entry, or zero or less for the configured call depth.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: The result of the inner log creator.
+func (pc *PrefixCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	if callDepth <= 0 {
+		// This frame stands in for the inner creator's LogIt, so its configured depth applies unchanged.
+		return pc.inner.LogItWithCallDepth(level, pc.inner.CallDepth(), pc.mark(logMessage))
+	}
+	return pc.inner.LogItWithCallDepth(level, callDepth+1, pc.mark(logMessage))
+}
+
+// LogIt marks the message and logs it with the inner log creator using its call depth.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: The result of the inner log creator.
+func (pc *PrefixCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return pc.inner.LogItWithCallDepth(level, pc.inner.CallDepth(), pc.mark(logMessage))
+}
+
+// LogName returns the name of the inner log creator.
+//
+// Returns:
+//   - LogCreatorName: The name of the log creator.
+func (pc *PrefixCreator) LogName() types.LogCreatorName {
+	return pc.inner.LogName()
+}
+
+// SetCallDepth sets the call depth of the inner log creator.
+//
+// Parameters:
+//   - callDepth: The depth to set for recording log entries.
+func (pc *PrefixCreator) SetCallDepth(callDepth int) {
+	pc.inner.SetCallDepth(callDepth)
+}
+
+// CallDepth returns the call depth of the inner log creator.
+//
+// Returns:
+//   - int: The current call depth setting for recording log entries.
+func (pc *PrefixCreator) CallDepth() int {
+	return pc.inner.CallDepth()
+}
+
+// IsReady reports whether the inner log creator is ready.
+func (pc *PrefixCreator) IsReady() bool {
+	return pc.inner.IsReady()
+}
+
+// Flush flushes the inner log creator if it buffers entries.
+//
+// Returns:
+//   - error: The error of the inner log creator's Flush, or nil if it does not implement logtor.Flusher.
+func (pc *PrefixCreator) Flush() error {
+	if flusher, ok := pc.inner.(logtor.Flusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
+
+// Shutdown shuts down the inner log creator.
+func (pc *PrefixCreator) Shutdown() {
+	pc.inner.Shutdown()
+}
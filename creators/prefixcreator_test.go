@@ -0,0 +1,45 @@
+package creators_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestPrefixCreator(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "temp.log")
+	fileCreator, err := creators.NewFileCreatorWithOptions(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prefixCreator := creators.NewPrefixCreator("billing", fileCreator)
+	if prefixCreator.LogName() != fileCreator.LogName() || !prefixCreator.IsReady() {
+		t.Error("name and readiness should be those of the inner creator")
+	}
+
+	fields := map[string]interface{}{"invoice": 12}
+	prefixCreator.LogIt(types.INFO, "invoice sent")
+	prefixCreator.LogItWithCallDepth(types.ERROR, 0, types.LogEntry{Message: "invoice rejected", Fields: fields})
+	if len(fields) != 1 {
+		t.Errorf("the caller's fields should not be modified: %v", fields)
+	}
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %q", content)
+	}
+	if !strings.HasSuffix(lines[0], "[billing] invoice sent") {
+		t.Errorf("string messages should be prefixed: %q", lines[0])
+	}
+	if !strings.HasSuffix(lines[1], "invoice rejected component=billing invoice=12") {
+		t.Errorf("entries should get a component field: %q", lines[1])
+	}
+}
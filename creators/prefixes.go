@@ -0,0 +1,46 @@
+package creators
+
+import (
+	"fmt"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// levelPrefixes holds the rendered "<level padded to width> : " prefix of every built-in log level,
+// so writing an entry only needs a map lookup instead of formatting the prefix each time.
+type levelPrefixes struct {
+	width    int
+	colored  bool
+	prefixes map[types.LogLevel]string
+}
+
+// newLevelPrefixes renders the prefixes of the levels in types.LogLevels. When colored is set,
+// each prefix starts with the level's color from types.GetColorForLogLevel, so the colors in effect
+// at construction are used.
+func newLevelPrefixes(width int, colored bool) *levelPrefixes {
+	levels := types.LogLevels()
+	lp := &levelPrefixes{
+		width:    width,
+		colored:  colored,
+		prefixes: make(map[types.LogLevel]string, len(levels)),
+	}
+	for _, level := range levels {
+		lp.prefixes[level] = lp.render(level)
+	}
+	return lp
+}
+
+func (lp *levelPrefixes) render(level types.LogLevel) string {
+	if lp.colored {
+		return fmt.Sprintf("%s%-*s : ", types.GetColorForLogLevel(level), lp.width, level)
+	}
+	return fmt.Sprintf("%-*s : ", lp.width, level)
+}
+
+// prefix returns the prefix of the level. Levels registered later are rendered on demand.
+func (lp *levelPrefixes) prefix(level types.LogLevel) string {
+	if prefix, ok := lp.prefixes[level]; ok {
+		return prefix
+	}
+	return lp.render(level)
+}
@@ -0,0 +1,322 @@
+package creators
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3PutObjectAPI is the part of the S3 client used by the S3Creator.
+type s3PutObjectAPI interface {
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+}
+
+// NewS3Creator creates a new instance of S3Creator, which archives log messages to Amazon S3.
+//
+// Log entries are buffered in memory and uploaded gzip-compressed on every flush interval as
+// s3://bucket/keyPrefix/YYYYMMDD-HHmmss.log.gz. Credentials are resolved from the default AWS
+// configuration chain (environment, shared config files, instance roles).
+//
+// Parameters:
+//   - bucket: The S3 bucket receiving the log archives.
+//   - keyPrefix: The key prefix of the uploaded objects.
+//   - region: The AWS region of the bucket.
+//   - logName: The name representing the log creator (e.g., S3).
+//   - callDepth: The call depth to be used in log output.
+//   - flushInterval: How often the buffered entries are uploaded.
+//
+// Returns:
+//   - *S3Creator: A pointer to the newly created S3Creator.
+//   - error: An error if the AWS configuration cannot be loaded or the arguments are invalid.
+//
+// If logName is an empty string, it defaults to S3.
+func NewS3Creator(bucket, keyPrefix, region string, logName types.LogCreatorName, callDepth int, flushInterval time.Duration) (logtor.LogCreator, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("creators: bucket must not be empty")
+	}
+	awsConfig, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	return newS3Creator(s3.NewFromConfig(awsConfig), bucket, keyPrefix, logName, callDepth, flushInterval)
+}
+
+// NewS3CreatorWithAdaptiveFlush creates a new instance of S3Creator whose upload interval follows the
+// arrival rate of log entries instead of a fixed flush interval.
+//
+// Busy periods are uploaded in batches of about the adaptive flush's target size, while quiet periods
+// wait up to its maximum interval. The current arrival rate and interval are reported by CreatorStats.
+//
+// Parameters:
+//   - bucket: The S3 bucket receiving the log archives.
+//   - keyPrefix: The key prefix of the uploaded objects.
+//   - region: The AWS region of the bucket.
+//   - logName: The name representing the log creator (e.g., S3).
+//   - callDepth: The call depth to be used in log output.
+//   - adaptive: The adaptive flush deciding when the buffered entries are uploaded.
+//
+// Returns:
+//   - *S3Creator: A pointer to the newly created S3Creator.
+//   - error: An error if the AWS configuration cannot be loaded or the arguments are invalid.
+//
+// If logName is an empty string, it defaults to S3.
+func NewS3CreatorWithAdaptiveFlush(bucket, keyPrefix, region string, logName types.LogCreatorName, callDepth int, adaptive *AdaptiveFlush) (logtor.LogCreator, error) {
+	if bucket == "" {
+		return nil, fmt.Errorf("creators: bucket must not be empty")
+	}
+	if adaptive == nil {
+		return nil, fmt.Errorf("creators: adaptive flush must not be nil")
+	}
+	awsConfig, err := config.LoadDefaultConfig(context.Background(), config.WithRegion(region))
+	if err != nil {
+		return nil, err
+	}
+	return startS3Creator(s3.NewFromConfig(awsConfig), bucket, keyPrefix, logName, callDepth, adaptive.maxInterval, adaptive), nil
+}
+
+func newS3Creator(client s3PutObjectAPI, bucket, keyPrefix string, logName types.LogCreatorName, callDepth int, flushInterval time.Duration) (*S3Creator, error) {
+	if flushInterval <= 0 {
+		return nil, fmt.Errorf("creators: flush interval must be positive, got %s", flushInterval)
+	}
+	return startS3Creator(client, bucket, keyPrefix, logName, callDepth, flushInterval, nil), nil
+}
+
+// startS3Creator constructs an S3Creator and starts its upload loop. Without an adaptive flush the
+// entries are uploaded every flushInterval.
+func startS3Creator(client s3PutObjectAPI, bucket, keyPrefix string, logName types.LogCreatorName, callDepth int, flushInterval time.Duration, adaptive *AdaptiveFlush) *S3Creator {
+	if logName == "" {
+		logName = S3
+	}
+
+	s3Creator := &S3Creator{
+		client:        client,
+		bucket:        bucket,
+		keyPrefix:     keyPrefix,
+		logName:       logName,
+		callDepth:     callDepth,
+		flushInterval: flushInterval,
+		adaptive:      adaptive,
+		now:           time.Now,
+		done:          make(chan struct{}),
+	}
+	s3Creator.log = log.New(&s3Creator.buffer, "", log.LstdFlags|log.Lshortfile)
+
+	s3Creator.wg.Add(1)
+	go func() {
+		defer s3Creator.wg.Done()
+		timer := time.NewTimer(s3Creator.nextFlushInterval())
+		defer timer.Stop()
+		for {
+			select {
+			case <-timer.C:
+				s3Creator.Flush()
+				timer.Reset(s3Creator.nextFlushInterval())
+			case <-s3Creator.done:
+				return
+			}
+		}
+	}()
+
+	return s3Creator
+}
+
+// nextFlushInterval returns how long the upload loop waits before the next flush.
+func (sr *S3Creator) nextFlushInterval() time.Duration {
+	if sr.adaptive != nil {
+		return sr.adaptive.Interval()
+	}
+	return sr.flushInterval
+}
+
+// S3 is a constant representing the LogCreatorName for the S3 log creator.
+const S3 types.LogCreatorName = "S3"
+
+// S3Creator is an implementation of the LogCreator interface that archives log messages to Amazon S3.
+type S3Creator struct {
+	client        s3PutObjectAPI
+	bucket        string
+	keyPrefix     string
+	logName       types.LogCreatorName
+	callDepth     int
+	flushInterval time.Duration
+	adaptive      *AdaptiveFlush
+	log           *log.Logger
+	buffer        bytes.Buffer
+	mutex         sync.Mutex
+	now           func() time.Time
+	done          chan struct{}
+	stopOnce      sync.Once
+	wg            sync.WaitGroup
+}
+
+// LogItWithCallDepth buffers a message with the specified log level and call depth until the next upload.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for recording the log entry, or zero or less for the configured call depth.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was buffered.
+func (sr *S3Creator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	callDepth = resolveCallDepth(callDepth, sr.callDepth)
+	sr.mutex.Lock()
+	defer sr.mutex.Unlock()
+	sr.log.SetPrefix(fmt.Sprintf("%-5s : ", level))
+	if sr.log.Output(callDepth, fmt.Sprintf("%+v", logMessage)) != nil {
+		return false
+	}
+	if sr.adaptive != nil {
+		sr.adaptive.Observe(1)
+	}
+	return true
+}
+
+// LogIt buffers a message with the specified log level using the default call depth.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was buffered.
+func (sr *S3Creator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return sr.LogItWithCallDepth(level, sr.callDepth, logMessage)
+}
+
+// Flush uploads the buffered entries to S3 as a gzip-compressed object.
+//
+// Nothing is uploaded when the buffer is empty. If the upload fails the entries are put back
+// into the buffer so the next flush retries them.
+//
+// Returns:
+//   - error: An error if compressing or uploading the entries failed.
+func (sr *S3Creator) Flush() error {
+	sr.mutex.Lock()
+	if sr.buffer.Len() == 0 {
+		sr.mutex.Unlock()
+		return nil
+	}
+	pending := append([]byte(nil), sr.buffer.Bytes()...)
+	sr.buffer.Reset()
+	sr.mutex.Unlock()
+
+	err := sr.upload(pending)
+	if err != nil {
+		sr.mutex.Lock()
+		remaining := append(pending, sr.buffer.Bytes()...)
+		sr.buffer.Reset()
+		sr.buffer.Write(remaining)
+		sr.mutex.Unlock()
+	}
+	return err
+}
+
+func (sr *S3Creator) upload(content []byte) error {
+	var compressed bytes.Buffer
+	gzipWriter := gzip.NewWriter(&compressed)
+	if _, err := gzipWriter.Write(content); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+
+	key := path.Join(sr.keyPrefix, sr.now().UTC().Format("20060102-150405")+".log.gz")
+	_, err := sr.client.PutObject(context.Background(), &s3.PutObjectInput{
+		Bucket:          aws.String(sr.bucket),
+		Key:             aws.String(key),
+		Body:            bytes.NewReader(compressed.Bytes()),
+		ContentType:     aws.String("text/plain"),
+		ContentEncoding: aws.String("gzip"),
+	})
+	return err
+}
+
+// CreatorStats returns the current flush interval, for Logtor.Stats.
+//
+// With an adaptive flush the recent arrival rate is included as well.
+//
+// Returns:
+//   - map[string]interface{}: The collected statistics.
+func (sr *S3Creator) CreatorStats() map[string]interface{} {
+	if sr.adaptive != nil {
+		return sr.adaptive.Stats()
+	}
+	return map[string]interface{}{
+		"flush_interval": sr.flushInterval.String(),
+	}
+}
+
+// LogName returns the name of the log creator.
+//
+// Returns:
+//   - LogCreatorName: The name of the log creator.
+func (sr *S3Creator) LogName() types.LogCreatorName {
+	return sr.logName
+}
+
+// SetCallDepth sets the call depth for recording log entries.
+//
+// Parameters:
+//   - callDepth: The depth to set for recording log entries.
+func (sr *S3Creator) SetCallDepth(callDepth int) {
+	sr.callDepth = callDepth
+}
+
+// CallDepth returns the current call depth setting for recording log entries.
+//
+// Returns:
+//   - int: The current call depth setting for recording log entries.
+func (sr *S3Creator) CallDepth() int {
+	return sr.callDepth
+}
+
+// IsReady returns true, since entries are buffered locally until they are uploaded.
+func (sr *S3Creator) IsReady() bool {
+	return true
+}
+
+// Shutdown stops the periodic uploads and flushes any remaining buffered entries.
+func (sr *S3Creator) Shutdown() {
+	sr.stopOnce.Do(func() {
+		close(sr.done)
+		sr.wg.Wait()
+		sr.Flush()
+	})
+}
+
+// CreatorType returns the kind of the log creator.
+//
+// Returns:
+//   - string: "s3".
+func (sr *S3Creator) CreatorType() string {
+	return s3Target
+}
+
+// CreatorVersion returns the version of the log creator implementation.
+//
+// Returns:
+//   - string: The implementation version.
+func (sr *S3Creator) CreatorVersion() string {
+	return creatorVersion
+}
+
+// CreatorDescription returns a short description of the log creator.
+//
+// Returns:
+//   - string: A description naming the bucket.
+func (sr *S3Creator) CreatorDescription() string {
+	return "Gzip-compressed log archives uploaded to s3://" + path.Join(sr.bucket, sr.keyPrefix)
+}
@@ -0,0 +1,91 @@
+package creators
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+type fakeS3Client struct {
+	mutex   sync.Mutex
+	fail    bool
+	objects map[string]string
+}
+
+func (fc *fakeS3Client) PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	fc.mutex.Lock()
+	defer fc.mutex.Unlock()
+	if fc.fail {
+		return nil, errors.New("upload failed")
+	}
+	gzipReader, err := gzip.NewReader(params.Body)
+	if err != nil {
+		return nil, err
+	}
+	content, err := io.ReadAll(gzipReader)
+	if err != nil {
+		return nil, err
+	}
+	fc.objects[*params.Bucket+"/"+*params.Key] = string(content)
+	return &s3.PutObjectOutput{}, nil
+}
+
+func TestS3CreatorUploadsCompressedBuffer(t *testing.T) {
+	client := &fakeS3Client{objects: map[string]string{}}
+	s3Creator, err := newS3Creator(client, "archive", "service/logs", "", 2, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s3Creator.now = func() time.Time { return time.Date(2024, 1, 15, 13, 4, 5, 0, time.UTC) }
+
+	if s3Creator.LogName() != S3 {
+		t.Errorf("unexpected default name: %s", s3Creator.LogName())
+	}
+	if result := s3Creator.LogIt(types.ERROR, "Example S3 Log Message"); !result {
+		t.Error("Log not recorded")
+	}
+
+	client.fail = true
+	if err := s3Creator.Flush(); err == nil {
+		t.Error("expected upload error")
+	}
+	client.fail = false
+
+	s3Creator.LogIt(types.INFO, "Second S3 Log Message")
+	s3Creator.Shutdown()
+
+	content, ok := client.objects["archive/service/logs/20240115-130405.log.gz"]
+	if !ok {
+		t.Fatalf("object not uploaded: %v", client.objects)
+	}
+	if !strings.Contains(content, "Example S3 Log Message") || !strings.Contains(content, "Second S3 Log Message") {
+		t.Errorf("unexpected object content: %q", content)
+	}
+	if strings.Index(content, "Example") > strings.Index(content, "Second") {
+		t.Error("entries of a failed upload must keep their order")
+	}
+}
+
+func TestS3CreatorSkipsEmptyBuffer(t *testing.T) {
+	client := &fakeS3Client{objects: map[string]string{}}
+	s3Creator, err := newS3Creator(client, "archive", "", "Archive", 2, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s3Creator.Shutdown()
+	s3Creator.Shutdown()
+	if len(client.objects) != 0 {
+		t.Errorf("nothing should be uploaded for an empty buffer: %v", client.objects)
+	}
+	if _, err := newS3Creator(client, "archive", "", "", 2, 0); err == nil {
+		t.Error("non-positive flush interval should be rejected")
+	}
+}
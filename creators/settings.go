@@ -0,0 +1,153 @@
+package creators
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DecodeSettings copies creator settings into the struct pointed to by target.
+//
+// Struct fields are matched by their `setting:"key"` tag; fields without a tag are ignored and
+// embedded structs contribute their tagged fields.
+// Values are converted the way JSON decoded settings need: float64 numbers to integers,
+// []interface{} to []string, and strings such as "10s" to time.Duration. Pointer fields are
+// allocated when the key is present, which makes it possible to tell missing settings apart
+// from zero values. Keys without a matching field are rejected with an error listing the valid keys.
+//
+// Parameters:
+//   - settings: The settings to decode, e.g. CreatorConfig.Settings.
+//   - target: A pointer to the struct receiving the settings.
+//
+// Returns:
+//   - error: A descriptive error for unknown keys or values of the wrong type.
+func DecodeSettings(settings map[string]interface{}, target interface{}) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("creators: settings target must be a pointer to a struct, got %T", target)
+	}
+	structValue := targetValue.Elem()
+	fields := make(map[string][]int)
+	collectSettingFields(structValue.Type(), nil, fields)
+
+	keys := make([]string, 0, len(settings))
+	for key := range settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		index, ok := fields[key]
+		if !ok {
+			valid := make([]string, 0, len(fields))
+			for field := range fields {
+				valid = append(valid, field)
+			}
+			sort.Strings(valid)
+			return fmt.Errorf("creators: unknown setting %q (valid settings: %s)", key, strings.Join(valid, ", "))
+		}
+		if err := assignSetting(structValue.FieldByIndex(index), settings[key]); err != nil {
+			return fmt.Errorf("creators: setting %q: %w", key, err)
+		}
+	}
+	return nil
+}
+
+func collectSettingFields(structType reflect.Type, parent []int, fields map[string][]int) {
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		index := append(append([]int(nil), parent...), i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			collectSettingFields(field.Type, index, fields)
+			continue
+		}
+		if key := field.Tag.Get("setting"); key != "" {
+			fields[key] = index
+		}
+	}
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+func assignSetting(field reflect.Value, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+	if field.Kind() == reflect.Pointer {
+		element := reflect.New(field.Type().Elem())
+		if err := assignSetting(element.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(element)
+		return nil
+	}
+
+	if field.Type() == durationType {
+		switch v := value.(type) {
+		case string:
+			duration, err := time.ParseDuration(v)
+			if err != nil {
+				return err
+			}
+			field.SetInt(int64(duration))
+			return nil
+		case time.Duration:
+			field.SetInt(int64(v))
+			return nil
+		}
+		return fmt.Errorf("expected a duration such as \"10s\", got %T", value)
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string, got %T", value)
+		}
+		field.SetString(v)
+	case reflect.Bool:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("expected a boolean, got %T", value)
+		}
+		field.SetBool(v)
+	case reflect.Int, reflect.Int64:
+		switch v := value.(type) {
+		case int:
+			field.SetInt(int64(v))
+		case int64:
+			field.SetInt(v)
+		case float64:
+			if v != float64(int64(v)) {
+				return fmt.Errorf("expected an integer, got %v", v)
+			}
+			field.SetInt(int64(v))
+		default:
+			return fmt.Errorf("expected an integer, got %T", value)
+		}
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported field type %s", field.Type())
+		}
+		switch v := value.(type) {
+		case []string:
+			field.Set(reflect.ValueOf(append([]string(nil), v...)))
+		case []interface{}:
+			result := make([]string, 0, len(v))
+			for _, item := range v {
+				s, ok := item.(string)
+				if !ok {
+					return fmt.Errorf("expected a list of strings, got an element of type %T", item)
+				}
+				result = append(result, s)
+			}
+			field.Set(reflect.ValueOf(result))
+		default:
+			return fmt.Errorf("expected a list of strings, got %T", value)
+		}
+	default:
+		return fmt.Errorf("unsupported field type %s", field.Type())
+	}
+	return nil
+}
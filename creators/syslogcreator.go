@@ -0,0 +1,282 @@
+//go:build !windows && !plan9
+
+package creators
+
+import (
+	"fmt"
+	"log/syslog"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// Syslog is a constant representing the LogCreatorName for the Syslog log creator.
+const Syslog types.LogCreatorName = "Syslog"
+
+// syslogFacilities are the facility names accepted by NewSyslogCreator.
+var syslogFacilities = map[string]syslog.Priority{
+	"kern":     syslog.LOG_KERN,
+	"user":     syslog.LOG_USER,
+	"mail":     syslog.LOG_MAIL,
+	"daemon":   syslog.LOG_DAEMON,
+	"auth":     syslog.LOG_AUTH,
+	"syslog":   syslog.LOG_SYSLOG,
+	"lpr":      syslog.LOG_LPR,
+	"news":     syslog.LOG_NEWS,
+	"uucp":     syslog.LOG_UUCP,
+	"cron":     syslog.LOG_CRON,
+	"authpriv": syslog.LOG_AUTHPRIV,
+	"ftp":      syslog.LOG_FTP,
+	"local0":   syslog.LOG_LOCAL0,
+	"local1":   syslog.LOG_LOCAL1,
+	"local2":   syslog.LOG_LOCAL2,
+	"local3":   syslog.LOG_LOCAL3,
+	"local4":   syslog.LOG_LOCAL4,
+	"local5":   syslog.LOG_LOCAL5,
+	"local6":   syslog.LOG_LOCAL6,
+	"local7":   syslog.LOG_LOCAL7,
+}
+
+// NewSyslogCreator creates a new instance of SyslogCreator, which forwards log messages to a syslog daemon.
+//
+// Parameters:
+//   - network: The network of the daemon ("udp", "tcp", "unix"), or "" for the local syslog socket.
+//   - addr: The address of the daemon, or "" for the local syslog socket.
+//   - priority: The syslog facility of the messages, such as "user", "daemon" or "local0"; "" selects "user".
+//   - tag: The tag of the messages, or "" for the program name.
+//   - logName: The name representing the log creator (e.g., Syslog).
+//   - callDepth: The call depth to be used in log output.
+//
+// Returns:
+//   - *SyslogCreator: A pointer to the newly created SyslogCreator.
+//   - error: An error if the facility is unknown or the daemon cannot be reached.
+//
+// If logName is an empty string, it defaults to Syslog. The log level of a message selects its severity:
+// FATAL is sent as LOG_CRIT, ERROR as LOG_ERR, WARN as LOG_WARNING, INFO as LOG_INFO, and DEBUG and
+// TRACE as LOG_DEBUG.
+func NewSyslogCreator(network, addr, priority string, tag string, logName types.LogCreatorName, callDepth int) (*SyslogCreator, error) {
+	return NewSyslogCreatorWithOptions(network, addr, priority, tag, WithName(logName), WithCallDepth(max(callDepth, 0)))
+}
+
+// NewSyslogCreatorWithOptions creates a new instance of SyslogCreator configured with functional options.
+//
+// Parameters:
+//   - network: The network of the daemon ("udp", "tcp", "unix"), or "" for the local syslog socket.
+//   - addr: The address of the daemon, or "" for the local syslog socket.
+//   - priority: The syslog facility of the messages, such as "user", "daemon" or "local0"; "" selects "user".
+//   - tag: The tag of the messages, or "" for the program name.
+//   - opts: Options such as WithName, WithCallDepth and WithClock.
+//
+// Returns:
+//   - *SyslogCreator: A pointer to the newly created SyslogCreator.
+//   - error: An error if an option is invalid or not supported, the facility is unknown or the daemon
+//     cannot be reached.
+//
+// The name defaults to Syslog. The clock stamps the entries passed to the creator's LogEntry method
+// by LogIt and LogItWithCallDepth.
+func NewSyslogCreatorWithOptions(network, addr, priority string, tag string, opts ...Option) (*SyslogCreator, error) {
+	options, err := newCreatorOptions(syslogTarget, Syslog, opts)
+	if err != nil {
+		return nil, err
+	}
+	if priority == "" {
+		priority = "user"
+	}
+	facility, ok := syslogFacilities[priority]
+	if !ok {
+		return nil, fmt.Errorf("creators: unknown syslog facility %q", priority)
+	}
+	syslogCreator := &SyslogCreator{
+		network:   network,
+		addr:      addr,
+		tag:       tag,
+		facility:  facility,
+		logName:   options.name,
+		callDepth: options.callDepth,
+		clock:     options.clock,
+	}
+	writer, err := syslogCreator.dial()
+	if err != nil {
+		return nil, fmt.Errorf("creators: dial syslog: %w", err)
+	}
+	syslogCreator.writer = writer
+	syslogCreator.alive.Store(true)
+	return syslogCreator, nil
+}
+
+// SyslogCreator is an implementation of the LogCreator interface that forwards log messages to a syslog
+// daemon.
+//
+// If writing a message fails, the SyslogCreator dials the daemon again once and retries the message
+// before reporting the failure. IsReady reports false after a failed retry, until a later message is
+// written successfully.
+type SyslogCreator struct {
+	network   string
+	addr      string
+	tag       string
+	facility  syslog.Priority
+	logName   types.LogCreatorName
+	callDepth int
+	clock     logtor.Clock
+	// writerMutex guards writer, which is replaced when the connection is dialed again.
+	writerMutex sync.Mutex
+	writer      *syslog.Writer
+	alive       atomic.Bool
+	closed      atomic.Bool
+}
+
+func (sc *SyslogCreator) dial() (*syslog.Writer, error) {
+	return syslog.Dial(sc.network, sc.addr, sc.facility|syslog.LOG_INFO, sc.tag)
+}
+
+// severity maps a log level to the syslog severity it is sent with.
+func severity(level types.LogLevel) syslog.Priority {
+	switch level {
+	case types.PANIC:
+		return syslog.LOG_EMERG
+	case types.FATAL:
+		return syslog.LOG_CRIT
+	case types.ERROR:
+		return syslog.LOG_ERR
+	case types.WARN:
+		return syslog.LOG_WARNING
+	case types.INFO:
+		return syslog.LOG_INFO
+	}
+	return syslog.LOG_DEBUG
+}
+
+// write sends a message with the severity of the level, dialing the daemon again once if writing fails.
+func (sc *SyslogCreator) write(level types.LogLevel, message string) bool {
+	if sc.closed.Load() {
+		return false
+	}
+	sc.writerMutex.Lock()
+	defer sc.writerMutex.Unlock()
+	if err := writeSyslog(sc.writer, severity(level), message); err == nil {
+		sc.alive.Store(true)
+		return true
+	}
+	writer, err := sc.dial()
+	if err != nil {
+		sc.alive.Store(false)
+		return false
+	}
+	sc.writer.Close()
+	sc.writer = writer
+	err = writeSyslog(sc.writer, severity(level), message)
+	sc.alive.Store(err == nil)
+	return err == nil
+}
+
+func writeSyslog(writer *syslog.Writer, severity syslog.Priority, message string) error {
+	switch severity {
+	case syslog.LOG_CRIT:
+		return writer.Crit(message)
+	case syslog.LOG_ERR:
+		return writer.Err(message)
+	case syslog.LOG_WARNING:
+		return writer.Warning(message)
+	case syslog.LOG_INFO:
+		return writer.Info(message)
+	}
+	return writer.Debug(message)
+}
+
+// LogItWithCallDepth sends a message with the specified log level and call depth to the syslog daemon.
+//
+// The message is sent as the caller's file and line followed by the message and its fields as sorted
+// key=value pairs; the daemon adds the time and tag.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for recording the log entry, or zero or less for the configured call depth.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was written; false if writing failed, even after dialing again.
+func (sc *SyslogCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	_, file, line, ok := runtime.Caller(resolveCallDepth(callDepth, sc.callDepth) - 1)
+	if !ok {
+		file = "???"
+		line = 0
+	}
+	return sc.LogEntry(logEntryOf(level, sc.clock.Now(), file, line, logMessage))
+}
+
+// LogIt sends a message with the specified log level to the syslog daemon using the configured call depth.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was written; false if writing failed, even after dialing again.
+func (sc *SyslogCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return sc.LogItWithCallDepth(level, sc.callDepth, logMessage)
+}
+
+// LogRendered sends an entry rendered by Logtor to the syslog daemon, with the caller resolved by Logtor.
+//
+// Parameters:
+//   - entry: The rendered entry to be logged.
+//
+// Returns:
+//   - bool: True if the entry was written; false if writing failed, even after dialing again.
+func (sc *SyslogCreator) LogRendered(entry types.RenderedEntry) bool {
+	return sc.write(entry.Level, fmt.Sprintf("%s:%d: %s", entry.File, entry.Line, entry.Text()))
+}
+
+// LogEntry sends a structured entry with its own level, caller and fields, implementing logtor.LogCreatorV2.
+//
+// Parameters:
+//   - entry: The entry to be logged.
+//
+// Returns:
+//   - bool: The result of LogRendered for the entry.
+func (sc *SyslogCreator) LogEntry(entry types.LogEntry) bool {
+	return sc.LogRendered(entry.Rendered())
+}
+
+// LogName returns the name of the SyslogCreator.
+//
+// Returns:
+//   - LogCreatorName: The name of the log creator.
+func (sc *SyslogCreator) LogName() types.LogCreatorName {
+	return sc.logName
+}
+
+// SetCallDepth sets the call depth for recording log entries.
+//
+// Parameters:
+//   - callDepth: The depth to set for recording log entries.
+func (sc *SyslogCreator) SetCallDepth(callDepth int) {
+	sc.callDepth = callDepth
+}
+
+// CallDepth returns the call depth of the SyslogCreator.
+//
+// Returns:
+//   - int: The call depth.
+func (sc *SyslogCreator) CallDepth() int {
+	return sc.callDepth
+}
+
+// IsReady reports whether the connection to the syslog daemon is alive: the SyslogCreator has not been
+// shut down and the last message was written.
+func (sc *SyslogCreator) IsReady() bool {
+	return sc.alive.Load() && !sc.closed.Load()
+}
+
+// Shutdown closes the connection to the syslog daemon. Calling it again has no effect.
+func (sc *SyslogCreator) Shutdown() {
+	if sc.closed.Swap(true) {
+		return
+	}
+	sc.writerMutex.Lock()
+	defer sc.writerMutex.Unlock()
+	sc.writer.Close()
+}
@@ -0,0 +1,98 @@
+//go:build !windows && !plan9
+
+package creators_test
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func readSyslogPacket(t *testing.T, conn net.PacketConn) string {
+	t.Helper()
+	buffer := make([]byte, 2048)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buffer)
+	if err != nil {
+		t.Fatalf("no syslog message received: %v", err)
+	}
+	return string(buffer[:n])
+}
+
+func TestSyslogCreator(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on udp: %v", err)
+	}
+	defer conn.Close()
+
+	syslogCreator, err := creators.NewSyslogCreator("udp", conn.LocalAddr().String(), "local0", "app", "", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if syslogCreator.LogName() != creators.Syslog || !syslogCreator.IsReady() {
+		t.Errorf("unexpected creator state: %s ready=%t", syslogCreator.LogName(), syslogCreator.IsReady())
+	}
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(syslogCreator)
+	newLogtor.SetLogLevel(types.TRACE)
+
+	newLogtor.LogIt(types.FATAL, types.LogEntry{Message: "disk full", Fields: map[string]interface{}{"disk": "sda"}})
+	packet := readSyslogPacket(t, conn)
+	// local0 (16) * 8 + LOG_CRIT (2).
+	if !strings.HasPrefix(packet, "<130>") || !strings.Contains(packet, "app[") {
+		t.Errorf("unexpected priority or tag: %q", packet)
+	}
+	if !strings.Contains(packet, "syslogcreator_test.go:") || !strings.Contains(packet, "disk full disk=sda") {
+		t.Errorf("unexpected message: %q", packet)
+	}
+
+	newLogtor.LogIt(types.TRACE, "details")
+	// local0 (16) * 8 + LOG_DEBUG (7).
+	if packet := readSyslogPacket(t, conn); !strings.HasPrefix(packet, "<135>") {
+		t.Errorf("unexpected priority: %q", packet)
+	}
+
+	syslogCreator.Shutdown()
+	syslogCreator.Shutdown()
+	if syslogCreator.IsReady() || syslogCreator.LogIt(types.INFO, "closed") {
+		t.Error("a shut down creator should not be ready or log")
+	}
+}
+
+func TestNewSyslogCreatorUnknownFacility(t *testing.T) {
+	if _, err := creators.NewSyslogCreator("udp", "127.0.0.1:514", "local9", "app", "", 3); err == nil {
+		t.Error("an unknown facility should be rejected")
+	}
+}
+
+func TestSyslogCreatorWithOptions(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen on udp: %v", err)
+	}
+	defer conn.Close()
+
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	syslogCreator, err := creators.NewSyslogCreatorWithOptions("udp", conn.LocalAddr().String(), "local0", "app", creators.WithName("Audit"), creators.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer syslogCreator.Shutdown()
+	if syslogCreator.LogName() != "Audit" || syslogCreator.CallDepth() != 3 {
+		t.Errorf("options not applied: name=%s callDepth=%d", syslogCreator.LogName(), syslogCreator.CallDepth())
+	}
+	syslogCreator.LogIt(types.WARN, "clocked")
+	if packet := readSyslogPacket(t, conn); !strings.Contains(packet, "syslogcreator_test.go:") || !strings.Contains(packet, "clocked") {
+		t.Errorf("unexpected message: %q", packet)
+	}
+
+	if _, err := creators.NewSyslogCreatorWithOptions("udp", conn.LocalAddr().String(), "local0", "app", creators.WithPrefixWidth(3)); err == nil {
+		t.Error("options the SyslogCreator does not support should be rejected")
+	}
+}
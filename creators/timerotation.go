@@ -0,0 +1,118 @@
+package creators
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// rotationTimeLayout is the layout of the period start in the names of log files rotated by time.
+const rotationTimeLayout = "2006-01-02T15:04:05"
+
+// NewFileCreatorWithTimeRotation creates a new instance of FileCreator that rotates its log file at every
+// interval boundary, regardless of its size.
+//
+// It is a thin wrapper around NewFileCreator with the WithTimeRotation option. The returned log creator
+// is a *FileCreator, whose Rotated channel reports every rotation. Call Shutdown to stop the rotation.
+//
+// Parameters:
+//   - filename: The name of the log file.
+//   - interval: The length of the period a log file covers, e.g. time.Hour or 24 * time.Hour.
+//   - maxBackups: The number of rotated files to keep.
+//   - logName: The name representing the log creator (e.g., File).
+//   - callDepth: The call depth to be used in log output.
+//   - logPrefix: An integer representing log prefix settings.
+//   - opts: Optional FileCreatorOption values such as WithClock.
+//
+// Returns:
+//   - *FileCreator: A pointer to the newly created FileCreator.
+//   - error: An error if the rotation settings are invalid or the file cannot be opened.
+func NewFileCreatorWithTimeRotation(filename string, interval time.Duration, maxBackups int, logName types.LogCreatorName, callDepth int, logPrefix int, opts ...FileCreatorOption) (logtor.LogCreator, error) {
+	return NewFileCreator(filename, logName, callDepth, logPrefix, append([]FileCreatorOption{WithTimeRotation(interval, maxBackups)}, opts...)...)
+}
+
+// startTimeRotation starts the goroutine rotating the log file at every interval boundary. The first
+// boundary is taken before the goroutine starts, so the clock moving meanwhile cannot skip it.
+func (fr *FileCreator) startTimeRotation() {
+	fr.done = make(chan struct{})
+	fr.stopped = make(chan struct{})
+	boundary := fr.clock.Now().Truncate(fr.interval).Add(fr.interval)
+	go func() {
+		defer close(fr.stopped)
+		for {
+			var now time.Time
+			select {
+			case <-fr.done:
+				return
+			case now = <-clockAt(fr.clock, boundary):
+			}
+			fr.fileMutex.Lock()
+			// A failed rotation is retried at the next boundary; entries go to the current file meanwhile.
+			fr.rotateByTime(boundary.Add(-fr.interval))
+			fr.fileMutex.Unlock()
+			boundary = now.Truncate(fr.interval).Add(fr.interval)
+		}
+	}()
+}
+
+// clockAt returns a channel that receives the time once the clock reaches t, waiting on the clock
+// itself if it implements logtor.TimerClock.
+func clockAt(clock logtor.Clock, t time.Time) <-chan time.Time {
+	if timerClock, ok := clock.(logtor.TimerClock); ok {
+		return timerClock.At(t)
+	}
+	return time.After(t.Sub(clock.Now()))
+}
+
+// rotateByTime moves the log file to the name of the period starting at start, removes the oldest rotated
+// files past maxBackups and reopens the log file. It must be called with fileMutex held.
+func (fr *FileCreator) rotateByTime(start time.Time) error {
+	if err := os.Rename(fr.fileName, fr.periodName(start)); err != nil {
+		return err
+	}
+	if err := fr.pruneRotated(); err != nil {
+		return err
+	}
+	return fr.reopen()
+}
+
+// periodName returns the name of the log file covering the period starting at start.
+func (fr *FileCreator) periodName(start time.Time) string {
+	extension := filepath.Ext(fr.fileName)
+	return strings.TrimSuffix(fr.fileName, extension) + "-" + start.Format(rotationTimeLayout) + extension
+}
+
+// pruneRotated removes the oldest log files rotated by time until at most maxBackups are left.
+func (fr *FileCreator) pruneRotated() error {
+	extension := filepath.Ext(fr.fileName)
+	prefix := strings.TrimSuffix(fr.fileName, extension) + "-"
+	candidates, err := filepath.Glob(prefix + "*" + extension)
+	if err != nil {
+		return err
+	}
+	var rotated []string
+	for _, name := range candidates {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), extension)
+		if _, err := time.Parse(rotationTimeLayout, stamp); err == nil {
+			rotated = append(rotated, name)
+		}
+	}
+	if len(rotated) <= fr.maxBackups {
+		return nil
+	}
+	// The period starts sort chronologically by name.
+	sort.Strings(rotated)
+	var errs []error
+	for _, name := range rotated[:len(rotated)-fr.maxBackups] {
+		if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
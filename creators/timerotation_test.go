@@ -0,0 +1,87 @@
+package creators_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// waitForRotation waits for the FileCreator to report a rotation.
+func waitForRotation(t *testing.T, rotated <-chan string) {
+	t.Helper()
+	select {
+	case <-rotated:
+	case <-time.After(5 * time.Second):
+		t.Fatal("rotation not reported")
+	}
+}
+
+func TestFileCreatorWithTimeRotation(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "app.log")
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 15, 12, 30, 0, 0, time.UTC))
+	fileCreator, err := creators.NewFileCreatorWithTimeRotation(fileName, time.Hour, 1, "File", 3, 5, creators.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fileCreator.Shutdown()
+	rotated := fileCreator.(*creators.FileCreator).Rotated()
+
+	fileCreator.LogIt(types.INFO, "first period")
+	clock.Advance(30 * time.Minute)
+	waitForRotation(t, rotated)
+	fileCreator.LogIt(types.INFO, "second period")
+	clock.Advance(time.Hour)
+	waitForRotation(t, rotated)
+	fileCreator.LogIt(types.INFO, "third period")
+
+	if _, err := os.Stat(filepath.Join(dir, "app-2024-01-15T12:00:00.log")); !os.IsNotExist(err) {
+		t.Errorf("rotated files past maxBackups should be removed: %v", err)
+	}
+	for name, expected := range map[string]string{"app-2024-01-15T13:00:00.log": "second period", "app.log": "third period"} {
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Count(string(content), "\n") != 1 || !strings.HasSuffix(string(content), expected+"\n") {
+			t.Errorf("%s should only hold %q: %q", name, expected, content)
+		}
+	}
+}
+
+func TestFileCreatorWithTimeRotationShutdown(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "app.log")
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 15, 12, 30, 0, 0, time.UTC))
+	fileCreator, err := creators.NewFileCreatorWithTimeRotation(fileName, time.Hour, 1, "File", 3, 5, creators.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileCreator.Shutdown()
+	fileCreator.Shutdown()
+
+	clock.Advance(time.Hour)
+	select {
+	case <-fileCreator.(*creators.FileCreator).Rotated():
+		t.Error("the file should not be rotated after Shutdown")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWithTimeRotationValidation(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "app.log")
+	if _, err := creators.NewFileCreatorWithTimeRotation(fileName, time.Millisecond, 1, "File", 3, 5); err == nil {
+		t.Error("an interval below a second should be rejected")
+	}
+	if _, err := creators.NewFileCreatorWithTimeRotation(fileName, time.Hour, -1, "File", 3, 5); err == nil {
+		t.Error("a negative number of backups should be rejected")
+	}
+	if _, err := creators.NewFileCreatorWithTimeRotation(fileName, time.Hour, 1, "File", 3, 5, creators.WithRotation(1024, 1)); err == nil {
+		t.Error("size and time rotation should not be combined")
+	}
+}
@@ -0,0 +1,130 @@
+package creators
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Eyup-Devop/logtor"
+)
+
+// maxTopicLength is the longest topic name accepted by Kafka.
+const maxTopicLength = 249
+
+func settingsIssue(path string, format string, args ...interface{}) logtor.ConfigIssue {
+	return logtor.ConfigIssue{Severity: logtor.SeverityError, Path: path, Message: fmt.Sprintf(format, args...)}
+}
+
+func validateConsoleSettings(settings map[string]interface{}) []logtor.ConfigIssue {
+	var decoded consoleSettings
+	if err := DecodeSettings(settings, &decoded); err != nil {
+		return []logtor.ConfigIssue{settingsIssue("", "%v", err)}
+	}
+	return optionIssues(consoleTarget, decoded.options())
+}
+
+// optionIssues reports options rejected by the creator, such as a negative call depth.
+func optionIssues(target string, opts []Option) []logtor.ConfigIssue {
+	if _, err := newCreatorOptions(target, "", opts); err != nil {
+		return []logtor.ConfigIssue{settingsIssue("", "%v", err)}
+	}
+	return nil
+}
+
+// validateFileSettings probes the writability of the log file directory with a temporary file,
+// so neither the log file itself nor a missing directory is created.
+func validateFileSettings(settings map[string]interface{}) []logtor.ConfigIssue {
+	var decoded fileSettings
+	if err := DecodeSettings(settings, &decoded); err != nil {
+		return []logtor.ConfigIssue{settingsIssue("", "%v", err)}
+	}
+	issues := optionIssues(fileTarget, decoded.options())
+	if decoded.FileName == "" {
+		return append(issues, settingsIssue("file_name", "file name must not be empty"))
+	}
+	probe, err := os.CreateTemp(filepath.Dir(decoded.FileName), ".logtor-probe-*")
+	if err != nil {
+		return append(issues, settingsIssue("file_name", "directory is not writable: %v", err))
+	}
+	probe.Close()
+	os.Remove(probe.Name())
+	return issues
+}
+
+func validateBrokerSettings(settings map[string]interface{}) []logtor.ConfigIssue {
+	var decoded brokerSettings
+	if err := DecodeSettings(settings, &decoded); err != nil {
+		return []logtor.ConfigIssue{settingsIssue("", "%v", err)}
+	}
+
+	issues := optionIssues(brokerTarget, decoded.options())
+	if len(decoded.Brokers) == 0 {
+		issues = append(issues, settingsIssue("brokers", "at least one broker address is required"))
+	}
+	for i, address := range decoded.Brokers {
+		if err := validateBrokerAddress(address); err != nil {
+			issues = append(issues, settingsIssue(fmt.Sprintf("brokers[%d]", i), "invalid broker address %q: %v", address, err))
+		}
+	}
+	issues = append(issues, validateTopic(decoded.Topic)...)
+	return issues
+}
+
+func validateBrokerAddress(address string) error {
+	host, port, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if host == "" {
+		return fmt.Errorf("missing host")
+	}
+	portNumber, err := strconv.Atoi(port)
+	if err != nil || portNumber < 1 || portNumber > 65535 {
+		return fmt.Errorf("invalid port %q", port)
+	}
+	return nil
+}
+
+// validateTopic applies the Kafka topic naming rules.
+func validateTopic(topic string) []logtor.ConfigIssue {
+	switch {
+	case topic == "":
+		return []logtor.ConfigIssue{settingsIssue("topic", "topic must not be empty")}
+	case topic == "." || topic == "..":
+		return []logtor.ConfigIssue{settingsIssue("topic", "topic must not be %q", topic)}
+	case len(topic) > maxTopicLength:
+		return []logtor.ConfigIssue{settingsIssue("topic", "topic must not be longer than %d characters", maxTopicLength)}
+	}
+	for _, r := range topic {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-') {
+			return []logtor.ConfigIssue{settingsIssue("topic", "topic %q contains the invalid character %q", topic, r)}
+		}
+	}
+	if strings.ContainsRune(topic, '.') && strings.ContainsRune(topic, '_') {
+		return []logtor.ConfigIssue{{
+			Severity: logtor.SeverityWarning,
+			Path:     "topic",
+			Message:  fmt.Sprintf("topic %q mixes '.' and '_', which Kafka treats as colliding in metric names", topic),
+		}}
+	}
+	return nil
+}
+
+func validateS3Settings(settings map[string]interface{}) []logtor.ConfigIssue {
+	var decoded s3Settings
+	if err := DecodeSettings(settings, &decoded); err != nil {
+		return []logtor.ConfigIssue{settingsIssue("", "%v", err)}
+	}
+
+	var issues []logtor.ConfigIssue
+	if decoded.Bucket == "" {
+		issues = append(issues, settingsIssue("bucket", "bucket must not be empty"))
+	}
+	if decoded.FlushInterval <= 0 {
+		issues = append(issues, settingsIssue("flush_interval", "flush interval must be positive, got %s", decoded.FlushInterval))
+	}
+	return issues
+}
@@ -0,0 +1,146 @@
+package logtor
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// maxDedupEntries is the number of distinct messages a deduplicator tracks at once. Messages arriving
+// while it is full are logged without being tracked.
+const maxDedupEntries = 4096
+
+// dedupEntry tracks the repeats of a message within the dedup window.
+type dedupEntry struct {
+	level   types.LogLevel
+	start   time.Time
+	repeats int64
+}
+
+// dedupSummary is a summary of suppressed repeats, logged at the level of the repeated message.
+type dedupSummary struct {
+	level   types.LogLevel
+	repeats int64
+}
+
+// deduplicator suppresses the repeats of a message within a window of the Logtor's clock.
+type deduplicator struct {
+	mutex     sync.Mutex
+	window    time.Duration
+	entries   map[uint64]*dedupEntry
+	last      uint64
+	lastSweep time.Time
+}
+
+// allow reports whether the message with the key is kept at now, and returns the summary of the repeats
+// of the last message if one is due: when a different message arrives, or when its window expired and it
+// arrives again. Only the last message can have repeats, so the entries of the other messages are evicted
+// once their window expired. For the first repeat of a message, allow also returns the start of its
+// window, whose expiry is then due to be reported with expire.
+func (d *deduplicator) allow(key uint64, level types.LogLevel, now time.Time) (bool, *dedupSummary, time.Time) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	var summary *dedupSummary
+	if last, ok := d.entries[d.last]; ok && last.repeats > 0 && (d.last != key || now.Sub(last.start) >= d.window) {
+		summary = &dedupSummary{level: last.level, repeats: last.repeats}
+		delete(d.entries, d.last)
+	}
+	if now.Sub(d.lastSweep) >= d.window || len(d.entries) >= maxDedupEntries {
+		d.sweep(now)
+	}
+	d.last = key
+	if entry, ok := d.entries[key]; ok && now.Sub(entry.start) < d.window {
+		entry.repeats++
+		if entry.repeats == 1 {
+			return false, summary, entry.start
+		}
+		return false, summary, time.Time{}
+	}
+	if len(d.entries) < maxDedupEntries {
+		d.entries[key] = &dedupEntry{level: level, start: now}
+	}
+	return true, summary, time.Time{}
+}
+
+// expire returns the summary of the repeats of the message with the key and evicts it, if its repeats
+// within the window that started at start were not reported yet.
+func (d *deduplicator) expire(key uint64, start time.Time) *dedupSummary {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+	entry, ok := d.entries[key]
+	if !ok || !entry.start.Equal(start) || entry.repeats == 0 {
+		return nil
+	}
+	delete(d.entries, key)
+	return &dedupSummary{level: entry.level, repeats: entry.repeats}
+}
+
+// flush returns the summary of the repeats of the last message and evicts it, if it has repeats.
+func (d *deduplicator) flush() *dedupSummary {
+	d.mutex.Lock()
+	last := d.entries[d.last]
+	d.mutex.Unlock()
+	if last == nil {
+		return nil
+	}
+	return d.expire(d.last, last.start)
+}
+
+// sweep evicts the entries whose window expired.
+func (d *deduplicator) sweep(now time.Time) {
+	d.lastSweep = now
+	for key, entry := range d.entries {
+		if now.Sub(entry.start) >= d.window {
+			delete(d.entries, key)
+		}
+	}
+}
+
+// SetDedup suppresses the repeats of identical messages within a window, so a message logged in a tight
+// loop, such as "connection refused" every 10ms, does not drown out everything else.
+//
+// Messages are identical when their level and rendered text are. The first message is logged and its
+// repeats within window of the Logtor's clock are dropped. A summary such as "last message repeated 42
+// times" is logged at the level of the repeated message once the window expired, even if no further
+// message arrives, or before a different message arriving earlier. Pending summaries are logged when the
+// Logtor is shut down. Up to 4096 distinct messages are tracked, and those whose window expired are
+// evicted. PANIC and FATAL messages are never suppressed. Setting a new window discards the repeats
+// counted so far.
+//
+// Parameters:
+//   - window: How long the repeats of a message are suppressed, or zero or less to stop suppressing them.
+func (l *Logtor) SetDedup(window time.Duration) {
+	if window <= 0 {
+		l.deduplicator.Store(nil)
+		return
+	}
+	l.deduplicator.Store(&deduplicator{window: window, entries: make(map[uint64]*dedupEntry)})
+}
+
+// dedup reports whether a message at the level is not a suppressed repeat, logs the summary of the
+// repeats that is due, and schedules the summary of a message repeated for the first time.
+func (l *Logtor) dedup(level types.LogLevel, logMessage interface{}) bool {
+	d := l.deduplicator.Load()
+	if d == nil || level == types.PANIC || level == types.FATAL {
+		return true
+	}
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%s\x00%+v", level, logMessage)
+	key := hash.Sum64()
+	allowed, summary, start := d.allow(key, level, l.now())
+	l.logRepeats(summary)
+	if !start.IsZero() {
+		l.schedule(start.Add(d.window), func() { l.logRepeats(d.expire(key, start)) })
+	}
+	return allowed
+}
+
+// logRepeats logs the summary of the repeats of a message, if there is one.
+func (l *Logtor) logRepeats(summary *dedupSummary) {
+	if summary != nil {
+		l.logSummary(summary.level, fmt.Sprintf("last message repeated %d times", summary.repeats))
+	}
+}
@@ -0,0 +1,48 @@
+package logtor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestDeduplicatorEvictsExpiredEntries(t *testing.T) {
+	d := &deduplicator{window: time.Second, entries: make(map[uint64]*dedupEntry)}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for key := uint64(1); key <= 100; key++ {
+		if allowed, _, _ := d.allow(key, types.INFO, now); !allowed {
+			t.Fatalf("the first message %d should be kept", key)
+		}
+	}
+	if len(d.entries) != 100 {
+		t.Fatalf("got %d entries, want 100", len(d.entries))
+	}
+	if allowed, _, _ := d.allow(100, types.INFO, now); allowed {
+		t.Error("a repeat within the window should be suppressed")
+	}
+
+	allowed, summary, _ := d.allow(101, types.INFO, now.Add(time.Second))
+	if !allowed || summary == nil || summary.repeats != 1 || summary.level != types.INFO {
+		t.Errorf("unexpected result: %v %+v", allowed, summary)
+	}
+	if len(d.entries) != 1 {
+		t.Errorf("expired entries should be evicted, got %d entries", len(d.entries))
+	}
+}
+
+func TestDeduplicatorStopsTrackingWhenFull(t *testing.T) {
+	d := &deduplicator{window: time.Minute, entries: make(map[uint64]*dedupEntry)}
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	for key := uint64(1); key <= maxDedupEntries+10; key++ {
+		d.allow(key, types.INFO, now)
+	}
+	if len(d.entries) != maxDedupEntries {
+		t.Errorf("got %d entries, want at most %d", len(d.entries), maxDedupEntries)
+	}
+	if allowed, _, _ := d.allow(maxDedupEntries+10, types.INFO, now); !allowed {
+		t.Error("untracked messages should be kept")
+	}
+}
@@ -0,0 +1,84 @@
+package logtor_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorSetDedup(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetClock(clock)
+	newLogtor.SetLogLevel(types.TRACE)
+	newLogtor.SetDedup(time.Second)
+
+	for i := 0; i < 5; i++ {
+		newLogtor.LogIt(types.ERROR, "connection refused")
+		clock.Advance(10 * time.Millisecond)
+	}
+	if result := newLogtor.LogItAttempt(types.ERROR, "connection refused"); !result.Deduplicated || result.Logged {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	newLogtor.LogIt(types.WARN, "something else")
+	newLogtor.LogIt(types.WARN, "something else")
+
+	clock.Advance(time.Second)
+	waitFor(t, func() bool { return len(recorder.Messages()) == 4 })
+	newLogtor.LogIt(types.WARN, "something else")
+	newLogtor.LogIt(types.FATAL, "fatal")
+	newLogtor.LogIt(types.FATAL, "fatal")
+
+	expected := []string{
+		"ERROR connection refused",
+		"ERROR last message repeated 5 times",
+		"WARN something else",
+		"WARN last message repeated 1 times",
+		"WARN something else",
+		"FATAL fatal",
+		"FATAL fatal",
+	}
+	if messages := recorder.Messages(); fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("unexpected messages:\n%q\nwant\n%q", messages, expected)
+	}
+
+	newLogtor.SetDedup(0)
+	newLogtor.LogIt(types.INFO, "again")
+	newLogtor.LogIt(types.INFO, "again")
+	if messages := recorder.Messages(); len(messages) != len(expected)+2 {
+		t.Errorf("messages should not be suppressed once dedup is cleared: %q", messages)
+	}
+}
+
+func TestLogtorSetDedupReportsExpiredWindows(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetClock(clock)
+	newLogtor.SetLogLevel(types.TRACE)
+	newLogtor.SetDedup(time.Second)
+
+	for i := 0; i < 3; i++ {
+		newLogtor.LogIt(types.ERROR, "connection refused")
+	}
+	clock.Advance(time.Second)
+	waitFor(t, func() bool { return len(recorder.Messages()) == 2 })
+	if messages := recorder.Messages(); messages[1] != "ERROR last message repeated 2 times" {
+		t.Errorf("the summary should be logged once the window expired: %q", messages)
+	}
+
+	newLogtor.LogIt(types.WARN, "disk almost full")
+	newLogtor.LogIt(types.WARN, "disk almost full")
+	newLogtor.Shutdown()
+	clock.Advance(time.Second)
+	expected := "[ERROR connection refused ERROR last message repeated 2 times WARN disk almost full WARN last message repeated 1 times]"
+	if messages := recorder.Messages(); fmt.Sprint(messages) != expected {
+		t.Errorf("the pending summary should be logged once on shutdown: %q", messages)
+	}
+}
@@ -0,0 +1,136 @@
+package logtor
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// defaultExitTimeout bounds how long FlushOnExit waits for the log creators to shut down.
+const defaultExitTimeout = 5 * time.Second
+
+// SetExitFunc sets the function FlushOnExit calls after the log creators have been flushed and shut
+// down, instead of re-raising the signal. Fatal and Fatalf call it with a nil signal when SetExitOnFatal
+// is enabled, instead of calling os.Exit(1).
+//
+// Parameters:
+//   - exit: The function ending the process, called with the signal received. Nil restores re-raising.
+func (l *Logtor) SetExitFunc(exit func(os.Signal)) {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	l.exitFunc = exit
+}
+
+// SetExitTimeout sets how long FlushOnExit waits for the log creators to shut down before the process
+// exits anyway. Defaults to five seconds.
+//
+// Parameters:
+//   - timeout: The maximum wait. Zero or less restores the default.
+func (l *Logtor) SetExitTimeout(timeout time.Duration) {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	l.exitTimeout = timeout
+}
+
+// FlushOnExit flushes and shuts down the log creators of a Logtor when the process receives one of the
+// signals, then lets the termination proceed.
+//
+// On the first signal received, the Logtor is shut down with ShutdownCtx, which flushes every log creator
+// that buffers entries, bounded by the timeout set with SetExitTimeout. The exit function set with
+// SetExitFunc is then called; without one, the default behaviour of the signal is restored and the signal
+// is raised again, so the process terminates the way it would have without FlushOnExit. Further signals
+// that arrive while the log creators are being flushed are ignored.
+//
+// Parameters:
+//   - l: The Logtor to flush and shut down.
+//   - signals: The signals to handle, SIGINT and SIGTERM if none are given.
+//
+// Returns:
+//   - func(): A function that stops handling the signals. It is safe to call more than once.
+func FlushOnExit(l *Logtor, signals ...os.Signal) (stop func()) {
+	if len(signals) == 0 {
+		signals = []os.Signal{os.Interrupt, syscall.SIGTERM}
+	}
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+
+	done := make(chan struct{})
+	var stopOnce sync.Once
+	stop = func() {
+		stopOnce.Do(func() {
+			signal.Stop(received)
+			close(done)
+		})
+	}
+
+	go func() {
+		select {
+		case <-done:
+			return
+		case sig := <-received:
+			l.exitOnSignal(sig, signals)
+		}
+	}()
+	return stop
+}
+
+// SetExitOnFatal sets whether Fatal and Fatalf end the process after logging. Logging at FATAL level
+// with LogIt never ends the process.
+//
+// When enabled, Fatal and Fatalf flush and shut down the log creators like FlushOnExit, bounded by the
+// timeout set with SetExitTimeout, and then call the exit function set with SetExitFunc with a nil
+// signal, or os.Exit(1) without one. The process ends even if the message was filtered by the log level.
+// It is disabled by default.
+//
+// Parameters:
+//   - enabled: Whether Fatal and Fatalf should end the process.
+func (l *Logtor) SetExitOnFatal(enabled bool) {
+	l.exitOnFatal.Store(enabled)
+}
+
+// exitIfFatal ends the process after a Fatal or Fatalf call, if SetExitOnFatal is enabled.
+func (l *Logtor) exitIfFatal() {
+	if l.exitOnFatal.Load() {
+		l.exit()
+	}
+}
+
+// exit flushes and shuts down the Logtor, then ends the process with the exit function or os.Exit(1).
+func (l *Logtor) exit() {
+	if exit := l.flushForExit(); exit != nil {
+		exit(nil)
+		return
+	}
+	os.Exit(1)
+}
+
+// exitOnSignal flushes and shuts down the Logtor, then ends the process for the signal received.
+func (l *Logtor) exitOnSignal(sig os.Signal, signals []os.Signal) {
+	if exit := l.flushForExit(); exit != nil {
+		exit(sig)
+		return
+	}
+	signal.Reset(signals...)
+	if process, err := os.FindProcess(os.Getpid()); err != nil || process.Signal(sig) != nil {
+		os.Exit(1)
+	}
+}
+
+// flushForExit flushes and shuts down the Logtor, bounded by the exit timeout, and returns the exit
+// function set with SetExitFunc.
+func (l *Logtor) flushForExit() func(os.Signal) {
+	l.changeMutex.RLock()
+	exit, timeout := l.exitFunc, l.exitTimeout
+	l.changeMutex.RUnlock()
+	if timeout <= 0 {
+		timeout = defaultExitTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	l.ShutdownCtx(ctx)
+	return exit
+}
@@ -0,0 +1,135 @@
+//go:build !windows
+
+package logtor_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// flushOnExitEnv selects the helper process role of TestFlushOnExit.
+const flushOnExitEnv = "LOGTOR_FLUSH_ON_EXIT_HELPER"
+
+// stdoutCreator writes its lifecycle to stdout, so the parent test can observe the helper process.
+type stdoutCreator struct {
+	discardCreator
+}
+
+func (sc *stdoutCreator) Flush() error {
+	fmt.Println("flushed")
+	return nil
+}
+
+func (sc *stdoutCreator) Shutdown() {
+	fmt.Println("shut down")
+}
+
+// TestFlushOnExitHelper is the helper process of TestFlushOnExit. It waits for signals after reporting
+// that it is ready.
+func TestFlushOnExitHelper(t *testing.T) {
+	mode := os.Getenv(flushOnExitEnv)
+	if mode == "" {
+		t.Skip("helper process only")
+	}
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(&stdoutCreator{discardCreator{name: "Stdout"}})
+	newLogtor.SetLogLevel(types.INFO)
+	if mode == "exitfunc" {
+		newLogtor.SetExitFunc(func(sig os.Signal) {
+			fmt.Println("exit func:", sig)
+			os.Exit(3)
+		})
+	}
+	logtor.FlushOnExit(newLogtor)
+	fmt.Println("ready")
+	time.Sleep(10 * time.Second)
+	fmt.Println("not terminated")
+}
+
+// startFlushOnExitHelper starts the helper process and waits until it handles signals. The returned
+// function waits for the helper to close its stdout and returns everything it wrote.
+func startFlushOnExitHelper(t *testing.T, mode string) (*exec.Cmd, func() string) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], "-test.run=^TestFlushOnExitHelper$")
+	cmd.Env = append(os.Environ(), flushOnExitEnv+"="+mode)
+	output := &strings.Builder{}
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmd.Stdout = writer
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+
+	ready, finished := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(finished)
+		defer reader.Close()
+		buffer := make([]byte, 256)
+		signaled := false
+		for {
+			n, err := reader.Read(buffer)
+			output.Write(buffer[:n])
+			if !signaled && strings.Contains(output.String(), "ready") {
+				signaled = true
+				close(ready)
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	select {
+	case <-ready:
+	case <-time.After(5 * time.Second):
+		cmd.Process.Kill()
+		t.Fatal("helper process did not start")
+	}
+	return cmd, func() string {
+		<-finished
+		return output.String()
+	}
+}
+
+func TestFlushOnExit(t *testing.T) {
+	cmd, output := startFlushOnExitHelper(t, "reraise")
+	cmd.Process.Signal(syscall.SIGTERM)
+	cmd.Process.Signal(syscall.SIGTERM)
+	err := cmd.Wait()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		t.Fatalf("helper should be terminated by the signal, got %v", err)
+	}
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); !ok || !status.Signaled() || status.Signal() != syscall.SIGTERM {
+		t.Errorf("helper should be terminated by SIGTERM, got %v", exitErr)
+	}
+	if text := output(); strings.Count(text, "flushed") != 1 || strings.Count(text, "shut down") != 1 || strings.Contains(text, "not terminated") {
+		t.Errorf("log creators should be flushed and shut down once: %q", text)
+	}
+}
+
+func TestFlushOnExitWithExitFunc(t *testing.T) {
+	cmd, output := startFlushOnExitHelper(t, "exitfunc")
+	cmd.Process.Signal(syscall.SIGINT)
+	err := cmd.Wait()
+
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Fatalf("helper should exit through the exit func, got %v", err)
+	}
+	if text := output(); !strings.Contains(text, "flushed\nshut down\nexit func: interrupt") {
+		t.Errorf("the exit func should run after the shutdown: %q", text)
+	}
+}
@@ -0,0 +1,171 @@
+package logtor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// stderrCreatorName is the name of the built-in stderr fallback.
+const stderrCreatorName types.LogCreatorName = "stderr"
+
+// stderrFallback is the log creator used by Logtors with SetStderrFallback enabled.
+var stderrFallback = &stderrCreator{name: stderrCreatorName}
+
+// SetStderrFallback enables or disables the built-in stderr fallback.
+//
+// When enabled, messages that have no log creator to go to, because no log creator has been added yet
+// or all of them were removed, and no default creator is set, are written to os.Stderr instead of being
+// dropped, so early startup messages are not lost. The fallback is not registered as a log creator, and
+// a default creator always takes precedence over it. It is disabled by default.
+//
+// Parameters:
+//   - enabled: Whether messages without a log creator should be written to os.Stderr.
+func (l *Logtor) SetStderrFallback(enabled bool) {
+	l.stderrFallback.Store(enabled)
+}
+
+// WithFallbacks registers log creators as the fallback chain, which receives the messages of log creators
+// that are not ready after the default creator.
+//
+// The chain is tried in order, skipping log creators that are not ready, until one of them logs the
+// message. Like the default creator, the fallbacks are added to the list of log creators without becoming
+// active; nil creators and creators whose name is invalid or taken by another log creator are ignored.
+// The chain refers to the log creators by name, so a replaced fallback is followed to its new instance and
+// a removed one drops out of the chain. Calling WithFallbacks again replaces the chain.
+//
+// Parameters:
+//   - creators: The log creators to fall back to, in order.
+//
+// Returns:
+//   - *Logtor: The Logtor instance, for chaining.
+func (l *Logtor) WithFallbacks(creators ...LogCreator) *Logtor {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	names := make([]types.LogCreatorName, 0, len(creators))
+	for _, creator := range creators {
+		if isNilCreator(creator) {
+			continue
+		}
+		name := creator.LogName()
+		if registered, ok := l.logCreatorList[name]; ok {
+			if !sameCreator(registered, creator) {
+				continue
+			}
+		} else if name.Validate() != nil {
+			continue
+		} else {
+			l.logCreatorList[name] = creator
+		}
+		names = append(names, name)
+	}
+	l.fallbackNames = names
+	l.rebuildRoutedCreators()
+	return l
+}
+
+// Fallbacks returns the names of the registered log creators in the fallback chain set with WithFallbacks,
+// in the order they are tried.
+//
+// Returns:
+//   - []types.LogCreatorName: The names of the fallback log creators.
+func (l *Logtor) Fallbacks() []types.LogCreatorName {
+	fallbacks := l.fallbacks.Load()
+	if fallbacks == nil {
+		return nil
+	}
+	names := make([]types.LogCreatorName, len(*fallbacks))
+	for i, logCreator := range *fallbacks {
+		names[i] = logCreator.LogName()
+	}
+	return names
+}
+
+// rebuildFallbacks resolves fallbackNames against logCreatorList. It must be called with changeMutex held.
+func (l *Logtor) rebuildFallbacks() {
+	if len(l.fallbackNames) == 0 {
+		l.fallbacks.Store(nil)
+		return
+	}
+	fallbacks := make([]LogCreator, 0, len(l.fallbackNames))
+	for _, name := range l.fallbackNames {
+		if logCreator, ok := l.logCreatorList[name]; ok {
+			fallbacks = append(fallbacks, logCreator)
+		}
+	}
+	l.fallbacks.Store(&fallbacks)
+}
+
+// fallbackChain returns the log creators recording the messages of a log creator that is missing or not
+// ready, in the order they are tried: the default creator and the fallbacks that are ready, then the
+// stderr fallback if it is enabled.
+func (l *Logtor) fallbackChain() []LogCreator {
+	var chain []LogCreator
+	if logCreator := l.defaultCreator.Load(); logCreator != nil && logCreator.IsReady() {
+		chain = append(chain, logCreator)
+	}
+	if fallbacks := l.fallbacks.Load(); fallbacks != nil {
+		for _, logCreator := range *fallbacks {
+			if logCreator.IsReady() {
+				chain = append(chain, logCreator)
+			}
+		}
+	}
+	if l.stderrFallback.Load() {
+		chain = append(chain, stderrFallback)
+	}
+	return chain
+}
+
+// stderrCreator writes entries to os.Stderr in the text format of the file creator. It looks up
+// os.Stderr on every write, so redirecting it takes effect immediately. It backs both the stderr fallback
+// and the console creator of NewWithDefault, so the root package logs without importing creators.
+type stderrCreator struct {
+	mutex sync.Mutex
+	name  types.LogCreatorName
+}
+
+func (sc *stderrCreator) LogRendered(entry types.RenderedEntry) bool {
+	sc.mutex.Lock()
+	defer sc.mutex.Unlock()
+	_, err := fmt.Fprintf(os.Stderr, "%-5s : %s %s:%d: %s\n", entry.Level, entry.Time.Format("2006/01/02 15:04:05"),
+		filepath.Base(entry.File), entry.Line, entry.Text())
+	return err == nil
+}
+
+func (sc *stderrCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	if callDepth <= 0 {
+		callDepth = sc.CallDepth() - 1
+	}
+	entry := types.RenderedEntry{Level: level, Time: SystemClock{}.Now(), Message: fmt.Sprintf("%+v", logMessage)}
+	var ok bool
+	if _, entry.File, entry.Line, ok = runtime.Caller(callDepth - 1); !ok {
+		entry.File = "???"
+	}
+	return sc.LogRendered(entry)
+}
+
+func (sc *stderrCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return sc.LogItWithCallDepth(level, sc.CallDepth(), logMessage)
+}
+
+func (sc *stderrCreator) LogName() types.LogCreatorName {
+	return sc.name
+}
+
+func (sc *stderrCreator) SetCallDepth(callDepth int) {}
+
+// CallDepth attributes entries to the caller of the Logtor method, as the fallback is only used by Logtor.
+func (sc *stderrCreator) CallDepth() int {
+	return 4
+}
+
+func (sc *stderrCreator) IsReady() bool {
+	return true
+}
+
+func (sc *stderrCreator) Shutdown() {}
@@ -0,0 +1,159 @@
+package logtor_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorLogItWithoutLogCreators(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	if newLogtor.LogIt(types.INFO, "before any log creator") {
+		t.Error("a message without a log creator should not be reported as logged")
+	}
+	if newLogtor.LogItWithCallDepth(types.INFO, 3, "before any log creator") {
+		t.Error("a message without a log creator should not be reported as logged")
+	}
+
+	recorder := newRecordingCreator("Recorder")
+	newLogtor.AddLogCreators(recorder)
+	if err := newLogtor.RemoveLogCreator("Recorder"); err != nil {
+		t.Fatal(err)
+	}
+	if newLogtor.LogIt(types.INFO, "after removing every log creator") {
+		t.Error("a message without a log creator should not be reported as logged")
+	}
+	if len(recorder.Messages()) != 0 {
+		t.Errorf("a removed log creator should not receive messages: %v", recorder.Messages())
+	}
+}
+
+func TestLogtorLogItWithoutActiveCreatorUsesDefault(t *testing.T) {
+	defaultCreator := newRecordingCreator("Fallback")
+	newLogtor := logtor.New().WithDefaultCreator(defaultCreator)
+	newLogtor.SetLogLevel(types.INFO)
+	if !newLogtor.LogIt(types.INFO, "early message") {
+		t.Fatal("the default creator should record messages without an active log creator")
+	}
+	if messages := defaultCreator.Messages(); len(messages) != 1 || !strings.Contains(messages[0], "early message") {
+		t.Errorf("unexpected messages: %v", messages)
+	}
+}
+
+func TestLogtorStderrFallback(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = writer
+	defer func() { os.Stderr = stderr }()
+
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.SetStderrFallback(true)
+	logged := newLogtor.LogIt(types.WARN, "early startup message")
+	newLogtor.SetStderrFallback(false)
+	dropped := newLogtor.LogIt(types.WARN, "dropped message")
+	os.Stderr = stderr
+	writer.Close()
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !logged || dropped {
+		t.Errorf("unexpected results: logged=%v dropped=%v", logged, dropped)
+	}
+	if text := string(output); !strings.HasPrefix(text, "WARN  : ") || !strings.Contains(text, "fallback_test.go:") ||
+		!strings.HasSuffix(text, ": early startup message\n") {
+		t.Errorf("unexpected stderr output: %q", text)
+	}
+}
+
+func TestLogtorWithFallbacks(t *testing.T) {
+	broker, file, console := newRecordingCreator("Broker"), newRecordingCreator("File"), newRecordingCreator("Console")
+	broker.notReady, file.notReady = true, true
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(broker)
+	newLogtor.ChangeLogCreator("Broker")
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.WithFallbacks(file, &failingCreator{discardCreator{name: "Failing"}}, console)
+
+	if fallbacks := newLogtor.Fallbacks(); !reflect.DeepEqual(fallbacks, []types.LogCreatorName{"File", "Failing", "Console"}) {
+		t.Errorf("unexpected fallbacks: %v", fallbacks)
+	}
+	if !newLogtor.LogIt(types.INFO, "stood in for") || newLogtor.LogItE(types.WARN, "with LogItE") != nil {
+		t.Fatal("the message should reach the fallback chain")
+	}
+	if messages := console.Messages(); strings.Join(messages, "|") != "INFO stood in for|WARN with LogItE" {
+		t.Errorf("unexpected Console messages: %v", messages)
+	}
+	if len(broker.Messages()) != 0 || len(file.Messages()) != 0 {
+		t.Error("log creators that are not ready should be skipped")
+	}
+
+	recorder := httptest.NewRecorder()
+	newLogtor.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/log-creators/current", nil))
+	if body := recorder.Body.String(); body != `{"current_log_creator":"Broker","fallbacks":["File","Failing","Console"]}` {
+		t.Errorf("unexpected response: %s", body)
+	}
+
+	newLogtor.RemoveLogCreator("Console")
+	if err := newLogtor.LogItE(types.INFO, "dropped"); !errors.Is(err, logtor.ErrNotLogged) {
+		t.Errorf("expected the error of the last fallback, got %v", err)
+	}
+}
+
+func TestLogtorWithFallbacksOnEveryLogPath(t *testing.T) {
+	broker, console := newRecordingCreator("Broker"), newRecordingCreator("Console")
+	broker.notReady = true
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	newLogtor := logtor.New()
+	newLogtor.SetClock(clock)
+	newLogtor.AddLogCreators(broker)
+	newLogtor.ChangeLogCreator("Broker")
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.WithFallbacks(&failingCreator{discardCreator{name: "Failing"}}, console)
+	newLogtor.SetRateLimit(types.WARN, 1, 1)
+
+	if !newLogtor.Named("billing").LogIt(types.INFO, "named") {
+		t.Error("NamedLogtor should reach the fallback chain")
+	}
+	if !newLogtor.LogItContext(context.Background(), types.INFO, "context") {
+		t.Error("LogItContext should reach the fallback chain")
+	}
+	if !newLogtor.LogEntry(types.LogEntry{Level: types.INFO, Message: "entry"}) {
+		t.Error("LogEntry should reach the fallback chain")
+	}
+	if result := newLogtor.LogItAttempt(types.INFO, "attempt"); !result.Logged || !result.FallbackUsed || result.CreatorUsed != "Console" {
+		t.Errorf("LogItAttempt should report the fallback chain: %+v", result)
+	}
+	newLogtor.LogIt(types.WARN, "kept")
+	newLogtor.LogIt(types.WARN, "dropped")
+	clock.Advance(10 * time.Second)
+	waitFor(t, func() bool { return len(console.Messages()) == 6 })
+	newLogtor.LogIt(types.WARN, "after the interval")
+
+	messages := console.Messages()
+	if len(messages) != 7 || !strings.Contains(messages[0], "named") || !strings.Contains(messages[1], "context") || !strings.Contains(messages[2], "entry") {
+		t.Fatalf("unexpected Console messages: %q", messages)
+	}
+	if messages[5] != "WARN suppressed 1 WARN messages in the last 10s" {
+		t.Errorf("the rate limit summary should reach the fallback chain: %q", messages[5])
+	}
+	if len(broker.Messages()) != 0 {
+		t.Error("the log creator that is not ready should be skipped")
+	}
+}
@@ -0,0 +1,73 @@
+package logtor
+
+import (
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// Filter decides whether a message is passed to a log creator, in addition to the log levels.
+//
+// It is called with the level and the message as they were logged, before the global fields and hooks
+// are applied, and returns false to skip the log creator.
+type Filter func(level types.LogLevel, logMessage interface{}) bool
+
+// SetCreatorFilter sets the filter of a single log creator, replacing its previous filter.
+//
+// Filters are evaluated after the log level check. A message rejected by the filter of the log creator
+// it is addressed to is not logged; LogItAll and BroadcastIt skip only the log creators whose filter
+// rejects it and still pass it to the others. The filter of a log creator also applies when the default
+// creator stands in for it because it is not ready.
+//
+// The filters are guarded by the same lock as the list of log creators and published to the logging
+// path as a snapshot, so log calls never wait for a change.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator.
+//   - filter: The filter of the log creator, or nil to remove it.
+//
+// Returns:
+//   - bool: True if the filter was set or removed; false if the log creator does not exist.
+func (l *Logtor) SetCreatorFilter(logCreatorName types.LogCreatorName, filter Filter) bool {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	if _, ok := l.logCreatorList[logCreatorName]; !ok {
+		return false
+	}
+	l.storeCreatorFilter(logCreatorName, filter)
+	return true
+}
+
+// storeCreatorFilter publishes a copy of the filters with the filter of the log creator replaced, or
+// removed if filter is nil. It must be called with changeMutex held.
+func (l *Logtor) storeCreatorFilter(logCreatorName types.LogCreatorName, filter Filter) {
+	current := l.creatorFilters.Load()
+	if current == nil && filter == nil {
+		return
+	}
+	updated := make(map[types.LogCreatorName]Filter)
+	if current != nil {
+		for name, existing := range *current {
+			updated[name] = existing
+		}
+	}
+	if filter == nil {
+		delete(updated, logCreatorName)
+	} else {
+		updated[logCreatorName] = filter
+	}
+	if len(updated) == 0 {
+		l.creatorFilters.Store(nil)
+		return
+	}
+	l.creatorFilters.Store(&updated)
+}
+
+// creatorAccepts reports whether the filter of the log creator, if it has one, accepts the message. It
+// does not take changeMutex.
+func (l *Logtor) creatorAccepts(logCreator LogCreator, level types.LogLevel, logMessage interface{}) bool {
+	filters := l.creatorFilters.Load()
+	if filters == nil || logCreator == nil {
+		return true
+	}
+	filter, ok := (*filters)[logCreator.LogName()]
+	return !ok || filter(level, logMessage)
+}
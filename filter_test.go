@@ -0,0 +1,52 @@
+package logtor_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorSetCreatorFilter(t *testing.T) {
+	console := newRecordingCreator("Console")
+	broker := newRecordingCreator("Broker")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(console, broker)
+	newLogtor.ChangeLogCreator("Console")
+	newLogtor.SetLogLevel(types.INFO)
+
+	if newLogtor.SetCreatorFilter("Missing", nil) {
+		t.Error("a filter should not be set for an unknown log creator")
+	}
+	newLogtor.SetCreatorFilter("Console", func(level types.LogLevel, logMessage interface{}) bool {
+		return !strings.Contains(fmt.Sprint(logMessage), "healthcheck")
+	})
+	newLogtor.SetCreatorFilter("Broker", func(level types.LogLevel, logMessage interface{}) bool {
+		return level == types.ERROR || level == types.FATAL
+	})
+
+	if newLogtor.LogIt(types.INFO, "GET /healthcheck") {
+		t.Error("the message should be skipped by the filter of the active log creator")
+	}
+	if result := newLogtor.LogItAttempt(types.INFO, "healthcheck ok"); !result.FilteredByCreator || result.Logged {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	newLogtor.LogIt(types.INFO, "order placed")
+	newLogtor.LogItAll(types.INFO, "cache warmed")
+	newLogtor.LogItAll(types.ERROR, "healthcheck failed")
+	newLogtor.BroadcastIt(types.ERROR, "payment failed")
+
+	if messages := console.Messages(); strings.Join(messages, "|") != "INFO order placed|INFO cache warmed|ERROR payment failed" {
+		t.Errorf("unexpected Console messages: %v", messages)
+	}
+	if messages := broker.Messages(); strings.Join(messages, "|") != "ERROR healthcheck failed|ERROR payment failed" {
+		t.Errorf("unexpected Broker messages: %v", messages)
+	}
+
+	newLogtor.SetCreatorFilter("Console", nil)
+	if !newLogtor.LogIt(types.INFO, "GET /healthcheck") {
+		t.Error("the message should be logged once the filter is removed")
+	}
+}
@@ -0,0 +1,125 @@
+package logtor
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// FlagConfig holds the values of the command line flags defined by RegisterFlags.
+//
+// Fields:
+//   - Level: The value of -log-level. Defaults to INFO.
+//   - File: The value of -log-file. Empty unless file logging was requested.
+//   - Format: The value of -log-format, either "text" (the default) or "json".
+type FlagConfig struct {
+	Level  types.LogLevel
+	File   string
+	Format string
+}
+
+// RegisterFlags defines the -log-level, -log-file and -log-format flags on the flag set.
+//
+// The returned FlagConfig is filled in when the flag set is parsed; call FlagConfig.Build afterwards.
+// The creators package must be imported, since Build constructs the log creators through its factories.
+//
+// Example:
+//
+//	flagConfig := logtor.RegisterFlags(flag.CommandLine)
+//	flag.Parse()
+//	l, err := flagConfig.Build()
+//
+// Parameters:
+//   - fs: The flag set the flags are defined on.
+//
+// Returns:
+//   - *FlagConfig: The configuration receiving the flag values.
+func RegisterFlags(fs *flag.FlagSet) *FlagConfig {
+	flagConfig := &FlagConfig{Level: types.INFO, Format: "text"}
+	fs.Var(&flagConfig.Level, "log-level", "log level (PANIC, FATAL, ERROR, WARN, DEBUG, INFO, TRACE or NONE)")
+	fs.StringVar(&flagConfig.File, "log-file", "", "write logs to this file instead of the console")
+	fs.StringVar(&flagConfig.Format, "log-format", flagConfig.Format, "console log format (text or json)")
+	return flagConfig
+}
+
+// Build constructs a Logtor from the flag values.
+//
+// A console log creator named "Console" is always constructed, writing JSON lines when -log-format is json.
+// When -log-file is set, a file log creator named "File" is constructed as well and becomes the active
+// creator, with the console creator as the default creator used while the file is not ready.
+// Without any flags the result logs exactly like a plain BaseCreator at INFO.
+//
+// Returns:
+//   - *Logtor: The constructed Logtor.
+//   - error: An error if the format is unknown or a log creator cannot be constructed.
+func (fc *FlagConfig) Build() (*Logtor, error) {
+	consoleSettings := map[string]interface{}{}
+	switch fc.Format {
+	case "", "text":
+	case "json":
+		consoleSettings["json_format"] = true
+	default:
+		return nil, fmt.Errorf("logtor: invalid log format %q (valid formats: text, json)", fc.Format)
+	}
+
+	builder := NewBuilder().
+		Creator("Console", "console", consoleSettings).
+		Level(fc.Level)
+	if fc.File != "" {
+		builder.File(fc.File, "File").ActiveTo("File").DefaultTo("Console")
+	}
+	return builder.Build()
+}
+
+// SetupFromFlags defines the <prefix>log-level and <prefix>log-creator flags on the default
+// command line flag set and returns a function applying their values to l.
+//
+// Flag values are only available after flag.Parse, so the returned function must be called after it.
+// An empty log creator name keeps the active log creator, which is the first registered one unless
+// it was changed.
+//
+// Example:
+//
+//	apply := logtor.SetupFromFlags(l, "")
+//	flag.Parse()
+//	if err := apply(); err != nil {
+//		log.Fatal(err)
+//	}
+//
+// Parameters:
+//   - l: The Logtor the flag values are applied to.
+//   - prefix: A prefix for the flag names, e.g. "app-" for -app-log-level.
+//
+// Returns:
+//   - func() error: The function applying the flag values, returning an error for an invalid level or unknown creator.
+func SetupFromFlags(l *Logtor, prefix string) func() error {
+	return SetupFromFlagSet(flag.CommandLine, l, prefix)
+}
+
+// SetupFromFlagSet defines the <prefix>log-level and <prefix>log-creator flags on a flag set, like
+// SetupFromFlags does on the default command line flag set, and returns a function applying their
+// values to l once the flag set has been parsed.
+//
+// Parameters:
+//   - fs: The flag set to define the flags on.
+//   - l: The Logtor the flag values are applied to.
+//   - prefix: A prefix for the flag names, e.g. "app-" for -app-log-level.
+//
+// Returns:
+//   - func() error: The function applying the flag values, returning an error for an invalid level or unknown creator.
+func SetupFromFlagSet(fs *flag.FlagSet, l *Logtor, prefix string) func() error {
+	level := fs.String(prefix+"log-level", string(types.INFO), "log level (PANIC, FATAL, ERROR, WARN, DEBUG, INFO, TRACE or NONE)")
+	creator := fs.String(prefix+"log-creator", "", "active log creator name")
+	return func() error {
+		var logLevel types.LogLevel
+		if err := logLevel.Set(*level); err != nil {
+			return fmt.Errorf("logtor: -%slog-level: %w", prefix, err)
+		}
+		if *creator != "" && !l.ChangeLogCreator(types.LogCreatorName(*creator)) {
+			return fmt.Errorf("logtor: -%slog-creator: unknown log creator %q", prefix, *creator)
+		}
+		l.SetLogLevel(logLevel)
+		return nil
+	}
+}
@@ -0,0 +1,112 @@
+package logtor_test
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestRegisterFlagsDefaults(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagConfig := logtor.RegisterFlags(fs)
+	if err := fs.Parse(nil); err != nil {
+		t.Fatal(err)
+	}
+
+	newLogtor, err := flagConfig.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer newLogtor.Shutdown()
+	if newLogtor.LogLevel() != types.INFO {
+		t.Errorf("unexpected log level: %s", newLogtor.LogLevel())
+	}
+	settings := newLogtor.LogCreator().(logtor.CreatorDescriber).Describe()
+	if newLogtor.LogCreator().LogName() != creators.Console || settings["json_format"] != false {
+		t.Errorf("unexpected creator: %v", settings)
+	}
+}
+
+func TestRegisterFlagsWithFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flagConfig := logtor.RegisterFlags(fs)
+	if err := fs.Parse([]string{"-log-level", "warn", "-log-file", path, "-log-format", "json"}); err != nil {
+		t.Fatal(err)
+	}
+
+	newLogtor, err := flagConfig.Build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer newLogtor.Shutdown()
+	if newLogtor.LogLevel() != types.WARN {
+		t.Errorf("unexpected log level: %s", newLogtor.LogLevel())
+	}
+	if newLogtor.LogCreator().LogName() != "File" {
+		t.Errorf("unexpected active creator: %s", newLogtor.LogCreator().LogName())
+	}
+	newLogtor.LogIt(types.ERROR, "Example Flag Log Message")
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "Example Flag Log Message") {
+		t.Errorf("message not written to the log file: %q", content)
+	}
+}
+
+func TestRegisterFlagsRejectsInvalidValues(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&strings.Builder{})
+	logtor.RegisterFlags(fs)
+	if err := fs.Parse([]string{"-log-level", "loud"}); err == nil {
+		t.Error("expected an invalid log level to be rejected")
+	}
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	flagConfig := logtor.RegisterFlags(fs)
+	if err := fs.Parse([]string{"-log-format", "xml"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := flagConfig.Build(); err == nil {
+		t.Error("expected an invalid log format to be rejected")
+	}
+}
+
+func TestSetupFromFlags(t *testing.T) {
+	first := newRecordingCreator("First")
+	second := newRecordingCreator("Second")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(first, second)
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	apply := logtor.SetupFromFlagSet(fs, newLogtor, "setup-test-")
+	if err := apply(); err != nil {
+		t.Fatal(err)
+	}
+	if newLogtor.LogLevel() != types.INFO || newLogtor.LogCreator().LogName() != "First" {
+		t.Errorf("unexpected defaults: level=%s creator=%s", newLogtor.LogLevel(), newLogtor.LogCreator().LogName())
+	}
+
+	if err := fs.Parse([]string{"-setup-test-log-level", "error", "-setup-test-log-creator", "Second"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := apply(); err != nil {
+		t.Fatal(err)
+	}
+	if newLogtor.LogLevel() != types.ERROR || newLogtor.LogCreator().LogName() != "Second" {
+		t.Errorf("flags not applied: level=%s creator=%s", newLogtor.LogLevel(), newLogtor.LogCreator().LogName())
+	}
+
+	fs.Set("setup-test-log-creator", "Missing")
+	if err := apply(); err == nil {
+		t.Error("expected an unknown log creator to be rejected")
+	}
+}
@@ -0,0 +1,134 @@
+package logtor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// formattedCallDepth is the call depth attributing a message logged by logItf to the caller of the
+// exported method calling logItf: the log creator, LogItWithCallDepth, logItf and the exported method
+// sit between the log creator's runtime.Caller and that caller.
+const formattedCallDepth = 5
+
+// LogItf formats a message like fmt.Sprintf and logs it at the specified log level.
+//
+// The log level is checked before the message is formatted, so suppressed levels cost no formatting.
+// The %w verb is supported like in fmt.Errorf, rendering the wrapped error's message.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - format: The format string.
+//   - args: The arguments for the format string.
+//
+// Returns:
+//   - bool: True if the message was successfully logged; false if it was skipped due to the log level
+//     or the Logtor has been shut down.
+func (l *Logtor) LogItf(level types.LogLevel, format string, args ...interface{}) bool {
+	return l.logItf(level, 0, format, args)
+}
+
+// LogItfWithCallDepth formats a message like LogItf and logs it with a custom call depth.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for calling function, as for LogItWithCallDepth, or zero or less for the caller of LogItfWithCallDepth.
+//   - format: The format string.
+//   - args: The arguments for the format string.
+//
+// Returns:
+//   - bool: True if the message was successfully logged; false if it was skipped due to the log level
+//     or the Logtor has been shut down.
+func (l *Logtor) LogItfWithCallDepth(level types.LogLevel, callDepth int, format string, args ...interface{}) bool {
+	return l.logItf(level, callDepth, format, args)
+}
+
+// Tracef formats a message like LogItf and logs it at TRACE level.
+func (l *Logtor) Tracef(format string, args ...interface{}) bool {
+	return l.logItf(types.TRACE, 0, format, args)
+}
+
+// Debugf formats a message like LogItf and logs it at DEBUG level.
+func (l *Logtor) Debugf(format string, args ...interface{}) bool {
+	return l.logItf(types.DEBUG, 0, format, args)
+}
+
+// Infof formats a message like LogItf and logs it at INFO level.
+func (l *Logtor) Infof(format string, args ...interface{}) bool {
+	return l.logItf(types.INFO, 0, format, args)
+}
+
+// Warnf formats a message like LogItf and logs it at WARN level.
+func (l *Logtor) Warnf(format string, args ...interface{}) bool {
+	return l.logItf(types.WARN, 0, format, args)
+}
+
+// Errorf formats a message like LogItf and logs it at ERROR level.
+func (l *Logtor) Errorf(format string, args ...interface{}) bool {
+	return l.logItf(types.ERROR, 0, format, args)
+}
+
+// Fatalf formats a message like LogItf and logs it at FATAL level, then ends the process if
+// SetExitOnFatal is enabled.
+func (l *Logtor) Fatalf(format string, args ...interface{}) bool {
+	logged := l.logItf(types.FATAL, 0, format, args)
+	l.exitIfFatal()
+	return logged
+}
+
+// TracefWithCallDepth formats a message like LogItfWithCallDepth and logs it at TRACE level.
+func (l *Logtor) TracefWithCallDepth(callDepth int, format string, args ...interface{}) bool {
+	return l.logItf(types.TRACE, callDepth, format, args)
+}
+
+// DebugfWithCallDepth formats a message like LogItfWithCallDepth and logs it at DEBUG level.
+func (l *Logtor) DebugfWithCallDepth(callDepth int, format string, args ...interface{}) bool {
+	return l.logItf(types.DEBUG, callDepth, format, args)
+}
+
+// InfofWithCallDepth formats a message like LogItfWithCallDepth and logs it at INFO level.
+func (l *Logtor) InfofWithCallDepth(callDepth int, format string, args ...interface{}) bool {
+	return l.logItf(types.INFO, callDepth, format, args)
+}
+
+// WarnfWithCallDepth formats a message like LogItfWithCallDepth and logs it at WARN level.
+func (l *Logtor) WarnfWithCallDepth(callDepth int, format string, args ...interface{}) bool {
+	return l.logItf(types.WARN, callDepth, format, args)
+}
+
+// ErrorfWithCallDepth formats a message like LogItfWithCallDepth and logs it at ERROR level.
+func (l *Logtor) ErrorfWithCallDepth(callDepth int, format string, args ...interface{}) bool {
+	return l.logItf(types.ERROR, callDepth, format, args)
+}
+
+// FatalfWithCallDepth formats a message like LogItfWithCallDepth and logs it at FATAL level, then ends the
+// process if SetExitOnFatal is enabled.
+func (l *Logtor) FatalfWithCallDepth(callDepth int, format string, args ...interface{}) bool {
+	logged := l.logItf(types.FATAL, callDepth, format, args)
+	l.exitIfFatal()
+	return logged
+}
+
+// logItf formats and logs a message for the exported formatting methods, which must call it directly.
+// A callDepth of zero or less attributes the message to their caller.
+func (l *Logtor) logItf(level types.LogLevel, callDepth int, format string, args []interface{}) bool {
+	if !l.isLoggable(level) {
+		return false
+	}
+	if callDepth > 0 {
+		// logItf and the exported method add two frames on top of LogItWithCallDepth.
+		callDepth += 2
+	} else {
+		callDepth = formattedCallDepth
+	}
+	return l.LogItWithCallDepth(level, callDepth, formatMessage(format, args))
+}
+
+// formatMessage formats a message like fmt.Sprintf, or like fmt.Errorf if the format wraps an error.
+func formatMessage(format string, args []interface{}) string {
+	if strings.Contains(format, "%w") {
+		return fmt.Errorf(format, args...).Error()
+	}
+	return fmt.Sprintf(format, args...)
+}
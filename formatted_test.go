@@ -0,0 +1,165 @@
+package logtor_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// countingStringer counts how often it is formatted.
+type countingStringer struct {
+	calls *int
+}
+
+func (cs countingStringer) String() string {
+	*cs.calls++
+	return "formatted"
+}
+
+func TestLogtorLogItf(t *testing.T) {
+	testCreator := logtor.NewTestCreator("Test")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(testCreator)
+	newLogtor.SetLogLevel(types.INFO)
+
+	errNotFound := errors.New("not found")
+	newLogtor.LogItf(types.WARN, "retry %d of %d", 2, 3)
+	newLogtor.Errorf("loading user %s: %w", "u-1", errNotFound)
+	newLogtor.Infof("plain")
+	newLogtor.Debugf("debug %s", "enabled")
+	newLogtor.Fatalf("fatal %v", true)
+	newLogtor.WarnfWithCallDepth(3, "with depth %d", 3)
+
+	calls := 0
+	if newLogtor.Tracef("suppressed %s", countingStringer{calls: &calls}) || newLogtor.LogItf(types.TRACE, "suppressed %s", countingStringer{calls: &calls}) {
+		t.Error("suppressed levels should not be logged")
+	}
+	if calls != 0 {
+		t.Errorf("suppressed messages should not be formatted, got %d calls", calls)
+	}
+
+	expected := []struct {
+		level   types.LogLevel
+		message string
+	}{
+		{types.WARN, "retry 2 of 3"},
+		{types.ERROR, "loading user u-1: not found"},
+		{types.INFO, "plain"},
+		{types.DEBUG, "debug enabled"},
+		{types.FATAL, "fatal true"},
+		{types.WARN, "with depth 3"},
+	}
+	entries := newLogtor.Snapshot()
+	if len(entries) != len(expected) {
+		t.Fatalf("unexpected entries: %+v", entries)
+	}
+	for i, entry := range entries {
+		if entry.Level != expected[i].level || entry.Message != expected[i].message {
+			t.Errorf("entry %d: got %s %q, want %s %q", i, entry.Level, entry.Message, expected[i].level, expected[i].message)
+		}
+	}
+	if wrapped := fmt.Errorf("loading user %s: %w", "u-1", errNotFound); entries[1].Message != wrapped.Error() {
+		t.Errorf("%%w should render like fmt.Errorf: %q", entries[1].Message)
+	}
+}
+
+func TestLogtorLogItfCallDepth(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "formatted.log")
+	fileCreator, err := creators.NewFileCreatorWithOptions(fileName, creators.WithCallDepth(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(fileCreator, legacyCreator{newFileCreator(t, filepath.Join(filepath.Dir(fileName), "legacy.log"))})
+	newLogtor.SetLogLevel(types.INFO)
+
+	_, _, line, _ := runtime.Caller(0)
+	newLogtor.Infof("shortcut %d", 1)
+	newLogtor.LogItf(types.INFO, "generic %d", 2)
+	newLogtor.InfofWithCallDepth(3, "with depth %d", 3)
+	newLogtor.ChangeLogCreator("Legacy")
+	newLogtor.Infof("legacy %d", 4)
+
+	for name, lines := range map[string][]int{fileName: {line + 1, line + 2, line + 3}, filepath.Join(filepath.Dir(fileName), "legacy.log"): {line + 5}} {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		output := string(content)
+		for _, expected := range lines {
+			if !strings.Contains(output, fmt.Sprintf("formatted_test.go:%d: ", expected)) {
+				t.Errorf("%s should attribute an entry to line %d: %s", filepath.Base(name), expected, output)
+			}
+		}
+	}
+}
+
+// newFileCreator creates a file creator named Legacy attributing LogIt entries to the caller of Logtor.
+func newFileCreator(t *testing.T, fileName string) logtor.LogCreator {
+	t.Helper()
+	fileCreator, err := creators.NewFileCreatorWithOptions(fileName, creators.WithName("Legacy"), creators.WithCallDepth(4))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fileCreator
+}
+
+func TestLogtorLogItfUsesDefaultCreator(t *testing.T) {
+	primary, fallback := newRecordingCreator("Primary"), newRecordingCreator("Fallback")
+	primary.notReady = true
+	newLogtor := logtor.New().WithDefaultCreator(fallback)
+	newLogtor.AddLogCreators(primary)
+	newLogtor.SetLogLevel(types.INFO)
+
+	if !newLogtor.LogItf(types.WARN, "user %d did %s", 42, "login") {
+		t.Fatal("message not logged")
+	}
+	if messages := fallback.Messages(); len(messages) != 1 || messages[0] != "WARN user 42 did login" || len(primary.Messages()) != 0 {
+		t.Errorf("the default creator should stand in for a log creator that is not ready: %v %v", messages, primary.Messages())
+	}
+}
+
+func TestLogtorLogItfFilteredDoesNotAllocate(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(&discardCreator{name: "Discard"})
+	newLogtor.SetLogLevel(types.INFO)
+	action := "login"
+	allocs := testing.AllocsPerRun(100, func() {
+		newLogtor.LogItf(types.TRACE, "user %d did %s", 42, action)
+	})
+	if allocs != 0 {
+		t.Errorf("a filtered LogItf call should not format or allocate, got %.1f allocations", allocs)
+	}
+}
+
+// BenchmarkLogtorLogItSprintfFiltered measures formatting the message before LogIt, as the baseline for
+// BenchmarkLogtorLogItfFiltered.
+func BenchmarkLogtorLogItSprintfFiltered(b *testing.B) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(&discardCreator{name: "Discard"})
+	newLogtor.SetLogLevel(types.INFO)
+	action := "login"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		newLogtor.LogIt(types.TRACE, fmt.Sprintf("user %d did %s", 42, action))
+	}
+}
+
+func BenchmarkLogtorLogItfFiltered(b *testing.B) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(&discardCreator{name: "Discard"})
+	newLogtor.SetLogLevel(types.INFO)
+	action := "login"
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		newLogtor.LogItf(types.TRACE, "user %d did %s", 42, action)
+	}
+}
@@ -0,0 +1,81 @@
+package logtor
+
+import (
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// SetGlobalFields sets fields, such as the service name, version and environment, that are attached to
+// every subsequent message, from any goroutine and any call site.
+//
+// The fields are merged into every types.LogEntry and ContextualMessage before the pre-log hooks run, and
+// other messages are wrapped in a ContextualMessage carrying them, so every log creator records them: the
+// BrokerCreator in the fields of the BrokerMessage, and the BaseCreator and FileCreator as key=value pairs
+// after the message. Fields of the message itself, such as those added with WithFields, take precedence
+// over global fields with the same key, and the message passed by the caller is never modified. The
+// fields are copied and replace any global fields set earlier; they are swapped atomically, so they can
+// be changed while messages are logged.
+//
+// Parameters:
+//   - fields: The fields to attach. A nil or empty map clears the global fields.
+func (l *Logtor) SetGlobalFields(fields map[string]interface{}) {
+	if len(fields) == 0 {
+		l.globalFields.Store(nil)
+		return
+	}
+	copied := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		copied[key] = value
+	}
+	l.globalFields.Store(&copied)
+}
+
+// ClearGlobalFields removes the global fields set with SetGlobalFields.
+func (l *Logtor) ClearGlobalFields() {
+	l.globalFields.Store(nil)
+}
+
+// GetGlobalFields returns the global fields set with SetGlobalFields.
+//
+// Returns:
+//   - map[string]interface{}: A copy of the global fields, or an empty map if none are set.
+func (l *Logtor) GetGlobalFields() map[string]interface{} {
+	current := l.globalFields.Load()
+	if current == nil {
+		return map[string]interface{}{}
+	}
+	copied := make(map[string]interface{}, len(*current))
+	for key, value := range *current {
+		copied[key] = value
+	}
+	return copied
+}
+
+// mergeGlobalFields returns the message with the global fields merged into a copy of its fields, if it
+// is a types.LogEntry or a ContextualMessage, and wrapped in a ContextualMessage carrying them otherwise.
+func (l *Logtor) mergeGlobalFields(logMessage interface{}) interface{} {
+	current := l.globalFields.Load()
+	if current == nil {
+		return logMessage
+	}
+	switch typed := logMessage.(type) {
+	case types.LogEntry:
+		typed.Fields = withGlobalFields(*current, typed.Fields)
+		return typed
+	case ContextualMessage:
+		typed.Fields = withGlobalFields(*current, typed.Fields)
+		return typed
+	}
+	return ContextualMessage{Message: logMessage, Fields: withGlobalFields(*current, nil)}
+}
+
+// withGlobalFields returns a new map with the global fields overridden by the fields of a message.
+func withGlobalFields(global, fields map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(global)+len(fields))
+	for key, value := range global {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return merged
+}
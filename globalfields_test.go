@@ -0,0 +1,99 @@
+package logtor_test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorGlobalFields(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.INFO)
+
+	fields := map[string]interface{}{"job_id": "abc123"}
+	newLogtor.SetGlobalFields(fields)
+	fields["job_id"] = "changed"
+
+	entryFields := map[string]interface{}{"step": 2}
+	newLogtor.LogIt(types.INFO, types.LogEntry{Message: "step done", Fields: entryFields})
+	newLogtor.LogIt(types.INFO, logtor.ContextualMessage{Message: "override", Fields: map[string]interface{}{"job_id": "local"}})
+	newLogtor.LogIt(types.INFO, "plain")
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		newLogtor.LogIt(types.INFO, types.LogEntry{Message: "from goroutine"})
+	}()
+	wg.Wait()
+	newLogtor.WithFields(map[string]interface{}{"job_id": "call", "user": "u1"}).LogIt(types.INFO, "per call")
+
+	newLogtor.ClearGlobalFields()
+	newLogtor.LogIt(types.INFO, types.LogEntry{Message: "cleared"})
+
+	expected := []string{
+		"INFO step done job_id=abc123 step=2",
+		"INFO override job_id=local",
+		"INFO plain job_id=abc123",
+		"INFO from goroutine job_id=abc123",
+		"INFO per call job_id=call user=u1",
+		"INFO cleared",
+	}
+	if messages := recorder.Messages(); fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("unexpected messages:\n got %q\nwant %q", messages, expected)
+	}
+	if len(entryFields) != 1 {
+		t.Errorf("the caller's fields should not be modified: %v", entryFields)
+	}
+}
+
+func TestLogtorGetGlobalFields(t *testing.T) {
+	newLogtor := logtor.New()
+	if fields := newLogtor.GetGlobalFields(); fields == nil || len(fields) != 0 {
+		t.Errorf("expected an empty map, got %v", fields)
+	}
+
+	newLogtor.SetGlobalFields(map[string]interface{}{"job_id": "abc123"})
+	fields := newLogtor.GetGlobalFields()
+	fields["job_id"] = "changed"
+	if newLogtor.GetGlobalFields()["job_id"] != "abc123" {
+		t.Error("GetGlobalFields should return a copy")
+	}
+
+	newLogtor.SetGlobalFields(nil)
+	if fields := newLogtor.GetGlobalFields(); len(fields) != 0 {
+		t.Errorf("a nil map should clear the global fields, got %v", fields)
+	}
+}
+
+func TestLogtorSetGlobalFieldsWhileLogging(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.INFO)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			newLogtor.SetGlobalFields(map[string]interface{}{"version": i})
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		newLogtor.LogIt(types.INFO, "message")
+	}
+	wg.Wait()
+
+	for _, message := range recorder.Messages() {
+		if message != "INFO message" && !strings.HasPrefix(message, "INFO message version=") {
+			t.Errorf("unexpected message: %q", message)
+		}
+	}
+}
@@ -2,55 +2,76 @@ package logtor
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
+	"sort"
+	"strconv"
 
 	"github.com/Eyup-Devop/logtor/types"
 )
 
 func (l *Logtor) GetLogCreatorList(w http.ResponseWriter, r *http.Request) {
-	result := []string{}
 	l.changeMutex.RLock()
-	defer l.changeMutex.RUnlock()
+	names := make([]string, 0, len(l.logCreatorList))
 	for k := range l.logCreatorList {
-		result = append(result, string(k))
+		names = append(names, string(k))
+	}
+	l.changeMutex.RUnlock()
+	sort.Strings(names)
+
+	var result interface{} = names
+	if detailed, _ := strconv.ParseBool(r.URL.Query().Get("detailed")); detailed {
+		active := ""
+		if currentLogCreator := l.LogCreator(); currentLogCreator != nil {
+			active = string(currentLogCreator.LogName())
+		}
+		type creatorEntry struct {
+			Name   string `json:"name"`
+			Active bool   `json:"active"`
+		}
+		entries := make([]creatorEntry, 0, len(names))
+		for _, name := range names {
+			entries = append(entries, creatorEntry{Name: name, Active: name == active})
+		}
+		result = entries
 	}
 	jsonResult, err := json.Marshal(result)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
 	w.Write(jsonResult)
 }
 
 func (l *Logtor) GetCurrentLogCreator(w http.ResponseWriter, r *http.Request) {
-	l.changeMutex.RLock()
-	defer l.changeMutex.RUnlock()
-	if len(l.logCreatorList) == 0 {
+	currentLogCreator := l.LogCreator()
+	if currentLogCreator == nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
 	result := struct {
-		CurrentLogCreator string `json:"current_log_creator"`
+		CurrentLogCreator string                 `json:"current_log_creator"`
+		Fallbacks         []types.LogCreatorName `json:"fallbacks,omitempty"`
 	}{
-		CurrentLogCreator: string(l.currentLogCreator.LogName()),
+		CurrentLogCreator: string(currentLogCreator.LogName()),
+		Fallbacks:         l.Fallbacks(),
 	}
 	jsonResult, err := json.Marshal(result)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
 	w.Write(jsonResult)
 }
 
 func (l *Logtor) ChangeActiveLogCreator(w http.ResponseWriter, r *http.Request) {
-	l.changeMutex.RLock()
-	defer l.changeMutex.RUnlock()
-	if len(l.logCreatorList) == 0 {
+	oldCreator := l.LogCreator()
+	if oldCreator == nil {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
@@ -65,16 +86,15 @@ func (l *Logtor) ChangeActiveLogCreator(w http.ResponseWriter, r *http.Request)
 		w.WriteHeader(http.StatusBadRequest)
 		return
 	}
-	oldLogCreator := string(l.currentLogCreator.LogName())
+	oldLogCreator := string(oldCreator.LogName())
 	var currentLogCreator string
 	if v, ok := payload["log_creator"]; ok {
-		l.changeMutex.RUnlock()
-		if l.ChangeLogCreator(types.LogCreatorName(v)) {
+		if previous, err := l.SwapLogCreator(types.LogCreatorName(v)); err == nil {
+			oldLogCreator = string(previous)
 			currentLogCreator = v
 		} else {
 			currentLogCreator = oldLogCreator
 		}
-		l.changeMutex.RLock()
 	}
 
 	result := struct {
@@ -89,13 +109,20 @@ func (l *Logtor) ChangeActiveLogCreator(w http.ResponseWriter, r *http.Request)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
 	w.Write(jsonResult)
 }
 
+// hasLogCreators reports whether any log creator is registered.
+func (l *Logtor) hasLogCreators() bool {
+	l.changeMutex.RLock()
+	defer l.changeMutex.RUnlock()
+	return len(l.logCreatorList) > 0
+}
+
 func (l *Logtor) GetLogLevelList(w http.ResponseWriter, r *http.Request) {
-	jsonResult, err := json.Marshal(types.LogLevelList)
+	jsonResult, err := json.Marshal(types.LogLevels())
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
@@ -105,9 +132,7 @@ func (l *Logtor) GetLogLevelList(w http.ResponseWriter, r *http.Request) {
 }
 
 func (l *Logtor) GetActiveLogLevel(w http.ResponseWriter, r *http.Request) {
-	l.changeMutex.RLock()
-	defer l.changeMutex.RUnlock()
-	if len(l.logCreatorList) == 0 {
+	if !l.hasLogCreators() {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
@@ -121,21 +146,19 @@ func (l *Logtor) GetActiveLogLevel(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
 	w.Write(jsonResult)
 }
 
 func (l *Logtor) SetLogLevelHandlerFunc(w http.ResponseWriter, r *http.Request) {
-	l.changeMutex.RLock()
-	if len(l.logCreatorList) == 0 {
+	if !l.hasLogCreators() {
 		w.WriteHeader(http.StatusNotFound)
 		return
 	}
 
 	var oldLogLevel string = string(l.LogLevel())
 
-	l.changeMutex.RUnlock()
 	if r.Method != http.MethodPost {
 		w.WriteHeader(http.StatusBadRequest)
 		return
@@ -166,7 +189,471 @@ func (l *Logtor) SetLogLevelHandlerFunc(w http.ResponseWriter, r *http.Request)
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResult)
+}
+
+func (l *Logtor) GetLogCreatorStatus(w http.ResponseWriter, r *http.Request) {
+	type creatorStatus struct {
+		Name     string                 `json:"name"`
+		Active   bool                   `json:"active"`
+		Ready    bool                   `json:"ready"`
+		Settings map[string]interface{} `json:"settings,omitempty"`
+		Chain    []string               `json:"chain,omitempty"`
+		Routing  RoutingState           `json:"routing,omitempty"`
+	}
+
+	currentLogCreator := l.LogCreator()
+	l.changeMutex.RLock()
+	result := make([]creatorStatus, 0, len(l.logCreatorList))
+	for name, logCreator := range l.logCreatorList {
+		status := creatorStatus{
+			Name:   string(name),
+			Active: sameCreator(logCreator, currentLogCreator),
+			Ready:  logCreator.IsReady(),
+			Chain:  l.creatorChain(name),
+		}
+		if status.Active {
+			status.Routing = l.RoutingState()
+		}
+		if describer, ok := logCreator.(CreatorDescriber); ok {
+			status.Settings = describer.Describe()
+		}
+		result = append(result, status)
+	}
+	l.changeMutex.RUnlock()
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
 	w.Write(jsonResult)
 }
+
+func (l *Logtor) AddLogCreator(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var payload CreatorConfig
+	err := json.NewDecoder(r.Body).Decode(&payload)
+	if err != nil || payload.Name == "" || payload.Type == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	settings := make(map[string]interface{}, len(payload.Settings)+1)
+	for k, v := range payload.Settings {
+		settings[k] = v
+	}
+	settings["name"] = string(payload.Name)
+	logCreator, err := BuildCreator(payload.Type, settings)
+	if err != nil {
+		result := struct {
+			Error string `json:"error"`
+		}{
+			Error: err.Error(),
+		}
+		jsonResult, _ := json.Marshal(result)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write(jsonResult)
+		return
+	}
+	if _, errs := l.TryAddLogCreators(logCreator); len(errs) > 0 {
+		logCreator.Shutdown()
+		status := http.StatusBadRequest
+		if errors.Is(errs[0], ErrLogCreatorExists) {
+			status = http.StatusConflict
+		}
+		jsonResult, _ := json.Marshal(struct {
+			Error string `json:"error"`
+		}{
+			Error: errs[0].Error(),
+		})
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(jsonResult)
+		return
+	}
+
+	result := struct {
+		LogCreator string `json:"log_creator"`
+	}{
+		LogCreator: string(logCreator.LogName()),
+	}
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResult)
+}
+
+func (l *Logtor) BrokerMetricsHandler(w http.ResponseWriter, r *http.Request) {
+	type brokerMetrics struct {
+		InputChannelUsage float64 `json:"input_channel_usage"`
+	}
+
+	result := map[string]brokerMetrics{}
+	l.changeMutex.RLock()
+	for name, logCreator := range l.logCreatorList {
+		if reporter, ok := logCreator.(InputChannelReporter); ok {
+			result[string(name)] = brokerMetrics{InputChannelUsage: reporter.InputChannelUsage()}
+		}
+	}
+	l.changeMutex.RUnlock()
+
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResult)
+}
+
+func (l *Logtor) StatsHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResult, err := json.Marshal(l.Stats())
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResult)
+}
+
+func (l *Logtor) ValidateConfigHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	status := http.StatusOK
+	var issues []ConfigIssue
+	body, err := io.ReadAll(r.Body)
+	if err == nil {
+		var cfg Config
+		cfg, err = parseConfig(body)
+		if err == nil {
+			issues = ValidateConfig(cfg)
+		}
+	}
+	if err != nil {
+		status = http.StatusBadRequest
+		issues = []ConfigIssue{{Severity: SeverityError, Path: "$", Message: err.Error()}}
+	}
+
+	jsonResult, err := json.Marshal(issues)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(jsonResult)
+}
+
+func (l *Logtor) HealthCheckHandler(w http.ResponseWriter, r *http.Request) {
+	type creatorHealth struct {
+		Name        string `json:"name"`
+		Active      bool   `json:"active"`
+		Ready       bool   `json:"ready"`
+		Type        string `json:"type,omitempty"`
+		Version     string `json:"version,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+
+	result := struct {
+		Status        string          `json:"status"`
+		LogLevel      types.LogLevel  `json:"log_level"`
+		ActiveCreator string          `json:"active_creator"`
+		Creators      []creatorHealth `json:"creators"`
+	}{
+		LogLevel: l.LogLevel(),
+	}
+
+	status := http.StatusServiceUnavailable
+	result.Status = "unavailable"
+	currentLogCreator := l.LogCreator()
+	if currentLogCreator != nil {
+		result.ActiveCreator = string(currentLogCreator.LogName())
+		if currentLogCreator.IsReady() {
+			status = http.StatusOK
+			result.Status = "ok"
+		}
+	}
+	l.changeMutex.RLock()
+	result.Creators = make([]creatorHealth, 0, len(l.logCreatorList))
+	for name, logCreator := range l.logCreatorList {
+		health := creatorHealth{
+			Name:   string(name),
+			Active: sameCreator(logCreator, currentLogCreator),
+			Ready:  logCreator.IsReady(),
+		}
+		if metadata, ok := logCreator.(CreatorMetadata); ok {
+			health.Type = metadata.CreatorType()
+			health.Version = metadata.CreatorVersion()
+			health.Description = metadata.CreatorDescription()
+		}
+		result.Creators = append(result.Creators, health)
+	}
+	l.changeMutex.RUnlock()
+	sort.Slice(result.Creators, func(i, j int) bool { return result.Creators[i].Name < result.Creators[j].Name })
+
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(jsonResult)
+}
+
+func (l *Logtor) FlushCreatorHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var payload struct {
+		Creator types.LogCreatorName `json:"creator"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Creator == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	l.changeMutex.RLock()
+	logCreator, ok := l.logCreatorList[payload.Creator]
+	l.changeMutex.RUnlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	result := struct {
+		Creator string  `json:"creator"`
+		Success bool    `json:"success"`
+		Error   *string `json:"error"`
+	}{
+		Creator: string(payload.Creator),
+		Success: true,
+	}
+	if flusher, ok := logCreator.(Flusher); ok {
+		if err := flusher.Flush(); err != nil {
+			message := err.Error()
+			result.Success = false
+			result.Error = &message
+		}
+	}
+
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResult)
+}
+
+func (l *Logtor) GetCreatorLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	name := types.LogCreatorName(r.URL.Query().Get("creator"))
+	if name == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	l.changeMutex.RLock()
+	_, ok := l.logCreatorList[name]
+	l.changeMutex.RUnlock()
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	level, ok := l.GetCreatorLogLevel(name)
+	if !ok {
+		level = l.LogLevel()
+	}
+
+	l.writeCreatorLogLevel(w, name, level)
+}
+
+func (l *Logtor) SetCreatorLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var payload struct {
+		Creator  types.LogCreatorName `json:"creator"`
+		LogLevel types.LogLevel       `json:"log_level"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Creator == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if payload.LogLevel == "" {
+		l.changeMutex.RLock()
+		_, ok := l.logCreatorList[payload.Creator]
+		l.changeMutex.RUnlock()
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		l.ClearCreatorLogLevel(payload.Creator)
+		l.writeCreatorLogLevel(w, payload.Creator, l.LogLevel())
+		return
+	}
+	if !payload.LogLevel.IsValid() {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !l.SetCreatorLogLevel(payload.Creator, payload.LogLevel) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	l.writeCreatorLogLevel(w, payload.Creator, payload.LogLevel)
+}
+
+// writeCreatorLogLevel writes the response of the creator log level handlers.
+func (l *Logtor) writeCreatorLogLevel(w http.ResponseWriter, name types.LogCreatorName, level types.LogLevel) {
+	result := struct {
+		Creator  string `json:"creator"`
+		LogLevel string `json:"log_level"`
+	}{
+		Creator:  string(name),
+		LogLevel: string(level),
+	}
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResult)
+}
+
+func (l *Logtor) GetNamespaceLogLevelsHandler(w http.ResponseWriter, r *http.Request) {
+	type namespaceLevel struct {
+		Namespace string `json:"namespace"`
+		LogLevel  string `json:"log_level"`
+	}
+
+	levels := l.NamespaceLogLevels()
+	result := make([]namespaceLevel, 0, len(levels))
+	for namespace, level := range levels {
+		result = append(result, namespaceLevel{Namespace: namespace, LogLevel: string(level)})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Namespace < result[j].Namespace })
+
+	jsonResult, err := json.Marshal(result)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(jsonResult)
+}
+
+func (l *Logtor) SetNamespaceLogLevelHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var payload struct {
+		Namespace string         `json:"namespace"`
+		LogLevel  types.LogLevel `json:"log_level"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil || payload.Namespace == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if payload.LogLevel == "" {
+		l.ClearLogLevelFor(payload.Namespace)
+	} else if !l.SetLogLevelFor(payload.Namespace, payload.LogLevel) {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	l.GetNamespaceLogLevelsHandler(w, r)
+}
+
+// ServeHTTP serves the admin handlers, so a Logtor can be mounted on any mux:
+//
+//	http.Handle("/admin/log/", http.StripPrefix("/admin/log", l))
+//
+// The routes are relative to "/":
+//   - GET /log-creators: GetLogCreatorList, with ?detailed=true for the active flag of each log creator
+//   - GET /log-creators/current: GetCurrentLogCreator
+//   - POST /log-creators/active: ChangeActiveLogCreator
+//   - GET /log-creators/status: GetLogCreatorStatus
+//   - POST /log-creators/add: AddLogCreator
+//   - GET /log-levels: GetLogLevelList
+//   - GET /log-level: GetActiveLogLevel
+//   - POST /log-level: SetLogLevelHandlerFunc
+//   - GET /broker-metrics: BrokerMetricsHandler
+//   - GET /stats: StatsHandler
+//   - POST /config/validate: ValidateConfigHandler
+//   - GET /health: HealthCheckHandler
+//   - POST /flush-creator: FlushCreatorHandler
+//   - GET /creator-log-level?creator=<name>: GetCreatorLogLevelHandler
+//   - POST /creator-log-level: SetCreatorLogLevelHandler, clearing the level of a log creator for an empty log_level
+//   - GET /namespace-log-levels: GetNamespaceLogLevelsHandler
+//   - POST /namespace-log-levels: SetNamespaceLogLevelHandler, clearing the level of a namespace for an empty log_level
+//
+// The routes are registered on an internal mux the first time ServeHTTP is called.
+func (l *Logtor) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	l.adminMuxOnce.Do(func() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/log-creators", l.GetLogCreatorList)
+		mux.HandleFunc("/log-creators/current", l.GetCurrentLogCreator)
+		mux.HandleFunc("/log-creators/active", l.ChangeActiveLogCreator)
+		mux.HandleFunc("/log-creators/status", l.GetLogCreatorStatus)
+		mux.HandleFunc("/log-creators/add", l.AddLogCreator)
+		mux.HandleFunc("/log-levels", l.GetLogLevelList)
+		mux.HandleFunc("/log-level", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				l.SetLogLevelHandlerFunc(w, r)
+				return
+			}
+			l.GetActiveLogLevel(w, r)
+		})
+		mux.HandleFunc("/broker-metrics", l.BrokerMetricsHandler)
+		mux.HandleFunc("/stats", l.StatsHandler)
+		mux.HandleFunc("/config/validate", l.ValidateConfigHandler)
+		mux.HandleFunc("/health", l.HealthCheckHandler)
+		mux.HandleFunc("/flush-creator", l.FlushCreatorHandler)
+		mux.HandleFunc("/creator-log-level", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				l.SetCreatorLogLevelHandler(w, r)
+				return
+			}
+			l.GetCreatorLogLevelHandler(w, r)
+		})
+		mux.HandleFunc("/namespace-log-levels", func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost {
+				l.SetNamespaceLogLevelHandler(w, r)
+				return
+			}
+			l.GetNamespaceLogLevelsHandler(w, r)
+		})
+		l.adminMux = mux
+	})
+	l.adminMux.ServeHTTP(w, r)
+}
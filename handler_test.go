@@ -3,13 +3,19 @@ package logtor_test
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/Eyup-Devop/logtor"
 	"github.com/Eyup-Devop/logtor/creators"
 	"github.com/Eyup-Devop/logtor/types"
+	"github.com/IBM/sarama/mocks"
 )
 
 func TestGetLogCreatorListHandlerFunc(t *testing.T) {
@@ -56,6 +62,39 @@ func TestGetLogCreatorListHandlerFunc(t *testing.T) {
 	}
 }
 
+func TestGetLogCreatorListIsSorted(t *testing.T) {
+	getList := func(target string, names ...types.LogCreatorName) string {
+		newLogtor := logtor.New()
+		for _, name := range names {
+			newLogtor.AddLogCreators(newRecordingCreator(name))
+		}
+		newLogtor.ChangeLogCreator("Console")
+		rw := httptest.NewRecorder()
+		newLogtor.GetLogCreatorList(rw, httptest.NewRequest(http.MethodGet, target, nil))
+		if contentType := rw.Header().Get("Content-Type"); contentType != "application/json" {
+			t.Errorf("unexpected Content-Type: %q", contentType)
+		}
+		return rw.Body.String()
+	}
+
+	plain := getList("/log-creators", "File", "Console", "Broker")
+	if plain != `["Broker","Console","File"]` {
+		t.Errorf("unexpected list: %s", plain)
+	}
+	if other := getList("/log-creators", "Console", "Broker", "File"); other != plain {
+		t.Errorf("responses differ by registration order: %s and %s", plain, other)
+	}
+
+	detailed := getList("/log-creators?detailed=true", "Broker", "File", "Console")
+	expected := `[{"name":"Broker","active":false},{"name":"Console","active":true},{"name":"File","active":false}]`
+	if detailed != expected {
+		t.Errorf("unexpected detailed list: %s", detailed)
+	}
+	if other := getList("/log-creators?detailed=true", "File", "Console", "Broker"); other != detailed {
+		t.Errorf("detailed responses differ by registration order: %s and %s", detailed, other)
+	}
+}
+
 func TestGetCurrentLogCreatorHandlerFunc(t *testing.T) {
 	baseCreator, err := creators.NewBaseCreator("Console", 3, 5)
 	if err != nil {
@@ -287,3 +326,455 @@ func TestSetLogLevelHandlerFunc(t *testing.T) {
 			rw.Body.String(), expected)
 	}
 }
+
+func TestGetLogCreatorStatus(t *testing.T) {
+	baseCreator, err := creators.NewBaseCreator("Console", 3, 5)
+	if err != nil {
+		t.Error(err)
+	}
+	fileCreator, err := creators.NewFileCreator(filepath.Join(t.TempDir(), "temp.log"), "File", 3, 5)
+	if err != nil {
+		t.Error(err)
+	}
+
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(baseCreator, fileCreator)
+
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw := httptest.NewRecorder()
+	newLogtor.GetLogCreatorStatus(rw, req)
+
+	if status := rw.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+
+	var response []struct {
+		Name     string                 `json:"name"`
+		Active   bool                   `json:"active"`
+		Ready    bool                   `json:"ready"`
+		Settings map[string]interface{} `json:"settings"`
+	}
+	if err := json.NewDecoder(rw.Body).Decode(&response); err != nil {
+		t.Fatalf("handler returned not json data: %v", err)
+	}
+	if len(response) != 2 || response[0].Name != "Console" || response[1].Name != "File" {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+	if !response[0].Active || response[1].Active || !response[0].Ready {
+		t.Errorf("unexpected active/ready flags: %+v", response)
+	}
+	if response[1].Settings["type"] != "file" {
+		t.Errorf("missing creator settings: %+v", response[1].Settings)
+	}
+}
+
+func TestAddLogCreator(t *testing.T) {
+	newLogtor := logtor.New()
+
+	payload := `{"name":"Audit","type":"file","settings":{"file_name":"` + filepath.Join(t.TempDir(), "audit.log") + `","prefix_width":5}}`
+	req, err := http.NewRequest("POST", "/", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw := httptest.NewRecorder()
+	newLogtor.AddLogCreator(rw, req)
+
+	if status := rw.Code; status != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v: %s",
+			status, http.StatusOK, rw.Body.String())
+	}
+	expected := `{"log_creator":"Audit"}`
+	if rw.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rw.Body.String(), expected)
+	}
+	if newLogtor.LogCreator() == nil || newLogtor.LogCreator().LogName() != "Audit" {
+		t.Error("creator was not registered")
+	}
+
+	req, _ = http.NewRequest("POST", "/", bytes.NewBufferString(payload))
+	rw = httptest.NewRecorder()
+	newLogtor.AddLogCreator(rw, req)
+	if status := rw.Code; status != http.StatusConflict {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusConflict)
+	}
+
+	req, _ = http.NewRequest("POST", "/", bytes.NewBufferString(`{"name":"Other","type":"console","settings":{"colour":true}}`))
+	rw = httptest.NewRecorder()
+	newLogtor.AddLogCreator(rw, req)
+	if status := rw.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusBadRequest)
+	}
+	if !strings.Contains(rw.Body.String(), `unknown setting \"colour\"`) {
+		t.Errorf("handler returned unexpected body: %v", rw.Body.String())
+	}
+}
+
+func TestAddLogCreatorDuplicateIsShutDown(t *testing.T) {
+	newLogtor := logtor.New()
+	payload := `{"name":"HandlerDuplicate","type":"recording"}`
+
+	req, _ := http.NewRequest("POST", "/", bytes.NewBufferString(payload))
+	rw := httptest.NewRecorder()
+	newLogtor.AddLogCreator(rw, req)
+	if rw.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rw.Code, http.StatusOK)
+	}
+	registered := recordedCreator("HandlerDuplicate")
+
+	req, _ = http.NewRequest("POST", "/", bytes.NewBufferString(payload))
+	rw = httptest.NewRecorder()
+	newLogtor.AddLogCreator(rw, req)
+	if rw.Code != http.StatusConflict {
+		t.Errorf("handler returned wrong status code: got %v want %v", rw.Code, http.StatusConflict)
+	}
+	if !strings.Contains(rw.Body.String(), "already registered") {
+		t.Errorf("handler returned unexpected body: %v", rw.Body.String())
+	}
+	rejected := recordedCreator("HandlerDuplicate")
+	if rejected == registered || rejected.Shutdowns() != 1 {
+		t.Errorf("the rejected creator should be shut down, got %d shutdowns", rejected.Shutdowns())
+	}
+	if registered.Shutdowns() != 0 || newLogtor.LogCreator() != logtor.LogCreator(registered) {
+		t.Error("the registered creator should stay active")
+	}
+}
+
+func TestBrokerMetricsHandler(t *testing.T) {
+	baseCreator, err := creators.NewBaseCreator("Console", 3, 5)
+	if err != nil {
+		t.Error(err)
+	}
+	brokerCreator, err := creators.NewBrokerCreatorWithProducer(mocks.NewAsyncProducer(t, nil), "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer brokerCreator.Shutdown()
+
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(baseCreator, brokerCreator)
+
+	req, err := http.NewRequest("GET", "/", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw := httptest.NewRecorder()
+	newLogtor.BrokerMetricsHandler(rw, req)
+
+	if status := rw.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	expected := `{"Broker":{"input_channel_usage":0}}`
+	if rw.Body.String() != expected {
+		t.Errorf("handler returned unexpected body: got %v want %v",
+			rw.Body.String(), expected)
+	}
+}
+
+func TestLogtorServeHTTP(t *testing.T) {
+	baseCreator, err := creators.NewBaseCreator("Console", 3, 5)
+	if err != nil {
+		t.Error(err)
+	}
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(baseCreator)
+	newLogtor.SetLogLevel(types.INFO)
+
+	mux := http.NewServeMux()
+	mux.Handle("/admin/log/", http.StripPrefix("/admin/log", newLogtor))
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	response, err := http.Get(server.URL + "/admin/log/log-creators/current")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(response.Body)
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK || string(body) != `{"current_log_creator":"Console"}` {
+		t.Errorf("unexpected response: %d %s", response.StatusCode, body)
+	}
+
+	response, err = http.Post(server.URL+"/admin/log/log-level", "text/plain", strings.NewReader("ERROR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK || newLogtor.LogLevel() != types.ERROR {
+		t.Errorf("log level not changed: %d %s", response.StatusCode, newLogtor.LogLevel())
+	}
+
+	response, err = http.Get(server.URL + "/admin/log/log-level")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ = io.ReadAll(response.Body)
+	response.Body.Close()
+	if string(body) != `{"log_level":"ERROR"}` {
+		t.Errorf("unexpected response: %s", body)
+	}
+
+	response, err = http.Get(server.URL + "/admin/log/unknown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusNotFound {
+		t.Errorf("unexpected status for unknown route: %d", response.StatusCode)
+	}
+}
+
+func TestHealthCheckHandler(t *testing.T) {
+	baseCreator, err := creators.NewBaseCreator("Console", 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileCreator, err := creators.NewFileCreator(filepath.Join(t.TempDir(), "temp.log"), "File", 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newLogtor := logtor.New()
+	rw := httptest.NewRecorder()
+	newLogtor.HealthCheckHandler(rw, httptest.NewRequest("GET", "/health", nil))
+	if rw.Code != http.StatusServiceUnavailable {
+		t.Errorf("handler without creators returned %v, want %v", rw.Code, http.StatusServiceUnavailable)
+	}
+
+	newLogtor.AddLogCreators(baseCreator, fileCreator)
+	metadata, ok := newLogtor.CreatorMetadataByName("File")
+	if !ok || metadata.CreatorType() != "file" {
+		t.Errorf("unexpected metadata for File: %v %v", metadata, ok)
+	}
+	if _, ok := newLogtor.CreatorMetadataByName("Missing"); ok {
+		t.Error("metadata reported for an unknown creator")
+	}
+
+	rw = httptest.NewRecorder()
+	newLogtor.ServeHTTP(rw, httptest.NewRequest("GET", "/health", nil))
+	if rw.Code != http.StatusOK {
+		t.Fatalf("handler returned wrong status code: got %v want %v", rw.Code, http.StatusOK)
+	}
+
+	var response struct {
+		Status        string `json:"status"`
+		ActiveCreator string `json:"active_creator"`
+		Creators      []struct {
+			Name        string `json:"name"`
+			Active      bool   `json:"active"`
+			Type        string `json:"type"`
+			Version     string `json:"version"`
+			Description string `json:"description"`
+		} `json:"creators"`
+	}
+	if err := json.NewDecoder(rw.Body).Decode(&response); err != nil {
+		t.Fatalf("handler returned not json data: %v", err)
+	}
+	if response.Status != "ok" || response.ActiveCreator != "Console" || len(response.Creators) != 2 {
+		t.Fatalf("unexpected response: %+v", response)
+	}
+	if response.Creators[0].Type != "console" || response.Creators[1].Type != "file" {
+		t.Errorf("unexpected creator types: %+v", response.Creators)
+	}
+	if response.Creators[1].Version == "" || !strings.Contains(response.Creators[1].Description, "temp.log") {
+		t.Errorf("missing creator metadata: %+v", response.Creators[1])
+	}
+}
+
+// flushingCreator is a recordingCreator that buffers entries until Flush is called.
+type flushingCreator struct {
+	*recordingCreator
+	flushes  int
+	flushErr error
+}
+
+func (fc *flushingCreator) Flush() error {
+	fc.flushes++
+	return fc.flushErr
+}
+
+func TestFlushCreatorHandler(t *testing.T) {
+	buffered := &flushingCreator{recordingCreator: newRecordingCreator("Buffered"), flushErr: errors.New("upload failed")}
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(newRecordingCreator("Plain"), buffered)
+
+	flush := func(body string) (int, map[string]interface{}) {
+		rw := httptest.NewRecorder()
+		newLogtor.ServeHTTP(rw, httptest.NewRequest("POST", "/flush-creator", strings.NewReader(body)))
+		var response map[string]interface{}
+		json.NewDecoder(rw.Body).Decode(&response)
+		return rw.Code, response
+	}
+
+	if status, _ := flush(`{"creator":"Missing"}`); status != http.StatusNotFound {
+		t.Errorf("unknown creator returned %v, want %v", status, http.StatusNotFound)
+	}
+	if status, _ := flush(`{}`); status != http.StatusBadRequest {
+		t.Errorf("missing creator returned %v, want %v", status, http.StatusBadRequest)
+	}
+
+	status, response := flush(`{"creator":"Plain"}`)
+	if status != http.StatusOK || response["success"] != true || response["error"] != nil {
+		t.Errorf("non-flushable creator: %v %v", status, response)
+	}
+
+	status, response = flush(`{"creator":"Buffered"}`)
+	if status != http.StatusOK || response["creator"] != "Buffered" || response["success"] != false || response["error"] != "upload failed" {
+		t.Errorf("failing flush: %v %v", status, response)
+	}
+	if buffered.flushes != 1 {
+		t.Errorf("Flush called %d times, want 1", buffered.flushes)
+	}
+}
+
+func TestHandlersWithConcurrentLogLevelChanges(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(newRecordingCreator("Console"), newRecordingCreator("File"))
+
+	levels := []types.LogLevel{types.ERROR, types.INFO, types.TRACE, types.WARN}
+	requests := []func() *http.Request{
+		func() *http.Request { return httptest.NewRequest(http.MethodGet, "/log-level", nil) },
+		func() *http.Request {
+			return httptest.NewRequest(http.MethodPost, "/log-level", strings.NewReader(string(types.DEBUG)))
+		},
+		func() *http.Request { return httptest.NewRequest(http.MethodGet, "/log-creators", nil) },
+		func() *http.Request { return httptest.NewRequest(http.MethodGet, "/health", nil) },
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(3)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				newLogtor.SetLogLevel(levels[(g+i)%len(levels)])
+				if !newLogtor.LogLevel().IsValid() {
+					t.Error("LogLevel returned an invalid level")
+					return
+				}
+			}
+		}(g)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				newLogtor.LogIt(types.INFO, i)
+			}
+		}()
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				rw := httptest.NewRecorder()
+				newLogtor.ServeHTTP(rw, requests[(g+i)%len(requests)]())
+				if rw.Code != http.StatusOK {
+					t.Errorf("unexpected status %d", rw.Code)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestCreatorLogLevelHandlers(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(newRecordingCreator("Console"), newRecordingCreator("File"))
+	newLogtor.SetLogLevel(types.WARN)
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		rw := httptest.NewRecorder()
+		newLogtor.ServeHTTP(rw, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return rw
+	}
+
+	if rw := serve(http.MethodGet, "/creator-log-level?creator=File", ""); rw.Body.String() != `{"creator":"File","log_level":"WARN"}` {
+		t.Errorf("without an override the global level should be reported: %d %s", rw.Code, rw.Body.String())
+	}
+	if rw := serve(http.MethodPost, "/creator-log-level", `{"creator":"File","log_level":"ERROR"}`); rw.Code != http.StatusOK {
+		t.Errorf("unexpected status %d", rw.Code)
+	}
+	if rw := serve(http.MethodGet, "/creator-log-level?creator=File", ""); rw.Body.String() != `{"creator":"File","log_level":"ERROR"}` {
+		t.Errorf("the override should be reported: %s", rw.Body.String())
+	}
+	if level, ok := newLogtor.GetCreatorLogLevel("File"); !ok || level != types.ERROR {
+		t.Errorf("the override should be applied, got %s %t", level, ok)
+	}
+
+	for _, tc := range []struct {
+		method, target, body string
+		status               int
+	}{
+		{http.MethodGet, "/creator-log-level", "", http.StatusBadRequest},
+		{http.MethodGet, "/creator-log-level?creator=Missing", "", http.StatusNotFound},
+		{http.MethodPost, "/creator-log-level", `{"creator":"File","log_level":"LOUD"}`, http.StatusBadRequest},
+		{http.MethodPost, "/creator-log-level", `not json`, http.StatusBadRequest},
+		{http.MethodPost, "/creator-log-level", `{"creator":"Missing","log_level":"INFO"}`, http.StatusNotFound},
+		{http.MethodPost, "/creator-log-level", `{"creator":"Missing"}`, http.StatusNotFound},
+	} {
+		if rw := serve(tc.method, tc.target, tc.body); rw.Code != tc.status {
+			t.Errorf("%s %s %s: got status %d want %d", tc.method, tc.target, tc.body, rw.Code, tc.status)
+		}
+	}
+
+	if rw := serve(http.MethodPost, "/creator-log-level", `{"creator":"File"}`); rw.Body.String() != `{"creator":"File","log_level":"WARN"}` {
+		t.Errorf("clearing the override should report the global level: %d %s", rw.Code, rw.Body.String())
+	}
+	if _, ok := newLogtor.GetCreatorLogLevel("File"); ok {
+		t.Error("the override should be cleared")
+	}
+
+	newLogtor.SetCreatorLogLevel("Console", types.TRACE)
+	if err := newLogtor.RemoveLogCreator("Console"); err != nil {
+		t.Fatal(err)
+	}
+	if rw := serve(http.MethodGet, "/creator-log-level?creator=Console", ""); rw.Code != http.StatusNotFound {
+		t.Errorf("a removed log creator should not be found, got status %d", rw.Code)
+	}
+}
+
+// taggedCreator is a LogCreator whose dynamic type is not comparable, so comparing it with == panics.
+type taggedCreator struct {
+	name types.LogCreatorName
+	tags []string
+}
+
+func (tc taggedCreator) LogIt(level types.LogLevel, logMessage interface{}) bool { return true }
+
+func (tc taggedCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	return true
+}
+
+func (tc taggedCreator) LogName() types.LogCreatorName { return tc.name }
+
+func (tc taggedCreator) SetCallDepth(callDepth int) {}
+
+func (tc taggedCreator) CallDepth() int { return 2 }
+
+func (tc taggedCreator) IsReady() bool { return true }
+
+func (tc taggedCreator) Shutdown() {}
+
+func TestStatusHandlersWithNonComparableCreator(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(&discardCreator{name: "Discard"}, taggedCreator{name: "Tagged", tags: []string{"a"}})
+	if !newLogtor.ChangeLogCreator("Tagged") {
+		t.Fatal("could not change to the Tagged log creator")
+	}
+
+	for _, handler := range []http.HandlerFunc{newLogtor.GetLogCreatorStatus, newLogtor.HealthCheckHandler} {
+		rw := httptest.NewRecorder()
+		handler(rw, httptest.NewRequest(http.MethodGet, "/", nil))
+		if rw.Code != http.StatusOK {
+			t.Errorf("handler returned wrong status code: got %v want %v", rw.Code, http.StatusOK)
+		}
+		if !strings.Contains(rw.Body.String(), `"name":"Discard","active":false`) {
+			t.Errorf("Discard should be reported as inactive: %s", rw.Body.String())
+		}
+	}
+}
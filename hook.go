@@ -0,0 +1,156 @@
+package logtor
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// Hook observes and rewrites log entries around the dispatch to a log creator, for cross-cutting
+// concerns such as injecting request IDs, masking personal data or alerting on FATAL messages.
+//
+// Hooks run in the order they were added, only for messages whose level is enabled, after the global
+// fields and the pre-log hooks. While hooks are added, every message is passed to the log creators as a
+// types.LogEntry carrying the fields of the message.
+type Hook interface {
+	// Before is called before the entry is passed to a log creator. It may change the message and the
+	// fields of the entry; changes to the level are ignored. Returning false drops the message, and the
+	// remaining hooks are not called.
+	Before(entry *types.LogEntry) bool
+	// After is called with the entry once it was passed to a log creator, whether or not the log
+	// creator recorded it.
+	After(entry types.LogEntry)
+}
+
+// AddHook adds a hook called around every message logged.
+//
+// Parameters:
+//   - hook: The hook, called after the hooks added earlier. A nil hook is skipped.
+func (l *Logtor) AddHook(hook Hook) {
+	if hook == nil {
+		return
+	}
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	var updated []Hook
+	if current := l.hooks.Load(); current != nil {
+		updated = append(updated, *current...)
+	}
+	updated = append(updated, hook)
+	l.hooks.Store(&updated)
+}
+
+// runBeforeHooks passes the message through the Before method of the hooks as a types.LogEntry and
+// returns the result, or false if a hook dropped it.
+func (l *Logtor) runBeforeHooks(level types.LogLevel, logMessage interface{}) (interface{}, bool) {
+	hooks := l.hooks.Load()
+	if hooks == nil {
+		return logMessage, true
+	}
+	entry := toLogEntry(logMessage, 0)
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = l.now()
+	}
+	for _, hook := range *hooks {
+		entry.Level = level
+		if !hook.Before(&entry) {
+			return nil, false
+		}
+	}
+	entry.Level = level
+	return entry, true
+}
+
+// runAfterHooks passes a message returned by runBeforeHooks to the After method of the hooks. It is
+// deferred before endLog, so the hooks run once the log call has ended and may log themselves. A nil message, for a log call that was dropped, is skipped.
+func (l *Logtor) runAfterHooks(logMessage *interface{}) {
+	hooks := l.hooks.Load()
+	if hooks == nil {
+		return
+	}
+	entry, ok := (*logMessage).(types.LogEntry)
+	if !ok {
+		return
+	}
+	for _, hook := range *hooks {
+		hook.After(entry)
+	}
+}
+
+// NewRegexFilterHook creates a new instance of RegexFilterHook.
+//
+// Parameters:
+//   - pattern: The compiled regular expression matched against the message of every entry.
+//
+// Returns:
+//   - *RegexFilterHook: A pointer to the newly created RegexFilterHook.
+func NewRegexFilterHook(pattern *regexp.Regexp) *RegexFilterHook {
+	return &RegexFilterHook{pattern: pattern}
+}
+
+// RegexFilterHook is a Hook dropping the entries whose message matches a regular expression. Messages
+// that are not strings are matched as rendered with "%+v"; fields are not matched.
+type RegexFilterHook struct {
+	pattern *regexp.Regexp
+}
+
+// Before drops the entry if its message matches the regular expression.
+//
+// Parameters:
+//   - entry: The entry about to be logged.
+//
+// Returns:
+//   - bool: False if the message matches, true otherwise or if the hook has no regular expression.
+func (h *RegexFilterHook) Before(entry *types.LogEntry) bool {
+	if h.pattern == nil {
+		return true
+	}
+	text, ok := entry.Message.(string)
+	if !ok {
+		text = fmt.Sprintf("%+v", entry.Message)
+	}
+	return !h.pattern.MatchString(text)
+}
+
+// After does nothing.
+func (h *RegexFilterHook) After(entry types.LogEntry) {}
+
+// NewHostnameHook creates a new instance of HostnameHook, looking up the hostname once.
+//
+// Returns:
+//   - *HostnameHook: A pointer to the newly created HostnameHook.
+func NewHostnameHook() *HostnameHook {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	return &HostnameHook{hostname: hostname}
+}
+
+// HostnameHook is a Hook adding the "hostname" field to every entry, keeping a hostname the entry
+// already carries.
+type HostnameHook struct {
+	hostname string
+}
+
+// Before adds the hostname to the fields of the entry.
+//
+// Parameters:
+//   - entry: The entry about to be logged.
+//
+// Returns:
+//   - bool: Always true.
+func (h *HostnameHook) Before(entry *types.LogEntry) bool {
+	if entry.Fields == nil {
+		entry.Fields = make(map[string]interface{})
+	}
+	if _, ok := entry.Fields["hostname"]; !ok {
+		entry.Fields["hostname"] = h.hostname
+	}
+	return true
+}
+
+// After does nothing.
+func (h *HostnameHook) After(entry types.LogEntry) {}
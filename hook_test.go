@@ -0,0 +1,148 @@
+package logtor_test
+
+import (
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// orderHook records its calls and stamps a field in Before.
+type orderHook struct {
+	name  string
+	calls *[]string
+	drop  bool
+}
+
+func (h orderHook) Before(entry *types.LogEntry) bool {
+	*h.calls = append(*h.calls, "before "+h.name)
+	entry.Fields[h.name] = true
+	return !h.drop
+}
+
+func (h orderHook) After(entry types.LogEntry) {
+	*h.calls = append(*h.calls, "after "+h.name+" "+string(entry.Level))
+}
+
+func TestLogtorHooks(t *testing.T) {
+	testCreator := logtor.NewTestCreator("test")
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.AddLogCreators(testCreator)
+	var calls []string
+	newLogtor.AddHook(orderHook{name: "first", calls: &calls})
+	newLogtor.AddHook(orderHook{name: "second", calls: &calls})
+	newLogtor.AddHook(nil)
+
+	if !newLogtor.LogIt(types.WARN, "hooked") {
+		t.Fatal("the message should be logged")
+	}
+	newLogtor.LogIt(types.TRACE, "filtered")
+
+	want := []string{"before first", "before second", "after first WARN", "after second WARN"}
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("unexpected hook calls: got %v, want %v", calls, want)
+	}
+	entries := testCreator.Entries()
+	if len(entries) != 1 || entries[0].Message != "hooked" || entries[0].Fields["first"] != true || entries[0].Fields["second"] != true {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
+
+func TestLogtorHookDropsMessage(t *testing.T) {
+	testCreator := logtor.NewTestCreator("test")
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.AddLogCreators(testCreator)
+	var calls []string
+	newLogtor.AddHook(orderHook{name: "dropping", calls: &calls, drop: true})
+	newLogtor.AddHook(orderHook{name: "skipped", calls: &calls})
+
+	if newLogtor.LogIt(types.ERROR, "dropped") {
+		t.Error("a dropped message should not be reported as logged")
+	}
+	if len(calls) != 1 || calls[0] != "before dropping" {
+		t.Errorf("only the dropping hook should be called: %v", calls)
+	}
+	if entries := testCreator.Entries(); len(entries) != 0 {
+		t.Errorf("the message should not reach the log creator: %+v", entries)
+	}
+}
+
+func TestRegexFilterHook(t *testing.T) {
+	testCreator := logtor.NewTestCreator("test")
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.AddLogCreators(testCreator)
+	newLogtor.AddHook(logtor.NewRegexFilterHook(regexp.MustCompile(`^health check`)))
+
+	newLogtor.LogIt(types.INFO, "health check ok")
+	newLogtor.LogIt(types.INFO, "request served")
+	newLogtor.LogIt(types.INFO, struct{ Text string }{"health check"})
+
+	entries := testCreator.Entries()
+	if len(entries) != 2 || entries[0].Message != "request served" {
+		t.Errorf("only the matching message should be dropped: %+v", entries)
+	}
+}
+
+func TestHostnameHook(t *testing.T) {
+	hostname, _ := os.Hostname()
+	testCreator := logtor.NewTestCreator("test")
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.AddLogCreators(testCreator)
+	newLogtor.AddHook(logtor.NewHostnameHook())
+
+	newLogtor.LogIt(types.INFO, "request served")
+	newLogtor.LogIt(types.INFO, types.LogEntry{Message: "forwarded", Fields: map[string]interface{}{"hostname": "edge-1"}})
+
+	entries := testCreator.Entries()
+	if len(entries) != 2 || entries[0].Fields["hostname"] != hostname {
+		t.Fatalf("the hostname should be added: %+v", entries)
+	}
+	if entries[1].Fields["hostname"] != "edge-1" {
+		t.Errorf("a hostname already set should be kept: %+v", entries[1])
+	}
+}
+
+// alertHook logs an alert from After for FATAL messages.
+type alertHook struct {
+	logtor *logtor.Logtor
+}
+
+func (h alertHook) Before(entry *types.LogEntry) bool { return true }
+
+func (h alertHook) After(entry types.LogEntry) {
+	if entry.Level == types.FATAL {
+		h.logtor.LogIt(types.ERROR, "alert sent")
+	}
+}
+
+func TestLogtorAfterHookLogs(t *testing.T) {
+	testCreator := logtor.NewTestCreator("test")
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.AddLogCreators(testCreator)
+	newLogtor.AddHook(alertHook{logtor: newLogtor})
+
+	done := make(chan struct{})
+	go func() {
+		newLogtor.LogIt(types.FATAL, "disk failed")
+		newLogtor.Shutdown()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("an After hook logging should not deadlock")
+	}
+	entries := testCreator.Entries()
+	if len(entries) != 2 || entries[0].Message != "disk failed" || entries[1].Message != "alert sent" {
+		t.Errorf("unexpected entries: %+v", entries)
+	}
+}
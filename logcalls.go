@@ -0,0 +1,63 @@
+package logtor
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// logCallGuard counts the log calls in flight so configuration changes and Shutdown can wait for them,
+// without ever blocking a log call.
+//
+// A read lock held for the duration of a log call would deadlock when the call logs again through the
+// same Logtor, from a hook or a log creator, while a writer is waiting in between, as a pending writer
+// blocks new readers. Instead, calls are counted per epoch: wait starts a new epoch, which later calls
+// join, and waits until the calls of the previous epoch have returned.
+type logCallGuard struct {
+	epoch   atomic.Uint32
+	counts  [2]atomic.Int64
+	waiting atomic.Bool
+	drained chan struct{}
+	mutex   sync.Mutex
+	once    sync.Once
+}
+
+// enter counts a log call in the current epoch and returns the epoch, to be passed to exit.
+func (g *logCallGuard) enter() uint32 {
+	for {
+		epoch := g.epoch.Load()
+		g.counts[epoch&1].Add(1)
+		if g.epoch.Load() == epoch {
+			return epoch
+		}
+		// A waiter started a new epoch in between and may have found the old one drained already.
+		g.exit(epoch)
+	}
+}
+
+// exit ends a log call counted by enter.
+func (g *logCallGuard) exit(epoch uint32) {
+	if g.counts[epoch&1].Add(-1) == 0 && g.waiting.Load() {
+		select {
+		case g.drainedChannel() <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// wait starts a new epoch and blocks until the log calls of the previous epoch have returned. Calls
+// entering meanwhile are not waited for.
+func (g *logCallGuard) wait() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	previous := g.epoch.Add(1) - 1
+	g.waiting.Store(true)
+	defer g.waiting.Store(false)
+	for g.counts[previous&1].Load() != 0 {
+		<-g.drainedChannel()
+	}
+}
+
+func (g *logCallGuard) drainedChannel() chan struct{} {
+	g.once.Do(func() { g.drained = make(chan struct{}, 1) })
+	return g.drained
+}
@@ -31,3 +31,84 @@ type LogCreator interface {
 	// Shutdown performs any necessary cleanup or shutdown operations for the log creator.
 	Shutdown()
 }
+
+// CreatorDescriber is an optional interface for log creators that can describe their resolved settings.
+//
+// The description is included in the GetLogCreatorStatus response.
+type CreatorDescriber interface {
+	// Describe returns the resolved settings of the log creator.
+	Describe() map[string]interface{}
+}
+
+// InputChannelReporter is an optional interface for log creators that publish through a buffered
+// input channel, such as the BrokerCreator. It is used by the BrokerMetricsHandler.
+type InputChannelReporter interface {
+	// InputChannelUsage returns the fraction of the input channel that is filled, between 0 and 1.
+	InputChannelUsage() float64
+}
+
+// CreatorStatsReporter is an optional interface for log creators that collect statistics about the
+// entries they record, such as the HistogramCreator. The statistics are included in Logtor.Stats.
+type CreatorStatsReporter interface {
+	// CreatorStats returns a snapshot of the collected statistics.
+	CreatorStats() map[string]interface{}
+}
+
+// Flusher is an optional interface for log creators that buffer entries before writing them, such as
+// the S3Creator. It is used by the FlushCreatorHandler to write the buffered entries on demand.
+type Flusher interface {
+	// Flush writes the buffered entries immediately.
+	Flush() error
+}
+
+// ErrorLogCreator is an optional interface for log creators that report why an entry was not recorded,
+// such as the FileCreator reporting a failed write. Logtor renders the entries for these log creators like
+// for a RenderedLogCreator, and LogItE returns the error wrapped with the name of the log creator.
+type ErrorLogCreator interface {
+	// LogRenderedE records an entry rendered by Logtor and returns the error that prevented it.
+	LogRenderedE(entry types.RenderedEntry) error
+}
+
+// CreatorMetadata is an optional interface for log creators that describe what kind of creator they are,
+// so operators can tell the implementation behind a name without inspecting the concrete type.
+type CreatorMetadata interface {
+	// CreatorType returns the kind of log creator, e.g. "console", "file" or "kafka".
+	CreatorType() string
+
+	// CreatorVersion returns the version of the log creator implementation.
+	CreatorVersion() string
+
+	// CreatorDescription returns a short human readable description of the log creator.
+	CreatorDescription() string
+}
+
+// RenderedLogCreator is an optional interface for log creators that record entries rendered by Logtor.
+//
+// Logtor renders every message once, resolving the time, the caller and the structured fields, and passes
+// the result to log creators implementing this interface instead of calling LogIt or LogItWithCallDepth.
+// Such log creators are only responsible for the envelope and the transport of the entry, so an entry
+// looks the same at every destination. Log creators that do not implement it keep receiving the message.
+type RenderedLogCreator interface {
+	// LogRendered records a rendered entry and returns true if successful.
+	LogRendered(entry types.RenderedEntry) bool
+}
+
+// LogCreatorV2 is an optional interface for log creators that record structured entries as they are.
+//
+// Logtor.LogEntry passes entries to log creators implementing this interface directly, keeping the level,
+// timestamp, caller and fields of the entry. Other log creators receive the entry as the message of LogIt.
+type LogCreatorV2 interface {
+	// LogEntry records a structured entry and returns true if successful.
+	LogEntry(entry types.LogEntry) bool
+}
+
+// EntryLogCreator is an optional interface for log creators that record the structured entries built by
+// Logtor.LogItContext and Logtor.LogEntry.
+//
+// Log creators implementing it receive the entry, with the fields extracted from the context, instead of
+// a LogEntry call or a LogIt call with the entry as the message, so log creators that implement neither
+// keep working unchanged.
+type EntryLogCreator interface {
+	// LogItEntry records a structured entry and returns true if successful.
+	LogItEntry(entry types.LogEntry) bool
+}
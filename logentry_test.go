@@ -0,0 +1,77 @@
+package logtor_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestNewLogEntry(t *testing.T) {
+	_, file, line, _ := runtime.Caller(0)
+	entry := types.NewLogEntry(types.WARN, "disk almost full")
+	if entry.Level != types.WARN || entry.Message != "disk almost full" || entry.Timestamp.IsZero() {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	if entry.Caller != file || entry.Line != line+1 {
+		t.Errorf("the entry should be attributed to its creator, got %s:%d", entry.Caller, entry.Line)
+	}
+
+	withDisk := entry.WithField("disk", "/dev/sda")
+	withUsage := withDisk.WithFields(map[string]interface{}{"usage": 0.93, "disk": "/dev/sdb"})
+	if entry.Fields != nil || len(withDisk.Fields) != 1 || withDisk.Fields["disk"] != "/dev/sda" {
+		t.Errorf("WithField should return a copy: %v %v", entry.Fields, withDisk.Fields)
+	}
+	if len(withUsage.Fields) != 2 || withUsage.Fields["disk"] != "/dev/sdb" || withUsage.Level != types.WARN {
+		t.Errorf("WithFields should merge into a copy: %+v", withUsage)
+	}
+}
+
+func TestLogtorLogEntry(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "entries.log")
+	fileCreator, err := creators.NewFileCreatorWithOptions(fileName, creators.WithName("File"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fileCreator.(logtor.LogCreatorV2); !ok {
+		t.Fatal("the file creator should implement LogCreatorV2")
+	}
+	testCreator := logtor.NewTestCreator("Test")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(fileCreator, testCreator)
+	newLogtor.SetLogLevel(types.INFO)
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local)
+	entry := types.LogEntry{Level: types.ERROR, Message: "payment failed", Timestamp: created, Caller: "/src/billing/charge.go", Line: 42}
+	if !newLogtor.LogEntry(entry.WithField("order_id", "ord-7")) {
+		t.Fatal("entry not logged")
+	}
+	if newLogtor.LogEntry(types.NewLogEntry(types.TRACE, "disabled")) {
+		t.Error("the entry's level should be checked")
+	}
+
+	newLogtor.ChangeLogCreator("Test")
+	_, _, line, _ := runtime.Caller(0)
+	newLogtor.LogEntry(types.LogEntry{Level: types.WARN, Message: "legacy"})
+	newLogtor.Shutdown()
+
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "ERROR : 2024/01/02 03:04:05 charge.go:42: payment failed order_id=ord-7\n"; string(content) != expected {
+		t.Errorf("the entry should be logged with its own metadata:\n got %q\nwant %q", content, expected)
+	}
+
+	captured := testCreator.Entries()
+	if len(captured) != 1 || captured[0].Level != types.WARN || captured[0].Timestamp.IsZero() ||
+		!strings.HasSuffix(captured[0].Caller, "logentry_test.go") || captured[0].Line != line+1 {
+		t.Errorf("log creators without LogCreatorV2 should receive the stamped entry: %+v", captured)
+	}
+}
@@ -0,0 +1,215 @@
+package logtor
+
+import (
+	"fmt"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// LogItE logs a message like LogIt and returns why it was not logged.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - error: Nil if the message was logged; ErrLevelFiltered, ErrMessageDropped, ErrShutDown, ErrNoCreator
+//     or ErrCreatorNotReady if it did not reach a log creator; otherwise the error of a log creator
+//     implementing ErrorLogCreator, wrapped, or ErrNotLogged.
+func (l *Logtor) LogItE(level types.LogLevel, logMessage interface{}) error {
+	call, err := l.beginLogCall(level, logMessage)
+	if err != nil {
+		return err
+	}
+	defer call.end()
+	if call.direct(0) {
+		err = call.result(call.logCreator.LogIt(level, call.logMessage))
+	} else {
+		err = call.dispatch(0, true, 1)
+	}
+	return call.fallBack(0, true, 1, err)
+}
+
+// LogItWithCallDepthE logs a message like LogItWithCallDepth and returns why it was not logged.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for calling function, or zero or less for the log creator's configured call depth.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - error: The error as returned by LogItE.
+func (l *Logtor) LogItWithCallDepthE(level types.LogLevel, callDepth int, logMessage interface{}) error {
+	call, err := l.beginLogCall(level, logMessage)
+	if err != nil {
+		return err
+	}
+	defer call.end()
+	if call.direct(callDepth) {
+		err = call.result(call.logCreator.LogItWithCallDepth(level, callDepth, call.logMessage))
+	} else {
+		err = call.dispatch(callDepth, false, 1)
+	}
+	return call.fallBack(callDepth, false, 1, err)
+}
+
+// logCall is a log call that passed the log level, the filters and the pre-log hooks, prepared by
+// beginLogCall for the log creator that records it.
+type logCall struct {
+	l          *Logtor
+	level      types.LogLevel
+	logMessage interface{}
+	logCreator LogCreator
+	// fallbacks are the log creators of the fallback chain after logCreator, tried in order if it fails.
+	fallbacks []LogCreator
+	// fellBack tells whether logCreator is a log creator of the fallback chain.
+	fellBack bool
+	// entry tells whether logMessage is a types.LogEntry passed to the log creators with logEntryWith.
+	entry bool
+	epoch uint32
+}
+
+// beginLogCall runs the steps of a log call before the log creator is called. If it returns no error,
+// the caller must call end once the log creator returned.
+func (l *Logtor) beginLogCall(level types.LogLevel, logMessage interface{}) (logCall, error) {
+	logCreator := l.creatorFor(level)
+	if !l.levelEnabledFor(level, logCreator) {
+		l.countFiltered(level)
+		return logCall{}, ErrLevelFiltered
+	}
+	if !l.creatorAccepts(logCreator, level, logMessage) || !l.dedup(level, logMessage) || !l.sample(level) || !l.rateLimit(level) {
+		return logCall{}, ErrMessageDropped
+	}
+	return l.startLogCall(logCreator, level, logMessage)
+}
+
+// startLogCall runs the steps of a log call that passed the log level and the filters: it begins the log
+// call and runs the pre-log hooks, then resolves the fallback chain if the log creator is missing or not
+// ready. If it returns no error, the caller must call end once the log creator returned.
+func (l *Logtor) startLogCall(logCreator LogCreator, level types.LogLevel, logMessage interface{}) (logCall, error) {
+	epoch, ok := l.beginLog()
+	if !ok {
+		return logCall{}, ErrShutDown
+	}
+	call := logCall{l: l, level: level, logCreator: logCreator, epoch: epoch}
+	if call.logMessage, ok = l.runPreLogHooks(level, logMessage); !ok {
+		l.endLog(epoch)
+		return logCall{}, ErrMessageDropped
+	}
+	if err := call.route(); err != nil {
+		call.end()
+		return logCall{}, err
+	}
+	return call, nil
+}
+
+// startEntryCall starts a log call like startLogCall for a structured entry, which is passed to the log
+// creators with logEntryWith. A message replaced by the pre-log hooks with one that is not a
+// types.LogEntry is wrapped in an entry with the level, timestamp and caller of the original entry.
+func (l *Logtor) startEntryCall(logCreator LogCreator, entry types.LogEntry) (logCall, error) {
+	call, err := l.startLogCall(logCreator, entry.Level, entry)
+	if err != nil {
+		return call, err
+	}
+	call.entry = true
+	if _, isEntry := call.logMessage.(types.LogEntry); !isEntry {
+		call.logMessage = types.LogEntry{Level: entry.Level, Message: call.logMessage, Timestamp: entry.Timestamp, Caller: entry.Caller, Line: entry.Line}
+	}
+	return call, nil
+}
+
+// route resolves the fallback chain if the log creator of the log call is missing or not ready, and
+// returns ErrNoCreator or ErrCreatorNotReady if the chain is empty.
+func (c *logCall) route() error {
+	if c.logCreator != nil && c.l.creatorReady(c.logCreator) {
+		return nil
+	}
+	chain := c.l.fallbackChain()
+	c.l.countNotReady(c.logCreator, c.level, len(chain) > 0)
+	if len(chain) == 0 {
+		if c.logCreator == nil {
+			return ErrNoCreator
+		}
+		return ErrCreatorNotReady
+	}
+	c.logCreator, c.fallbacks, c.fellBack = chain[0], chain[1:], true
+	return nil
+}
+
+// end ends the log call and runs the after hooks.
+func (c *logCall) end() {
+	c.l.endLog(c.epoch)
+	c.l.runAfterHooks(&c.logMessage)
+}
+
+// direct reports whether the log creator resolves the caller from its configured call depth, relative to
+// the exported Logtor method, which then calls the log creator itself: LogIt for a callDepth of zero,
+// LogItWithCallDepth otherwise. The other log creators, EntryLogCreators at PANIC level, and entries, are
+// called through dispatch.
+func (c *logCall) direct(callDepth int) bool {
+	if c.entry {
+		return false
+	}
+	switch c.logCreator.(type) {
+	case ErrorLogCreator, RenderedLogCreator:
+		return false
+	case EntryLogCreator:
+		if c.level == types.PANIC {
+			return false
+		}
+	}
+	return callDepth <= 0 && c.l.callDepthAdjustment.Load() == 0
+}
+
+// dispatch calls the log creator with an explicit call depth. viaLogIt tells whether the call stands in
+// for LogIt rather than LogItWithCallDepth. frames is the number of frames between the exported Logtor
+// method and the log creator, which the call depths account for. Entries are passed with logEntryWith,
+// as they already carry their caller.
+func (c *logCall) dispatch(callDepth int, viaLogIt bool, frames int) error {
+	l, logCreator := c.l, c.logCreator
+	if c.entry {
+		return c.result(logEntryWith(logCreator, c.logMessage.(types.LogEntry)))
+	}
+	if entryCreator, ok := logCreator.(EntryLogCreator); ok && c.level == types.PANIC {
+		rendered := l.renderEntry(c.level, l.adjustedDepth(logCreator, callDepth)+frames, c.logMessage)
+		entry := types.LogEntry{Level: c.level, Message: rendered.Message, Timestamp: rendered.Time, Fields: rendered.Fields, Caller: rendered.File, Line: rendered.Line}
+		return c.result(entryCreator.LogItEntry(withStack(entry)))
+	}
+	if errorCreator, ok := logCreator.(ErrorLogCreator); ok {
+		return c.failure(errorCreator.LogRenderedE(l.renderEntry(c.level, l.adjustedDepth(logCreator, callDepth)+frames, c.logMessage)))
+	}
+	if renderer, ok := logCreator.(RenderedLogCreator); ok {
+		return c.result(renderer.LogRendered(l.renderEntry(c.level, l.adjustedDepth(logCreator, callDepth)+frames, c.logMessage)))
+	}
+	if viaLogIt {
+		return c.result(logCreator.LogItWithCallDepth(c.level, logItCallDepth(logCreator, l.callDepthAdjustment.Load())+frames, c.logMessage))
+	}
+	return c.result(logCreator.LogItWithCallDepth(c.level, l.adjustedCallDepth(logCreator, callDepth)+frames, c.logMessage))
+}
+
+// fallBack tries the remaining log creators of the fallback chain in order while the log call fails, and
+// returns the error of the last attempt. frames is the number of frames between the exported Logtor
+// method and fallBack, as for dispatch.
+func (c *logCall) fallBack(callDepth int, viaLogIt bool, frames int, err error) error {
+	for err != nil && len(c.fallbacks) > 0 {
+		c.logCreator, c.fallbacks, c.fellBack = c.fallbacks[0], c.fallbacks[1:], true
+		err = c.dispatch(callDepth, viaLogIt, frames+1)
+	}
+	return err
+}
+
+// result counts the outcome reported by the log creator and returns ErrNotLogged for a failure.
+func (c *logCall) result(logged bool) error {
+	if c.l.countLogged(c.logCreator, c.level, logged) {
+		return nil
+	}
+	return ErrNotLogged
+}
+
+// failure counts the outcome reported by an ErrorLogCreator and wraps its error.
+func (c *logCall) failure(err error) error {
+	if c.l.countLogged(c.logCreator, c.level, err == nil) {
+		return nil
+	}
+	return fmt.Errorf("logtor: %s: %w", c.logCreator.LogName(), err)
+}
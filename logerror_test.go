@@ -0,0 +1,62 @@
+package logtor_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// erroringCreator is a discardCreator whose rendered writes fail with err.
+type erroringCreator struct {
+	discardCreator
+	err error
+}
+
+func (ec *erroringCreator) LogRenderedE(entry types.RenderedEntry) error { return ec.err }
+
+func TestLogtorLogItE(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	if err := newLogtor.LogItE(types.INFO, "nowhere"); !errors.Is(err, logtor.ErrNoCreator) {
+		t.Errorf("expected ErrNoCreator, got %v", err)
+	}
+
+	offline := newRecordingCreator("Offline")
+	offline.notReady = true
+	diskFull := errors.New("no space left on device")
+	newLogtor.AddLogCreators(offline, &erroringCreator{discardCreator: discardCreator{name: "Full"}, err: diskFull},
+		&failingCreator{discardCreator{name: "Failing"}}, newRecordingCreator("Console"))
+	newLogtor.ChangeLogCreator("Offline")
+	if err := newLogtor.LogItE(types.INFO, "offline"); !errors.Is(err, logtor.ErrCreatorNotReady) {
+		t.Errorf("expected ErrCreatorNotReady, got %v", err)
+	}
+	newLogtor.ChangeLogCreator("Full")
+	if err := newLogtor.LogItWithCallDepthE(types.INFO, 2, "full"); !errors.Is(err, diskFull) || err.Error() != "logtor: Full: no space left on device" {
+		t.Errorf("expected the wrapped creator error, got %v", err)
+	}
+	if newLogtor.LogIt(types.INFO, "full") {
+		t.Error("LogIt should return false when the log creator fails")
+	}
+	newLogtor.ChangeLogCreator("Failing")
+	if err := newLogtor.LogItE(types.INFO, "failing"); !errors.Is(err, logtor.ErrNotLogged) {
+		t.Errorf("expected ErrNotLogged, got %v", err)
+	}
+	newLogtor.ChangeLogCreator("Console")
+	if err := newLogtor.LogItE(types.TRACE, "filtered"); !errors.Is(err, logtor.ErrLevelFiltered) {
+		t.Errorf("expected ErrLevelFiltered, got %v", err)
+	}
+	newLogtor.SetCreatorFilter("Console", func(level types.LogLevel, logMessage interface{}) bool { return logMessage != "noise" })
+	if err := newLogtor.LogItE(types.INFO, "noise"); !errors.Is(err, logtor.ErrMessageDropped) {
+		t.Errorf("expected ErrMessageDropped, got %v", err)
+	}
+	if err := newLogtor.LogItE(types.INFO, "logged"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	newLogtor.Shutdown()
+	if err := newLogtor.LogItWithCallDepthE(types.INFO, 0, "late"); !errors.Is(err, logtor.ErrShutDown) {
+		t.Errorf("expected ErrShutDown, got %v", err)
+	}
+}
@@ -17,34 +17,195 @@
 package logtor
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
 	"reflect"
+	"runtime"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/Eyup-Devop/logtor/types"
 )
 
-var defaultCreatorName string = "defaultCreator"
+var (
+	// ErrUnknownLogCreator is returned when no log creator is registered under the requested name.
+	ErrUnknownLogCreator = errors.New("logtor: unknown log creator")
+
+	// ErrNoPreviousLogCreator is returned by RevertLogCreator when there is no log creator to switch back to.
+	ErrNoPreviousLogCreator = errors.New("logtor: no previous log creator")
+
+	// ErrLogCreatorExists is returned by TryAddLogCreators when a log creator with the same name is already registered.
+	ErrLogCreatorExists = errors.New("logtor: log creator already registered")
+
+	// ErrLevelFiltered is returned by LogItE when the log level of the message is not enabled.
+	ErrLevelFiltered = errors.New("logtor: message filtered by log level")
+
+	// ErrMessageDropped is returned by LogItE when a creator filter, sampling, rate limiting or a pre-log
+	// hook dropped the message.
+	ErrMessageDropped = errors.New("logtor: message dropped before reaching a log creator")
+
+	// ErrShutDown is returned by LogItE once Shutdown has started.
+	ErrShutDown = errors.New("logtor: logtor is shut down")
+
+	// ErrNoCreator is returned by LogItE when there is no log creator for the message and no fallback.
+	ErrNoCreator = errors.New("logtor: no log creator")
+
+	// ErrCreatorNotReady is returned by LogItE when the log creator for the message is not ready and
+	// there is no fallback.
+	ErrCreatorNotReady = errors.New("logtor: log creator not ready")
+
+	// ErrNotLogged is returned by LogItE when the log creator reported a failure without an error.
+	ErrNotLogged = errors.New("logtor: log creator did not log the message")
+)
+
+// defaultCreatorName is the name of the stderr log creator registered by NewWithDefault.
+const defaultCreatorName types.LogCreatorName = "defaultCreator"
 
 // New creates a new Logtor instance with default settings.
 //
 // It initializes a Logtor with an empty list of log creators, a global log level set to NONE,
-// and no current log creator selected.
+// and no current log creator selected, so nothing is logged until log creators are added and a log
+// level is set. Use NewWithDefault for a Logtor that logs to the console out of the box.
 //
 // Returns:
 //   - *Logtor: A pointer to the newly created Logtor.
 func New() *Logtor {
 	return &Logtor{
-		logCreatorList:    make(map[types.LogCreatorName]LogCreator),
-		logLevel:          types.NONE,
-		currentLogCreator: nil,
+		logCreatorList: make(map[types.LogCreatorName]LogCreator),
 	}
 }
 
+// NewWithDefault creates a new Logtor instance that logs to the console out of the box.
+//
+// It registers a log creator named "defaultCreator", writing to os.Stderr in the text format of the
+// file creator, as both the default and the active log creator, and sets the global log level to INFO.
+// The first log creators added with AddLogCreators become active, while the stderr log creator stays
+// registered as the fallback for log creators that are not ready. It does not depend on the creators
+// package. Use New for a Logtor that logs nothing until log creators are added.
+//
+// Returns:
+//   - *Logtor: A pointer to the newly created Logtor.
+func NewWithDefault() *Logtor {
+	logCreator := &stderrCreator{name: defaultCreatorName}
+	l := New().WithDefaultCreator(logCreator)
+	l.currentLogCreator.Store(logCreator)
+	l.SetLogLevel(types.INFO)
+	return l
+}
+
+// WithDefaultCreator registers a log creator and makes it the default creator, which receives the
+// messages of log creators that are not ready.
+//
+// The default creator is added to the list of log creators, so it is listed by the HTTP handlers and
+// shut down by Shutdown, but it does not become the active log creator. A nil or typed nil creator is
+// ignored, and so is a creator whose name is invalid or already taken by another log creator; use
+// SetDefaultCreator to find out why a creator could not be made the default.
+//
+// Parameters:
+//   - creator: The log creator to use as the default creator.
+//
+// Returns:
+//   - *Logtor: The Logtor instance, for chaining.
 func (l *Logtor) WithDefaultCreator(creator LogCreator) *Logtor {
-	l.defaultCreator = creator
+	if isNilCreator(creator) {
+		return l
+	}
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	name := creator.LogName()
+	if registered, ok := l.logCreatorList[name]; ok {
+		if !sameCreator(registered, creator) {
+			return l
+		}
+	} else if name.Validate() != nil {
+		return l
+	} else {
+		l.logCreatorList[name] = creator
+		l.rebuildRoutedCreators()
+	}
+	l.defaultCreator.Store(creator)
 	return l
 }
 
+// SetDefaultCreator makes an already registered log creator the default creator, which receives the
+// messages of log creators that are not ready.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator to use as the default creator.
+//
+// Returns:
+//   - error: An error if no log creator is registered under the name.
+func (l *Logtor) SetDefaultCreator(logCreatorName types.LogCreatorName) error {
+	l.changeMutex.RLock()
+	defer l.changeMutex.RUnlock()
+	logCreator, ok := l.logCreatorList[logCreatorName]
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownLogCreator, logCreatorName)
+	}
+	l.defaultCreator.Store(logCreator)
+	return nil
+}
+
+// creatorHolder holds a LogCreator, possibly nil, so it can be swapped atomically.
+//
+// The logging path loads the current and default creators through a creatorHolder instead of
+// taking changeMutex, so changing the active creator never blocks goroutines that are logging.
+type creatorHolder struct {
+	pointer atomic.Pointer[LogCreator]
+}
+
+// Load returns the held log creator, or nil if none was stored.
+func (h *creatorHolder) Load() LogCreator {
+	if logCreator := h.pointer.Load(); logCreator != nil {
+		return *logCreator
+	}
+	return nil
+}
+
+// Store replaces the held log creator. Storing nil clears it.
+func (h *creatorHolder) Store(logCreator LogCreator) {
+	if logCreator == nil {
+		h.pointer.Store(nil)
+		return
+	}
+	h.pointer.Store(&logCreator)
+}
+
+// Swap replaces the held log creator and returns the one it replaced, or nil if none was held.
+func (h *creatorHolder) Swap(logCreator LogCreator) LogCreator {
+	var replaced *LogCreator
+	if logCreator == nil {
+		replaced = h.pointer.Swap(nil)
+	} else {
+		replaced = h.pointer.Swap(&logCreator)
+	}
+	if replaced == nil {
+		return nil
+	}
+	return *replaced
+}
+
+// CompareAndSwap replaces the held log creator with another one, possibly nil, only if the held log
+// creator is old, and reports whether it was replaced.
+func (h *creatorHolder) CompareAndSwap(old, logCreator LogCreator) bool {
+	current := h.pointer.Load()
+	if current == nil || !sameCreator(*current, old) {
+		return false
+	}
+	var replacement *LogCreator
+	if logCreator != nil {
+		replacement = &logCreator
+	}
+	return h.pointer.CompareAndSwap(current, replacement)
+}
+
 // Logtor is a central logging manager that coordinates multiple log creators and log levels.
 //
 // It manages a list of log creators, allowing you to log messages to different destinations (e.g., file, console) simultaneously.
@@ -52,15 +213,87 @@ func (l *Logtor) WithDefaultCreator(creator LogCreator) *Logtor {
 //
 // Fields:
 //   - logCreatorList: A map of LogCreatorName to LogCreator, representing registered log creator.
-//   - logLevel: The global log level that controls which log messages are created.
-//   - currentLogCreator: The currently active log creator for logging messages.
-//   - changeMutex: A read-write mutex to control concurrent access to Logtor's fields.
+//   - logLevel: The rank of the global log level, as returned by levelRank, stored atomically so level checks are lock-free.
+//   - currentLogCreator: The currently active log creator for logging messages, swapped atomically.
+//   - previousLogCreator: The log creator replaced by the last switch, for RevertLogCreator.
+//   - changeMutex: A read-write mutex guarding logCreatorList, levelRoutes, creatorLevels and previousLogCreator.
+//   - defaultCreator: The log creator used when the selected one is not ready, swapped atomically.
+//   - fallbackNames: The names of the log creators set with WithFallbacks, tried after the default creator.
+//   - fallbacks: The log creators fallbackNames resolves to, rebuilt under changeMutex so the logging path reads it lock-free.
+//   - configMutex: A mutex serializing configuration changes applied with ApplyConfig.
+//   - appliedCreators: The log creators declared by the last applied configuration.
+//   - lastLogged: The last message logged per key by LogItIfChanged.
+//   - loggedOnce: The keys already logged by LogItOnce.
+//   - loggedEvery: The time each key was last logged by LogItEvery.
+//   - clock: The clock set with SetClock, nil for the SystemClock.
+//   - levelRoutes: Log levels that are always sent to a specific log creator.
+//   - creatorLevels: The log levels set for single log creators, overriding the global log level.
+//   - creatorLevelRanks: The ranks of creatorLevels, rebuilt under changeMutex so the logging path reads them lock-free.
+//   - routedCreators: The log creators levelRoutes resolves to, rebuilt under changeMutex so the logging path reads it lock-free.
+//   - adminMux: The admin routes served by ServeHTTP, built on first use.
+//   - adminMuxOnce: Guards building adminMux.
+//   - preLogHooks: The hooks transforming messages before they are logged, replaced under changeMutex so the logging path reads them lock-free.
+//   - hooks: The hooks added with AddHook, replaced under changeMutex so the logging path reads them lock-free.
+//   - creatorFilters: The filters set with SetCreatorFilter, replaced under changeMutex so the logging path reads them lock-free.
+//   - shutdownMutex: A mutex guarding the shutdown hooks and serializing Shutdown.
+//   - shutDown: Whether Shutdown has been called, set under shutdownMutex and read lock-free by every log call.
+//   - logCalls: The log calls in flight, waited for before log creators are shut down.
+//   - readinessMutex: A mutex guarding readinessMonitor.
+//   - readinessMonitor: The monitor started with MonitorReadiness, nil if none runs.
+//   - readiness: The readiness of the active log creator last seen by the monitor, nil if none runs.
 type Logtor struct {
-	logCreatorList    map[types.LogCreatorName]LogCreator
-	logLevel          types.LogLevel
-	currentLogCreator LogCreator
-	changeMutex       sync.RWMutex
-	defaultCreator    LogCreator
+	logCreatorList      map[types.LogCreatorName]LogCreator
+	logLevel            atomic.Int32
+	currentLogCreator   creatorHolder
+	previousLogCreator  types.LogCreatorName
+	changeMutex         sync.RWMutex
+	defaultCreator      creatorHolder
+	fallbackNames       []types.LogCreatorName
+	fallbacks           atomic.Pointer[[]LogCreator]
+	configMutex         sync.Mutex
+	appliedCreators     map[types.LogCreatorName]CreatorConfig
+	lastLogged          sync.Map
+	loggedOnce          sync.Map
+	loggedEvery         sync.Map
+	clock               atomic.Pointer[Clock]
+	levelRoutes         map[types.LogLevel]types.LogCreatorName
+	creatorLevels       map[types.LogCreatorName]types.LogLevel
+	creatorChains       map[types.LogCreatorName][]string
+	namespaceLevels     map[string]types.LogLevel
+	namespaceRanks      atomic.Pointer[namespaceRanks]
+	creatorLevelRanks   atomic.Pointer[map[types.LogCreatorName]int32]
+	routedCreators      atomic.Pointer[map[types.LogLevel]LogCreator]
+	adminMux            *http.ServeMux
+	adminMuxOnce        sync.Once
+	preLogHooks         atomic.Pointer[[]PreLogHook]
+	hooks               atomic.Pointer[[]Hook]
+	creatorFilters      atomic.Pointer[map[types.LogCreatorName]Filter]
+	globalFields        atomic.Pointer[map[string]interface{}]
+	stderrFallback      atomic.Bool
+	contextKeys         atomic.Pointer[[]contextKey]
+	shutdownMutex       sync.Mutex
+	shutDown            atomic.Bool
+	logCalls            logCallGuard
+	readinessMutex      sync.Mutex
+	readinessMonitor    *readinessMonitor
+	readiness           atomic.Pointer[readinessState]
+	shutdownHooks       []func()
+	timersDone          chan struct{}
+	callDepthAdjustment atomic.Int32
+	samplers            [levelSlots]atomic.Pointer[levelSampler]
+	sampledOut          [levelSlots]atomic.Int64
+	samplingExempt      atomic.Pointer[[levelSlots]bool]
+	limiters            [levelSlots]atomic.Pointer[levelLimiter]
+	suppressedOut       [levelSlots]atomic.Int64
+	filteredOut         [levelSlots]atomic.Uint64
+	loggedOut           [levelSlots]atomic.Uint64
+	redactedKeys        atomic.Pointer[map[string]struct{}]
+	deduplicator        atomic.Pointer[deduplicator]
+	messageCounters     sync.Map
+	fanOutPolicy        atomic.Int32
+	exitFunc            func(os.Signal)
+	exitTimeout         time.Duration
+	exitOnFatal         atomic.Bool
 }
 
 // SetLogLevel sets the global log level for the Logtor instance.
@@ -68,16 +301,42 @@ type Logtor struct {
 // You can use this method to change the log level for the Logtor, which controls which log messages
 // are recorded and displayed. The log level should be one of the predefined LogLevelType constants.
 //
+// The log level is stored atomically, so SetLogLevel, LogLevel and the log calls can be used concurrently
+// without locking.
+//
 // Parameters:
 //   - logLevel: The new global log level to set for the Logtor.
 func (l *Logtor) SetLogLevel(logLevel types.LogLevel) bool {
 	if logLevel.IsValid() {
-		l.logLevel = logLevel
+		l.logLevel.Store(levelRank(logLevel))
 		return true
 	}
 	return false
 }
 
+// SetDefaultCallDepthAdjustment shifts the call depth used for every log creator by delta, for applications
+// that log through helper layers adding stack frames between the call site and Logtor.
+//
+// The adjustment is stored on the Logtor and added to the configured call depth of the log creator
+// whenever a message is logged without an explicit call depth, so every log creator, including those
+// added later, attributes entries to the same call site. The log creators themselves are not changed,
+// and an explicit call depth passed to LogItWithCallDepth is used as is. Setting a new adjustment
+// replaces the previous one.
+//
+// Parameters:
+//   - delta: The number of stack frames to add to the call depth of every log creator.
+func (l *Logtor) SetDefaultCallDepthAdjustment(delta int) {
+	l.callDepthAdjustment.Store(int32(delta))
+}
+
+// DefaultCallDepthAdjustment returns the adjustment set with SetDefaultCallDepthAdjustment.
+//
+// Returns:
+//   - int: The number of stack frames added to the call depth of every log creator.
+func (l *Logtor) DefaultCallDepthAdjustment() int {
+	return int(l.callDepthAdjustment.Load())
+}
+
 // LogLevel returns the current global log level of the Logtor instance.
 //
 // Use this method to retrieve the current global log level, which determines which log messages
@@ -86,14 +345,96 @@ func (l *Logtor) SetLogLevel(logLevel types.LogLevel) bool {
 // Returns:
 //   - LogLevelType: The current global log level.
 func (l *Logtor) LogLevel() types.LogLevel {
-	return l.logLevel
+	return levelOfRank(l.logLevel.Load())
+}
+
+// IsLevelEnabled reports whether messages at the given level are recorded under the current global log level.
+//
+// The check is a lock-free integer comparison, so it is cheap enough to guard the construction of
+// expensive log messages:
+//
+//	if l.IsLevelEnabled(types.DEBUG) {
+//		l.LogIt(types.DEBUG, buildDump())
+//	}
+//
+// Parameters:
+//   - level: The log level to check.
+//
+// Log levels set for single log creators with SetCreatorLogLevel are not taken into account.
+//
+// Returns:
+//   - bool: True if LogIt would record a message at this level under the global log level.
+func (l *Logtor) IsLevelEnabled(level types.LogLevel) bool {
+	selected := l.logLevel.Load()
+	rank := levelRank(level)
+	return rank > 0 && rank <= selected
+}
+
+// levelRank returns the severity rank of the level as registered in types, where NONE is 0 and a higher
+// rank includes all lower ones. Unknown levels return -1.
+func levelRank(level types.LogLevel) int32 {
+	if rank, ok := types.LogLevelRank(level); ok {
+		return int32(rank)
+	}
+	return -1
+}
+
+// levelSlot returns the index of the level in the per-level tables of the Logtor, where NONE is 0.
+// Unknown levels return -1.
+func levelSlot(level types.LogLevel) int {
+	return types.LogLevelSlot(level)
+}
+
+// levelOfRank returns the log level of a rank returned by levelRank, NONE if no level has it.
+func levelOfRank(rank int32) types.LogLevel {
+	for _, level := range types.LogLevels() {
+		if levelRank(level) == rank {
+			return level
+		}
+	}
+	return types.NONE
+}
+
+// Explain describes which log levels are recorded and which are suppressed for the given level.
+//
+// Use this method to document log level decisions, for example in startup banners or admin UIs.
+// The levels are taken from types.LogLevels in order, and NONE is never listed since it is
+// not a level messages are logged at.
+//
+// Parameters:
+//   - level: The log level to explain.
+//
+// Returns:
+//   - string: A human-readable description such as
+//     "Selected level: WARN. Will log: PANIC, FATAL, ERROR, WARN. Will suppress: DEBUG, INFO, TRACE."
+func (l *Logtor) Explain(level types.LogLevel) string {
+	logged := []string{}
+	suppressed := []string{}
+	for _, logLevel := range types.LogLevels() {
+		if logLevel == types.NONE {
+			continue
+		}
+		if level.IsLogLevelAcceptable(logLevel) {
+			logged = append(logged, string(logLevel))
+		} else {
+			suppressed = append(suppressed, string(logLevel))
+		}
+	}
+	return fmt.Sprintf("Selected level: %s. Will log: %s. Will suppress: %s.", level, joinLevelNames(logged), joinLevelNames(suppressed))
+}
+
+func joinLevelNames(names []string) string {
+	if len(names) == 0 {
+		return "none"
+	}
+	return strings.Join(names, ", ")
 }
 
 // ChangeLogCreator changes the active log creator to the one with the specified name.
 //
 // Use this method to switch the active log creator to the one identified by the provided
 // LogCreatorName. This allows you to direct log messages to a specific log creator from the
-// list of registered log creators.
+// list of registered log creators. It calls SwapLogCreator and discards the previous log creator.
 //
 // Parameters:
 //   - logCreatorName: The name of the log creator to make active.
@@ -102,13 +443,134 @@ func (l *Logtor) LogLevel() types.LogLevel {
 //   - bool: True if the log creator with the specified name exists and is successfully set as active;
 //     false if the log creator does not exist.
 func (l *Logtor) ChangeLogCreator(logCreatorName types.LogCreatorName) bool {
-	l.changeMutex.RLock()
-	defer l.changeMutex.RUnlock()
-	if _, ok := l.logCreatorList[logCreatorName]; !ok {
-		return false
+	_, err := l.SwapLogCreator(logCreatorName)
+	return err == nil
+}
+
+// SwapLogCreator changes the active log creator to the one with the specified name and reports which
+// log creator it replaced.
+//
+// The switch and the returned name form one atomic step, and the replaced log creator is remembered so
+// RevertLogCreator can switch back to it.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator to make active.
+//
+// Returns:
+//   - previous: The name of the log creator that was active before, empty if none was.
+//   - err: An error wrapping ErrUnknownLogCreator if the log creator does not exist.
+func (l *Logtor) SwapLogCreator(logCreatorName types.LogCreatorName) (previous types.LogCreatorName, err error) {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	return l.swapLogCreatorLocked(logCreatorName)
+}
+
+// RevertLogCreator switches back to the log creator that was active before the last SwapLogCreator,
+// ChangeLogCreator or RevertLogCreator call. Calling it twice therefore toggles between two log creators.
+//
+// Returns:
+//   - previous: The name of the log creator that was active before the revert.
+//   - err: ErrNoPreviousLogCreator if no log creator was replaced yet, or an error wrapping
+//     ErrUnknownLogCreator if the previous log creator is no longer registered.
+func (l *Logtor) RevertLogCreator() (previous types.LogCreatorName, err error) {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	if l.previousLogCreator == "" {
+		return "", ErrNoPreviousLogCreator
 	}
-	l.currentLogCreator = l.logCreatorList[logCreatorName]
-	return true
+	return l.swapLogCreatorLocked(l.previousLogCreator)
+}
+
+// swapLogCreatorLocked makes the named log creator active and remembers the replaced one. It must be
+// called with changeMutex held for writing.
+func (l *Logtor) swapLogCreatorLocked(logCreatorName types.LogCreatorName) (types.LogCreatorName, error) {
+	logCreator, ok := l.logCreatorList[logCreatorName]
+	if !ok {
+		return "", fmt.Errorf("%w %q", ErrUnknownLogCreator, logCreatorName)
+	}
+	var previous types.LogCreatorName
+	if replaced := l.currentLogCreator.Swap(logCreator); replaced != nil {
+		previous = replaced.LogName()
+	}
+	if previous != "" && previous != logCreatorName {
+		l.previousLogCreator = previous
+	}
+	return previous, nil
+}
+
+// ChangeLogCreatorIfReady changes the active log creator to the one with the specified name, but only if
+// that log creator reports that it is ready.
+//
+// Use this method instead of ChangeLogCreator when the target may not be usable yet, such as a
+// BrokerCreator that is still connecting. Switching to it unconditionally would send every message to
+// the default creator until it becomes ready.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator to make active.
+//
+// Returns:
+//   - changed: True if the active log creator was switched.
+//   - ready: True if the log creator exists and is ready, whether or not it was already active.
+func (l *Logtor) ChangeLogCreatorIfReady(logCreatorName types.LogCreatorName) (changed bool, ready bool) {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	logCreator, ok := l.logCreatorList[logCreatorName]
+	if !ok || !logCreator.IsReady() {
+		return false, false
+	}
+	if current := l.currentLogCreator.Load(); current != nil && current.LogName() == logCreatorName {
+		return false, true
+	}
+	l.swapLogCreatorLocked(logCreatorName)
+	return true, true
+}
+
+// ChangeLogCreatorWithRollback changes the active log creator and rolls back if it fails a probe.
+//
+// After switching, a DEBUG probe message is logged directly to the new log creator, regardless of the
+// global log level. If the probe is not logged within probeTimeout, the previously active log creator
+// is restored, unless another change made it inactive in the meantime. A probe that times out keeps
+// running in the background until the log creator returns.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator to make active.
+//   - probeTimeout: How long to wait for the probe message to be logged.
+//
+// Returns:
+//   - changed: True if the log creator is active after the call.
+//   - err: An error if the log creator does not exist or the change was rolled back.
+func (l *Logtor) ChangeLogCreatorWithRollback(logCreatorName types.LogCreatorName, probeTimeout time.Duration) (changed bool, err error) {
+	l.changeMutex.Lock()
+	logCreator, ok := l.logCreatorList[logCreatorName]
+	if !ok {
+		l.changeMutex.Unlock()
+		return false, fmt.Errorf("%w %q", ErrUnknownLogCreator, logCreatorName)
+	}
+	previous, previousName := l.currentLogCreator.Load(), l.previousLogCreator
+	l.swapLogCreatorLocked(logCreatorName)
+	l.changeMutex.Unlock()
+
+	probed := make(chan bool, 1)
+	go func() {
+		probed <- logCreator.IsReady() && logCreator.LogIt(types.DEBUG, fmt.Sprintf("logtor: probe after switching to log creator %q", logCreatorName))
+	}()
+	timer := time.NewTimer(probeTimeout)
+	defer timer.Stop()
+	select {
+	case ok = <-probed:
+		if ok {
+			return true, nil
+		}
+		err = fmt.Errorf("logtor: probe of log creator %q failed", logCreatorName)
+	case <-timer.C:
+		err = fmt.Errorf("logtor: probe of log creator %q timed out after %s", logCreatorName, probeTimeout)
+	}
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	if l.currentLogCreator.CompareAndSwap(logCreator, previous) {
+		l.previousLogCreator = previousName
+	}
+	return false, err
 }
 
 // LogCreator returns the currently active log creator of the Logtor instance.
@@ -119,50 +581,382 @@ func (l *Logtor) ChangeLogCreator(logCreatorName types.LogCreatorName) bool {
 // Returns:
 //   - LogCreator: The currently active log creator.
 func (l *Logtor) LogCreator() LogCreator {
-	return l.currentLogCreator
+	return l.currentLogCreator.Load()
+}
+
+// CreatorMetadataByName returns the metadata of a registered log creator.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator.
+//
+// Returns:
+//   - CreatorMetadata: The metadata of the log creator.
+//   - bool: False if no log creator is registered under the name or it does not implement CreatorMetadata.
+func (l *Logtor) CreatorMetadataByName(logCreatorName types.LogCreatorName) (CreatorMetadata, bool) {
+	l.changeMutex.RLock()
+	defer l.changeMutex.RUnlock()
+	metadata, ok := l.logCreatorList[logCreatorName].(CreatorMetadata)
+	return metadata, ok
+}
+
+// RouteLevels sends messages of the given log levels to a specific log creator instead of the active one.
+//
+// Routed messages are still subject to the global log level, and fall back to the default creator
+// when the routed log creator is not ready.
+//
+// Parameters:
+//   - logCreatorName: The name of a registered log creator.
+//   - levels: The log levels to route to the log creator.
+//
+// Returns:
+//   - bool: True if the routes were set; false if the log creator is not registered.
+func (l *Logtor) RouteLevels(logCreatorName types.LogCreatorName, levels ...types.LogLevel) bool {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	if _, ok := l.logCreatorList[logCreatorName]; !ok {
+		return false
+	}
+	if l.levelRoutes == nil {
+		l.levelRoutes = make(map[types.LogLevel]types.LogCreatorName)
+	}
+	for _, level := range levels {
+		l.levelRoutes[level] = logCreatorName
+	}
+	l.rebuildRoutedCreators()
+	return true
+}
+
+// rebuildRoutedCreators resolves levelRoutes against logCreatorList into a new routedCreators map, and
+// the fallback chain with rebuildFallbacks. It must be called with changeMutex held whenever any of them
+// changes.
+func (l *Logtor) rebuildRoutedCreators() {
+	l.rebuildFallbacks()
+	if len(l.levelRoutes) == 0 {
+		l.routedCreators.Store(nil)
+		return
+	}
+	routed := make(map[types.LogLevel]LogCreator, len(l.levelRoutes))
+	for level, name := range l.levelRoutes {
+		if logCreator, ok := l.logCreatorList[name]; ok {
+			routed[level] = logCreator
+		}
+	}
+	l.routedCreators.Store(&routed)
+}
+
+// creatorFor returns the log creator responsible for the log level: the routed one if a route
+// exists for the level, otherwise the currently active log creator. It does not take changeMutex.
+func (l *Logtor) creatorFor(level types.LogLevel) LogCreator {
+	if routed := l.routedCreators.Load(); routed != nil {
+		if logCreator, ok := (*routed)[level]; ok {
+			return logCreator
+		}
+	}
+	return l.currentLogCreator.Load()
 }
 
 // LogIt logs a message at the specified log level using the currently active log creator.
 //
 // This method allows you to log a message at a specific log level, subject to the global log level
 // configured for the Logtor. If the provided log level is acceptable based on the global log level,
-// the message is recorded by the currently active log creator.
+// the message is recorded by the currently active log creator, or by the log creator the level is
+// routed to with RouteLevels. If there is no active log creator yet, or it is not ready, the message
+// goes to the fallback chain: the default creator, the fallbacks set with WithFallbacks and the stderr
+// fallback, and is dropped if none of them is set and ready.
 //
 // Parameters:
 //   - level: The log level for the message (e.g., INFO, DEBUG).
 //   - logMessage: The message to be logged, which can be of any type.
 //
 // Returns:
-//   - bool: True if the message was successfully logged; false if it was skipped due to the log level.
+//   - bool: True if the message was successfully logged; false if it was skipped due to the log level
+//     or the Logtor has been shut down.
 func (l *Logtor) LogIt(level types.LogLevel, logMessage interface{}) bool {
-	if l.logLevel.IsLogLevelAcceptable(level) && l.currentLogCreator.IsReady() {
-		return l.currentLogCreator.LogIt(level, logMessage)
-	} else if l.logLevel.IsLogLevelAcceptable(level) && !l.currentLogCreator.IsReady() && l.defaultCreator != nil {
-		return l.defaultCreator.LogIt(level, logMessage)
+	call, err := l.beginLogCall(level, logMessage)
+	if err != nil {
+		return false
 	}
-	return false
+	defer call.end()
+	if call.direct(0) {
+		err = call.result(call.logCreator.LogIt(level, call.logMessage))
+	} else {
+		err = call.dispatch(0, true, 1)
+	}
+	return call.fallBack(0, true, 1, err) == nil
 }
 
-// LogIt logs a message at the specified log level using the currently active log creator.
+// LogItWithCallDepth logs a message at the specified log level and call depth using the currently active log creator.
 //
 // This method allows you to log a message at a specific log level, subject to the global log level
 // configured for the Logtor. If the provided log level is acceptable based on the global log level,
 // the message is recorded by the currently active log creator.
 //
+// A callDepth of zero or less is forwarded as is: the built-in log creators then fall back to their
+// configured call depth, attributing the entry the way LogIt does.
+//
 // Parameters:
 //   - level: The log level for the message (e.g., INFO, DEBUG).
-//   - callDepth: The call depth for calling function.
+//   - callDepth: The call depth for calling function, or zero or less for the log creator's configured call depth.
 //   - logMessage: The message to be logged, which can be of any type.
 //
 // Returns:
-//   - bool: True if the message was successfully logged; false if it was skipped due to the log level.
+//   - bool: True if the message was successfully logged; false if it was skipped due to the log level
+//     or the Logtor has been shut down.
 func (l *Logtor) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
-	if types.IsLogLevelAcceptable(l.LogLevel(), level) && l.currentLogCreator.IsReady() {
-		return l.currentLogCreator.LogItWithCallDepth(level, callDepth, logMessage)
-	} else if l.logLevel.IsLogLevelAcceptable(level) && !l.currentLogCreator.IsReady() && l.defaultCreator != nil {
-		return l.defaultCreator.LogItWithCallDepth(level, callDepth, logMessage)
+	call, err := l.beginLogCall(level, logMessage)
+	if err != nil {
+		return false
 	}
-	return false
+	defer call.end()
+	if call.direct(callDepth) {
+		err = call.result(call.logCreator.LogItWithCallDepth(level, callDepth, call.logMessage))
+	} else {
+		err = call.dispatch(callDepth, false, 1)
+	}
+	return call.fallBack(callDepth, false, 1, err) == nil
+}
+
+// LogEntry logs a structured entry at its own level using the currently active log creator.
+//
+// The entry is checked against the log level like LogIt and passes through the global fields and the
+// pre-log hooks. An entry without a timestamp is stamped with the current time, and an entry without a
+// caller is attributed to the caller of LogEntry. Log creators implementing EntryLogCreator or LogCreatorV2
+// receive the entry as it is; other log creators receive it as the message of LogIt.
+//
+// Parameters:
+//   - entry: The entry to be logged, for example built with types.NewLogEntry.
+//
+// Returns:
+//   - bool: True if the entry was successfully logged; false if it was skipped due to the log level
+//     or the Logtor has been shut down.
+func (l *Logtor) LogEntry(entry types.LogEntry) bool {
+	logCreator := l.creatorFor(entry.Level)
+	if !l.levelEnabledFor(entry.Level, logCreator) {
+		l.countFiltered(entry.Level)
+		return false
+	}
+	if !l.creatorAccepts(logCreator, entry.Level, entry) || !l.dedup(entry.Level, entry.Message) || !l.sample(entry.Level) || !l.rateLimit(entry.Level) {
+		return false
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = l.now()
+	}
+	if entry.Caller == "" {
+		if _, file, line, ok := runtime.Caller(1); ok {
+			entry.Caller, entry.Line = file, line
+		}
+	}
+	call, err := l.startEntryCall(logCreator, entry)
+	if err != nil {
+		return false
+	}
+	defer call.end()
+	return call.fallBack(0, true, 1, call.dispatch(0, true, 1)) == nil
+}
+
+// LogItAttempt logs a message like LogIt and reports how the message was handled.
+//
+// Use this method to debug routing and fallback decisions: the result tells whether the level was
+// filtered, which log creator received the message, whether the default creator stood in for a log
+// creator that was not ready, and how long the write took.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - types.LogAttemptResult: The outcome of the log call.
+func (l *Logtor) LogItAttempt(level types.LogLevel, logMessage interface{}) types.LogAttemptResult {
+	var result types.LogAttemptResult
+	logCreator := l.creatorFor(level)
+	if !l.levelEnabledFor(level, logCreator) {
+		l.countFiltered(level)
+		result.FilteredByLevel = true
+		return result
+	}
+	if !l.creatorAccepts(logCreator, level, logMessage) {
+		result.FilteredByCreator = true
+		return result
+	}
+	if !l.dedup(level, logMessage) {
+		result.Deduplicated = true
+		return result
+	}
+	if !l.sample(level) {
+		result.Sampled = true
+		return result
+	}
+	if !l.rateLimit(level) {
+		result.RateLimited = true
+		return result
+	}
+	call, err := l.startLogCall(logCreator, level, logMessage)
+	if err != nil {
+		return result
+	}
+	defer call.end()
+	start := time.Now()
+	if call.direct(0) {
+		err = call.result(call.logCreator.LogIt(level, call.logMessage))
+	} else {
+		err = call.dispatch(0, true, 1)
+	}
+	err = call.fallBack(0, true, 1, err)
+	result.Duration = time.Since(start)
+	result.Logged, result.CreatorUsed, result.FallbackUsed = err == nil, call.logCreator.LogName(), call.fellBack
+	return result
+}
+
+// LogItWithCorrelation logs a message with a correlation ID attached as a field.
+//
+// The message is converted to a types.LogEntry carrying fields["correlation_id"], keeping the fields of
+// a types.LogEntry or ContextualMessage, so services that pass correlation IDs as plain strings do not
+// need a context or a ContextualLogtor to attach them.
+//
+// Parameters:
+//   - correlationID: The correlation ID of the operation being logged.
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged, false otherwise.
+func (l *Logtor) LogItWithCorrelation(correlationID string, level types.LogLevel, logMessage interface{}) bool {
+	if !l.isLoggable(level) {
+		return false
+	}
+	entry := toLogEntry(logMessage, 1)
+	entry.Fields["correlation_id"] = correlationID
+	return l.LogIt(level, entry)
+}
+
+// LogItStruct logs a struct as a types.LogEntry carrying its exported fields.
+//
+// The fields are extracted with an encoding/json round trip, so they are keyed by their JSON names and
+// json tags, including omitempty and "-", are honoured. The message of the entry is the name of the
+// struct type. Values that are not a struct or a non-nil pointer to a struct, and structs that cannot be
+// encoded as JSON, are logged as they are with LogIt.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - v: The struct to be logged.
+//
+// Returns:
+//   - bool: True if the message was successfully logged, false otherwise.
+func (l *Logtor) LogItStruct(level types.LogLevel, v interface{}) bool {
+	if !l.isLoggable(level) {
+		return false
+	}
+	value := reflect.ValueOf(v)
+	if value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return l.LogIt(level, v)
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return l.LogIt(level, v)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return l.LogIt(level, v)
+	}
+	return l.LogIt(level, types.LogEntry{Message: value.Type().Name(), Fields: fields})
+}
+
+// LogItIfChanged logs a message only when it differs from the last message logged for the same key.
+//
+// This is useful for polling loops that would otherwise log the same value over and over. Messages are
+// compared by their "%+v" representation, and a message only counts as logged when LogIt succeeds.
+//
+// Parameters:
+//   - key: The key identifying the logged value.
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was logged; false if it was unchanged or not logged by LogIt.
+func (l *Logtor) LogItIfChanged(key string, level types.LogLevel, logMessage interface{}) bool {
+	if !l.isLoggable(level) {
+		return false
+	}
+	value := fmt.Sprintf("%+v", logMessage)
+	if last, ok := l.lastLogged.Load(key); ok && last.(string) == value {
+		return false
+	}
+	if !l.LogIt(level, logMessage) {
+		return false
+	}
+	l.lastLogged.Store(key, value)
+	return true
+}
+
+// ResetChangeDetection forgets the last message logged for the key, so the next LogItIfChanged call logs.
+//
+// Parameters:
+//   - key: The key identifying the logged value.
+func (l *Logtor) ResetChangeDetection(key string) {
+	l.lastLogged.Delete(key)
+}
+
+// LogItOnce logs a message only the first time it is called with the given key.
+//
+// This is useful for errors that should be reported once, such as initialization failures, and then
+// suppressed. Calls for a level that is not enabled do not count, so the message is still logged by a
+// later call once the level is enabled. When called concurrently with the same key, only one call logs.
+//
+// Parameters:
+//   - key: The key identifying the message.
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was logged; false if the key was already logged or LogIt did not log it.
+func (l *Logtor) LogItOnce(key string, level types.LogLevel, logMessage interface{}) bool {
+	if !l.isLoggable(level) {
+		return false
+	}
+	if _, loaded := l.loggedOnce.LoadOrStore(key, struct{}{}); loaded {
+		return false
+	}
+	return l.LogIt(level, logMessage)
+}
+
+// ResetOnce forgets that the key was logged, so the next LogItOnce call with the key logs again.
+//
+// Parameters:
+//   - key: The key identifying the message.
+func (l *Logtor) ResetOnce(key string) {
+	l.loggedOnce.Delete(key)
+}
+
+// LogItEvery logs a message at most once per interval for the given key.
+//
+// Calls for the key are suppressed until interval has elapsed since the last time it was logged, which
+// is useful for warnings that fire in tight loops, such as "disk nearly full". Calls for a level that is
+// not enabled do not count. When called concurrently with the same key, only one call logs.
+//
+// Parameters:
+//   - key: The key identifying the message.
+//   - interval: The minimum time between two logged messages for the key.
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was logged; false if it was suppressed or LogIt did not log it.
+func (l *Logtor) LogItEvery(key string, interval time.Duration, level types.LogLevel, logMessage interface{}) bool {
+	if !l.isLoggable(level) {
+		return false
+	}
+	now := l.now()
+	if last, ok := l.loggedEvery.Load(key); ok {
+		if now.Sub(last.(time.Time)) < interval || !l.loggedEvery.CompareAndSwap(key, last, now) {
+			return false
+		}
+	} else if _, loaded := l.loggedEvery.LoadOrStore(key, now); loaded {
+		return false
+	}
+	return l.LogIt(level, logMessage)
 }
 
 // AddLogcreators registers one or more log creators with the Logtor instance.
@@ -171,27 +965,411 @@ func (l *Logtor) LogItWithCallDepth(level types.LogLevel, callDepth int, logMess
 // identified by their names and can be used for logging messages. If no active log creator
 // is currently set, the first added log creator becomes the active one.
 //
+// AddLogCreators calls TryAddLogCreators and ignores its errors; use TryAddLogCreators to find out
+// which log creators were rejected. Calling it without log creators, or with nil log creators only,
+// registers nothing and leaves the active log creator unchanged.
+//
+// Parameters:
+//   - logCreators: One or more LogCreator instances to be added to the Logtor.
+//
+// Returns:
+//   - int: The number of log creators that were registered, so callers can detect that nothing was added.
+func (l *Logtor) AddLogCreators(logCreators ...LogCreator) int {
+	added, _ := l.TryAddLogCreators(logCreators...)
+	return len(added)
+}
+
+// TryAddLogCreators registers one or more log creators and reports which of them were added.
+//
+// Nil log creators are skipped. A log creator is rejected if its name is not valid according to
+// types.LogCreatorName.Validate, or if a log creator with the same name is already registered,
+// including one added earlier in the same call. If no active log creator is currently set, the first
+// added log creator becomes the active one; this also applies while the console log creator registered
+// by NewWithDefault is active.
+//
 // Parameters:
 //   - logCreators: One or more LogCreator instances to be added to the Logtor.
-func (l *Logtor) AddLogCreators(logCreators ...LogCreator) {
+//
+// Returns:
+//   - added: The names of the log creators that were registered, in order.
+//   - errs: One error for every log creator that was rejected.
+func (l *Logtor) TryAddLogCreators(logCreators ...LogCreator) (added []types.LogCreatorName, errs []error) {
 	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
 	for _, logCreator := range logCreators {
-		if logCreator != nil && !reflect.ValueOf(logCreator).IsNil() {
-			l.logCreatorList[logCreator.LogName()] = logCreator
+		if isNilCreator(logCreator) {
+			continue
+		}
+		name := logCreator.LogName()
+		if err := name.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("logtor: %w", err))
+			continue
+		}
+		if _, ok := l.logCreatorList[name]; ok {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrLogCreatorExists, name))
+			continue
+		}
+		l.logCreatorList[name] = logCreator
+		added = append(added, name)
+	}
+	if len(added) > 0 {
+		l.rebuildRoutedCreators()
+		if current := l.currentLogCreator.Load(); current == nil || l.isInitialDefault(current) {
+			l.currentLogCreator.Store(l.logCreatorList[added[0]])
+		}
+	}
+	return added, errs
+}
+
+// ReplaceLogCreator registers a log creator in place of the one registered under the same name and
+// shuts the displaced log creator down.
+//
+// TryAddLogCreators rejects a log creator whose name is taken, so the registered one is never dropped
+// without being shut down; use ReplaceLogCreator to swap it deliberately. The new log creator takes the
+// place of the old one wherever it is used, as the active, default or routed log creator, and keeps its
+// level routes and per-creator log level, while the middleware chain of the old one is dropped with it.
+// ReplaceLogCreator waits for log calls that are in flight to return before shutting the old log creator
+// down. If no log creator is registered under the name, the log creator is added like with AddLogCreators.
+//
+// Parameters:
+//   - logCreator: The log creator to register.
+//
+// Returns:
+//   - replaced: True if a log creator was displaced and shut down.
+//   - err: An error if the log creator is nil or its name is not valid. Nothing is changed if an error is returned.
+func (l *Logtor) ReplaceLogCreator(logCreator LogCreator) (replaced bool, err error) {
+	if isNilCreator(logCreator) {
+		return false, fmt.Errorf("logtor: log creator is nil")
+	}
+	name := logCreator.LogName()
+	if err := name.Validate(); err != nil {
+		return false, fmt.Errorf("logtor: %w", err)
+	}
+	l.configMutex.Lock()
+	defer l.configMutex.Unlock()
+
+	l.changeMutex.Lock()
+	old, ok := l.logCreatorList[name]
+	if ok && sameCreator(old, logCreator) {
+		l.changeMutex.Unlock()
+		return false, nil
+	}
+	l.logCreatorList[name] = logCreator
+	delete(l.creatorChains, name)
+	delete(l.appliedCreators, name)
+	if current := l.currentLogCreator.Load(); current == nil || (ok && sameCreator(current, old)) || (!ok && l.isInitialDefault(current)) {
+		l.currentLogCreator.Store(logCreator)
+	}
+	if defaultCreator := l.defaultCreator.Load(); ok && defaultCreator != nil && sameCreator(defaultCreator, old) {
+		l.defaultCreator.Store(logCreator)
+	}
+	l.rebuildRoutedCreators()
+	l.changeMutex.Unlock()
+	if !ok {
+		return false, nil
+	}
+
+	l.waitForLogCalls()
+	old.Shutdown()
+	return true, nil
+}
+
+// ExchangeLogCreator registers a log creator in place of a registered one without shutting the old one down,
+// so the caller keeps using or closing it.
+//
+// The new log creator takes the place of the old one wherever it is used, as the active, default, routed
+// or fallback log creator, and takes over its level routes, per-creator log level and filter, while the
+// middleware chain of the old one is dropped with it. Other log creators are left as they are. The swap
+// is atomic for log calls, which see either the old or the new log creator.
+//
+// Parameters:
+//   - oldName: The name of the registered log creator to swap out.
+//   - logCreator: The log creator to register in its place. Its name may differ from oldName, but must not
+//     be taken by another log creator.
+//
+// Returns:
+//   - bool: True if the log creators were swapped; false if no log creator is registered under oldName,
+//     or the new log creator is nil, has an invalid name or a name taken by another log creator.
+func (l *Logtor) ExchangeLogCreator(oldName types.LogCreatorName, logCreator LogCreator) bool {
+	if isNilCreator(logCreator) {
+		return false
+	}
+	name := logCreator.LogName()
+	if name.Validate() != nil {
+		return false
+	}
+	l.configMutex.Lock()
+	defer l.configMutex.Unlock()
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+
+	old, ok := l.logCreatorList[oldName]
+	if !ok {
+		return false
+	}
+	if _, taken := l.logCreatorList[name]; taken && name != oldName {
+		return false
+	}
+	delete(l.logCreatorList, oldName)
+	delete(l.creatorChains, oldName)
+	delete(l.appliedCreators, oldName)
+	l.logCreatorList[name] = logCreator
+	if name != oldName {
+		for level, routed := range l.levelRoutes {
+			if routed == oldName {
+				l.levelRoutes[level] = name
+			}
+		}
+		if level, ok := l.creatorLevels[oldName]; ok {
+			delete(l.creatorLevels, oldName)
+			l.creatorLevels[name] = level
+		}
+		if filters := l.creatorFilters.Load(); filters != nil {
+			if filter, ok := (*filters)[oldName]; ok {
+				l.storeCreatorFilter(oldName, nil)
+				l.storeCreatorFilter(name, filter)
+			}
+		}
+		for i, fallback := range l.fallbackNames {
+			if fallback == oldName {
+				l.fallbackNames[i] = name
+			}
+		}
+		if l.previousLogCreator == oldName {
+			l.previousLogCreator = name
+		}
+	}
+	if current := l.currentLogCreator.Load(); current != nil && sameCreator(current, old) {
+		l.currentLogCreator.Store(logCreator)
+	}
+	if defaultCreator := l.defaultCreator.Load(); defaultCreator != nil && sameCreator(defaultCreator, old) {
+		l.defaultCreator.Store(logCreator)
+	}
+	l.rebuildRoutedCreators()
+	l.rebuildCreatorLevelRanks()
+	return true
+}
+
+// RemoveLogCreator removes a registered log creator and shuts it down.
+//
+// If the log creator is active, the default creator becomes active, unless it is the one being removed.
+// Level routes, the per-creator log level and the middleware chain of the log creator are removed with it,
+// and a log creator wrapped with WrapCreator is shut down once, through the outermost layer of its chain.
+// RemoveLogCreator waits for log calls that are in flight to return before shutting the log creator down.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator to remove.
+//
+// Returns:
+//   - error: ErrUnknownLogCreator if no log creator is registered under the name.
+func (l *Logtor) RemoveLogCreator(logCreatorName types.LogCreatorName) error {
+	l.configMutex.Lock()
+	defer l.configMutex.Unlock()
+
+	l.changeMutex.Lock()
+	logCreator, ok := l.logCreatorList[logCreatorName]
+	if !ok {
+		l.changeMutex.Unlock()
+		return fmt.Errorf("%w: %s", ErrUnknownLogCreator, logCreatorName)
+	}
+	delete(l.logCreatorList, logCreatorName)
+	delete(l.creatorLevels, logCreatorName)
+	delete(l.creatorChains, logCreatorName)
+	l.storeCreatorFilter(logCreatorName, nil)
+	delete(l.appliedCreators, logCreatorName)
+	for level, name := range l.levelRoutes {
+		if name == logCreatorName {
+			delete(l.levelRoutes, level)
 		}
 	}
+	if defaultCreator := l.defaultCreator.Load(); defaultCreator != nil && sameCreator(defaultCreator, logCreator) {
+		l.defaultCreator.Store(nil)
+	}
+	if current := l.currentLogCreator.Load(); current != nil && sameCreator(current, logCreator) {
+		l.currentLogCreator.Store(l.defaultCreator.Load())
+	}
+	if l.previousLogCreator == logCreatorName {
+		l.previousLogCreator = ""
+	}
+	l.rebuildRoutedCreators()
+	l.rebuildCreatorLevelRanks()
 	l.changeMutex.Unlock()
-	if l.currentLogCreator == nil {
-		l.ChangeLogCreator(logCreators[0].LogName())
+
+	l.waitForLogCalls()
+	logCreator.Shutdown()
+	return nil
+}
+
+// isInitialDefault reports whether the log creator is the console log creator registered by
+// NewWithDefault, which gives way to the first log creators added.
+func (l *Logtor) isInitialDefault(logCreator LogCreator) bool {
+	return logCreator.LogName() == defaultCreatorName && sameCreator(logCreator, l.defaultCreator.Load())
+}
+
+// isNilCreator reports whether the log creator is nil or a typed nil, such as a nil *BaseCreator.
+func isNilCreator(logCreator LogCreator) bool {
+	if logCreator == nil {
+		return true
+	}
+	value := reflect.ValueOf(logCreator)
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+		return value.IsNil()
+	}
+	return false
+}
+
+// sameCreator reports whether both values are the same log creator, without panicking on log creators
+// of types that cannot be compared.
+func sameCreator(a, b LogCreator) bool {
+	if reflect.TypeOf(a) != reflect.TypeOf(b) || !reflect.TypeOf(a).Comparable() {
+		return false
 	}
+	return a == b
 }
 
 // Shutdown gracefully shuts down all registered log creators.
 //
-// Use this method to perform any necessary cleanup or shutdown operations for all registered log creators.
-// It iterates through the list of log creators and calls their respective shutdown methods.
+// It is ShutdownCtx with a background context, so it waits for every log creator to stop.
 func (l *Logtor) Shutdown() {
+	l.ShutdownCtx(context.Background())
+}
+
+// ShutdownCtx gracefully shuts down all registered log creators, waiting at most until the context is done.
+//
+// It stops accepting log calls, waits for log calls that are in flight to return and runs the shutdown
+// hooks. It then flushes every log creator implementing Flusher and calls the Shutdown method of every
+// log creator. The summaries of the messages dropped by rate limiting and deduplication are logged
+// before log calls stop being accepted, and their timers are stopped. Log calls made after ShutdownCtx has started return false without reaching a log creator,
+// and calling it again has no effect. Log creators that are still stopping when the context is done keep
+// stopping in the background.
+//
+// Parameters:
+//   - ctx: The context bounding the wait.
+//
+// Returns:
+//   - error: The flush errors and, if the context was done first, an error for every log creator that
+//     had not stopped, joined, or nil.
+func (l *Logtor) ShutdownCtx(ctx context.Context) error {
+	if !l.shutDown.Load() {
+		l.flushSummaries()
+	}
+	l.shutdownMutex.Lock()
+	if l.shutDown.Load() {
+		l.shutdownMutex.Unlock()
+		return nil
+	}
+	l.shutDown.Store(true)
+	hooks := l.shutdownHooks
+	l.shutdownHooks = nil
+	if l.timersDone != nil {
+		close(l.timersDone)
+	}
+	l.shutdownMutex.Unlock()
+
+	// The log creators are shut down without holding changeMutex, so a log creator calling back into
+	// the Logtor from its Shutdown method does not take it a second time.
+	l.changeMutex.RLock()
+	logCreators := make([]LogCreator, 0, len(l.logCreatorList))
+	for _, logCreator := range l.logCreatorList {
+		logCreators = append(logCreators, logCreator)
+	}
+	l.changeMutex.RUnlock()
+	sort.Slice(logCreators, func(i, j int) bool { return logCreators[i].LogName() < logCreators[j].LogName() })
+
+	type stopped struct {
+		name types.LogCreatorName
+		err  error
+	}
+	results := make(chan stopped, len(logCreators))
+	go func() {
+		defer close(results)
+		l.waitForLogCalls()
+		for _, hook := range hooks {
+			hook()
+		}
+		for _, logCreator := range logCreators {
+			var err error
+			if flusher, ok := logCreator.(Flusher); ok {
+				if flushErr := flusher.Flush(); flushErr != nil {
+					err = fmt.Errorf("logtor: flushing %s: %w", logCreator.LogName(), flushErr)
+				}
+			}
+			logCreator.Shutdown()
+			results <- stopped{name: logCreator.LogName(), err: err}
+		}
+	}()
+
+	var errs []error
+	remaining := make(map[types.LogCreatorName]bool, len(logCreators))
+	for _, logCreator := range logCreators {
+		remaining[logCreator.LogName()] = true
+	}
+	for {
+		select {
+		case result, ok := <-results:
+			if !ok {
+				return errors.Join(errs...)
+			}
+			delete(remaining, result.name)
+			if result.err != nil {
+				errs = append(errs, result.err)
+			}
+		case <-ctx.Done():
+			if len(remaining) == 0 {
+				errs = append(errs, fmt.Errorf("logtor: shutdown did not complete: %w", ctx.Err()))
+			}
+			for _, logCreator := range logCreators {
+				if remaining[logCreator.LogName()] {
+					errs = append(errs, fmt.Errorf("logtor: %s did not stop: %w", logCreator.LogName(), ctx.Err()))
+				}
+			}
+			return errors.Join(errs...)
+		}
+	}
+}
+
+// Flush flushes every registered log creator that buffers entries.
+//
+// Returns:
+//   - error: The errors of the log creators that failed to flush, joined, or nil.
+func (l *Logtor) Flush() error {
+	l.changeMutex.RLock()
+	flushers := make([]Flusher, 0, len(l.logCreatorList))
 	for _, logCreator := range l.logCreatorList {
-		logCreator.Shutdown()
+		if flusher, ok := logCreator.(Flusher); ok {
+			flushers = append(flushers, flusher)
+		}
 	}
+	l.changeMutex.RUnlock()
+
+	var errs []error
+	for _, flusher := range flushers {
+		if err := flusher.Flush(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// beginLog counts a log call in flight. It returns false once Shutdown has started; otherwise the caller
+// must pass the returned epoch to endLog when it no longer uses the log creator. It never blocks, so a
+// log call may be made from within another one.
+func (l *Logtor) beginLog() (uint32, bool) {
+	epoch := l.logCalls.enter()
+	if l.shutDown.Load() {
+		l.logCalls.exit(epoch)
+		return 0, false
+	}
+	return epoch, true
+}
+
+// endLog ends a log call counted by beginLog.
+func (l *Logtor) endLog(epoch uint32) {
+	l.logCalls.exit(epoch)
+}
+
+// waitForLogCalls blocks until the log calls that were in flight when it was called have returned.
+func (l *Logtor) waitForLogCalls() {
+	l.logCalls.wait()
 }
@@ -1,6 +1,17 @@
 package logtor_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -136,3 +147,888 @@ func TestLogtorUsingAllCreators(t *testing.T) {
 	newLogtor.LogIt(types.INFO, "Example Test Log String")
 	newLogtor.LogIt(types.TRACE, "Example Test Log String")
 }
+
+func TestLogtorExplain(t *testing.T) {
+	newLogtor := logtor.New()
+
+	expected := "Selected level: WARN. Will log: PANIC, FATAL, ERROR, WARN. Will suppress: DEBUG, INFO, TRACE."
+	if result := newLogtor.Explain(types.WARN); result != expected {
+		t.Errorf("unexpected explanation: got %q want %q", result, expected)
+	}
+
+	expected = "Selected level: NONE. Will log: none. Will suppress: PANIC, FATAL, ERROR, WARN, DEBUG, INFO, TRACE."
+	if result := newLogtor.Explain(types.NONE); result != expected {
+		t.Errorf("unexpected explanation: got %q want %q", result, expected)
+	}
+}
+
+func TestLogtorLogItIfChanged(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.TRACE)
+
+	if !newLogtor.LogItIfChanged("workers", types.INFO, 3) {
+		t.Error("first value should be logged")
+	}
+	if newLogtor.LogItIfChanged("workers", types.INFO, 3) {
+		t.Error("unchanged value should be suppressed")
+	}
+	if !newLogtor.LogItIfChanged("queue", types.INFO, 3) {
+		t.Error("keys should be tracked independently")
+	}
+	if !newLogtor.LogItIfChanged("workers", types.INFO, 4) {
+		t.Error("changed value should be logged")
+	}
+	newLogtor.ResetChangeDetection("workers")
+	if !newLogtor.LogItIfChanged("workers", types.INFO, 4) {
+		t.Error("value should be logged after ResetChangeDetection")
+	}
+	if len(recorder.Messages()) != 4 {
+		t.Errorf("unexpected messages: %v", recorder.Messages())
+	}
+}
+
+func TestLogtorIsLevelEnabled(t *testing.T) {
+	newLogtor := logtor.New()
+	for _, level := range types.LogLevelList {
+		if newLogtor.IsLevelEnabled(level) {
+			t.Errorf("%s should be disabled at NONE", level)
+		}
+	}
+
+	for _, selected := range types.LogLevelList {
+		newLogtor.SetLogLevel(selected)
+		for _, level := range types.LogLevelList {
+			if newLogtor.IsLevelEnabled(level) != types.IsLogLevelAcceptable(selected, level) {
+				t.Errorf("IsLevelEnabled(%s) at %s disagrees with IsLogLevelAcceptable", level, selected)
+			}
+		}
+	}
+	if newLogtor.IsLevelEnabled("LOUD") {
+		t.Error("unknown levels should be disabled")
+	}
+}
+
+func BenchmarkLogtorLogItFiltered(b *testing.B) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(newRecordingCreator("Recorder"))
+	newLogtor.SetLogLevel(types.ERROR)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		newLogtor.LogIt(types.DEBUG, "Example Debug Message")
+	}
+	if allocs := testing.AllocsPerRun(100, func() { newLogtor.LogIt(types.DEBUG, "Example Debug Message") }); allocs != 0 {
+		b.Errorf("filtered LogIt allocates: %v allocs/op", allocs)
+	}
+}
+
+// discardCreator is a LogCreator that drops every message, so benchmarks measure Logtor alone.
+type discardCreator struct {
+	name types.LogCreatorName
+}
+
+func (dc *discardCreator) LogIt(level types.LogLevel, logMessage interface{}) bool { return true }
+
+func (dc *discardCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	return true
+}
+
+func (dc *discardCreator) LogName() types.LogCreatorName { return dc.name }
+
+func (dc *discardCreator) SetCallDepth(callDepth int) {}
+
+func (dc *discardCreator) CallDepth() int { return 2 }
+
+func (dc *discardCreator) IsReady() bool { return true }
+
+func (dc *discardCreator) Shutdown() {}
+
+// changeCreatorsUntil flips the active log creator between the given names until stop is closed.
+func changeCreatorsUntil(l *logtor.Logtor, stop chan struct{}, names ...types.LogCreatorName) *sync.WaitGroup {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				l.ChangeLogCreator(names[i%len(names)])
+			}
+		}
+	}()
+	return &wg
+}
+
+func TestLogtorChangeLogCreatorWhileLogging(t *testing.T) {
+	first, second := newRecordingCreator("First"), newRecordingCreator("Second")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(first, second)
+	newLogtor.SetLogLevel(types.TRACE)
+
+	stop := make(chan struct{})
+	changer := changeCreatorsUntil(newLogtor, stop, "First", "Second")
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				if !newLogtor.LogIt(types.INFO, "Example Log Message") {
+					t.Error("Log not recorded")
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	changer.Wait()
+
+	if total := len(first.Messages()) + len(second.Messages()); total != 800 {
+		t.Errorf("unexpected message count: %d", total)
+	}
+}
+
+func TestLogtorSetLogLevelWhileLogging(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(&discardCreator{name: "Discard"})
+
+	stop := make(chan struct{})
+	var toggler sync.WaitGroup
+	toggler.Add(1)
+	go func() {
+		defer toggler.Done()
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+				newLogtor.SetLogLevel(types.LogLevelList[1+i%(len(types.LogLevelList)-1)])
+			}
+		}
+	}()
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				newLogtor.LogIt(types.INFO, "Example Log Message")
+				if level := newLogtor.LogLevel(); !level.IsValid() {
+					t.Errorf("invalid log level: %q", level)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(stop)
+	toggler.Wait()
+}
+
+// BenchmarkLogtorLogItWhileChanging logs from 8 goroutines while another goroutine keeps changing
+// the active log creator.
+func BenchmarkLogtorLogItWhileChanging(b *testing.B) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(&discardCreator{name: "First"}, &discardCreator{name: "Second"})
+	newLogtor.SetLogLevel(types.TRACE)
+
+	const goroutines = 8
+	stop := make(chan struct{})
+	changer := changeCreatorsUntil(newLogtor, stop, "First", "Second")
+	b.ReportAllocs()
+	b.ResetTimer()
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < b.N/goroutines+1; i++ {
+				newLogtor.LogIt(types.INFO, "Example Log Message")
+			}
+		}()
+	}
+	wg.Wait()
+	b.StopTimer()
+	close(stop)
+	changer.Wait()
+}
+
+func TestLogtorLogItAttempt(t *testing.T) {
+	primary, fallback := newRecordingCreator("Primary"), newRecordingCreator("Fallback")
+	newLogtor := logtor.New().WithDefaultCreator(fallback)
+	newLogtor.AddLogCreators(primary)
+	newLogtor.SetLogLevel(types.WARN)
+
+	if result := newLogtor.LogItAttempt(types.INFO, "filtered"); !result.FilteredByLevel || result.Logged || result.CreatorUsed != "" {
+		t.Errorf("unexpected result for a filtered level: %+v", result)
+	}
+	if result := newLogtor.LogItAttempt(types.ERROR, "primary"); !result.Logged || result.CreatorUsed != "Primary" || result.FallbackUsed {
+		t.Errorf("unexpected result for the active creator: %+v", result)
+	}
+
+	primary.mutex.Lock()
+	primary.notReady = true
+	primary.mutex.Unlock()
+	if result := newLogtor.LogItAttempt(types.ERROR, "fallback"); !result.Logged || result.CreatorUsed != "Fallback" || !result.FallbackUsed {
+		t.Errorf("unexpected result for the default creator: %+v", result)
+	}
+	if len(primary.Messages()) != 1 || len(fallback.Messages()) != 1 {
+		t.Errorf("unexpected messages: %v %v", primary.Messages(), fallback.Messages())
+	}
+}
+
+func TestLogtorTryAddLogCreators(t *testing.T) {
+	var typedNil *recordingCreator
+	newLogtor := logtor.New()
+	added, errs := newLogtor.TryAddLogCreators(
+		nil,
+		typedNil,
+		newRecordingCreator("First"),
+		newRecordingCreator("has space"),
+		newRecordingCreator(""),
+		newRecordingCreator("First"),
+		newRecordingCreator("Second"),
+	)
+	if len(added) != 2 || added[0] != "First" || added[1] != "Second" {
+		t.Errorf("unexpected added creators: %v", added)
+	}
+	if len(errs) != 3 {
+		t.Errorf("expected 3 errors, got %v", errs)
+	}
+	if newLogtor.LogCreator() == nil || newLogtor.LogCreator().LogName() != "First" {
+		t.Error("the first added creator should become active")
+	}
+
+	if _, errs := newLogtor.TryAddLogCreators(newRecordingCreator("Second")); len(errs) != 1 {
+		t.Errorf("registered names should be rejected, got %v", errs)
+	}
+	newLogtor.AddLogCreators(typedNil)
+}
+
+func TestLogtorAddLogCreatorsCount(t *testing.T) {
+	var typedNil *recordingCreator
+	tests := []struct {
+		name        string
+		logCreators []logtor.LogCreator
+		expected    int
+		active      types.LogCreatorName
+	}{
+		{name: "empty", logCreators: nil, expected: 0},
+		{name: "nil", logCreators: []logtor.LogCreator{nil}, expected: 0},
+		{name: "typed nil", logCreators: []logtor.LogCreator{typedNil, nil}, expected: 0},
+		{name: "mixed", logCreators: []logtor.LogCreator{nil, typedNil, newRecordingCreator("First"), newRecordingCreator("")}, expected: 1, active: "First"},
+		{name: "duplicate", logCreators: []logtor.LogCreator{newRecordingCreator("First"), newRecordingCreator("First"), newRecordingCreator("Second")}, expected: 2, active: "First"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			newLogtor := logtor.New()
+			if added := newLogtor.AddLogCreators(test.logCreators...); added != test.expected {
+				t.Errorf("expected %d added creators, got %d", test.expected, added)
+			}
+			active := newLogtor.LogCreator()
+			if test.active == "" {
+				if active != nil {
+					t.Errorf("no creator should become active, got %s", active.LogName())
+				}
+			} else if active == nil || active.LogName() != test.active {
+				t.Errorf("expected %s to become active, got %v", test.active, active)
+			}
+		})
+	}
+}
+
+func TestLogtorExchangeLogCreator(t *testing.T) {
+	oldCreator, newCreator := newRecordingCreator("Kafka"), newRecordingCreator("KafkaV2")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(oldCreator, newRecordingCreator("Console"))
+	newLogtor.ChangeLogCreator("Kafka")
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.RouteLevels("Kafka", types.ERROR)
+
+	if newLogtor.ExchangeLogCreator("Missing", newCreator) || newLogtor.ExchangeLogCreator("Kafka", newRecordingCreator("Console")) {
+		t.Error("an unknown log creator or a taken name should not be exchanged")
+	}
+	if !newLogtor.ExchangeLogCreator("Kafka", newCreator) {
+		t.Fatal("the log creator should be exchanged")
+	}
+	if newLogtor.LogCreator() != newCreator || oldCreator.Shutdowns() != 0 {
+		t.Error("the new log creator should become active without shutting the old one down")
+	}
+	newLogtor.ChangeLogCreator("Console")
+	newLogtor.LogIt(types.ERROR, "routed")
+	if messages := newCreator.Messages(); len(messages) != 1 || len(oldCreator.Messages()) != 0 {
+		t.Errorf("the level routes should follow the new log creator, got %q", messages)
+	}
+}
+
+func TestLogtorReplaceLogCreator(t *testing.T) {
+	original, other := newRecordingCreator("File"), newRecordingCreator("Other")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(original, other)
+	newLogtor.RouteLevels("File", types.ERROR)
+	newLogtor.SetLogLevel(types.INFO)
+
+	if added := newLogtor.AddLogCreators(newRecordingCreator("File")); added != 0 || original.Shutdowns() != 0 {
+		t.Fatalf("a duplicate name should be rejected without touching the registered creator: added=%d", added)
+	}
+
+	replacement := newRecordingCreator("File")
+	replaced, err := newLogtor.ReplaceLogCreator(replacement)
+	if err != nil || !replaced {
+		t.Fatalf("unexpected result: replaced=%t err=%v", replaced, err)
+	}
+	if original.Shutdowns() != 1 {
+		t.Errorf("the displaced creator should be shut down once, got %d", original.Shutdowns())
+	}
+	if newLogtor.LogCreator() != replacement {
+		t.Error("the replacement should become active in place of the displaced creator")
+	}
+	newLogtor.ChangeLogCreator("Other")
+	newLogtor.LogIt(types.ERROR, "routed")
+	if len(replacement.Messages()) != 1 || len(original.Messages()) != 0 {
+		t.Errorf("level routes should resolve to the replacement: %v %v", replacement.Messages(), original.Messages())
+	}
+
+	rw := httptest.NewRecorder()
+	newLogtor.GetLogCreatorList(rw, httptest.NewRequest(http.MethodGet, "/log-creators", nil))
+	if list := rw.Body.String(); list != `["File","Other"]` {
+		t.Errorf("unexpected list: %s", list)
+	}
+
+	if replaced, err := newLogtor.ReplaceLogCreator(replacement); replaced || err != nil || replacement.Shutdowns() != 0 {
+		t.Errorf("replacing a creator with itself should do nothing: replaced=%t err=%v", replaced, err)
+	}
+	if replaced, err := newLogtor.ReplaceLogCreator(newRecordingCreator("New")); replaced || err != nil {
+		t.Errorf("an unknown name should be added: replaced=%t err=%v", replaced, err)
+	}
+	if _, err := newLogtor.ReplaceLogCreator(nil); err == nil {
+		t.Error("a nil creator should be rejected")
+	}
+}
+
+func TestLogtorChangeLogCreatorIfReady(t *testing.T) {
+	first, second := newRecordingCreator("First"), newRecordingCreator("Second")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(first, second)
+
+	if changed, ready := newLogtor.ChangeLogCreatorIfReady("Missing"); changed || ready {
+		t.Errorf("unknown creator: changed %t, ready %t", changed, ready)
+	}
+	if changed, ready := newLogtor.ChangeLogCreatorIfReady("First"); changed || !ready {
+		t.Errorf("active creator: changed %t, ready %t", changed, ready)
+	}
+
+	second.mutex.Lock()
+	second.notReady = true
+	second.mutex.Unlock()
+	if changed, ready := newLogtor.ChangeLogCreatorIfReady("Second"); changed || ready {
+		t.Errorf("creator not ready: changed %t, ready %t", changed, ready)
+	}
+	if newLogtor.LogCreator().LogName() != "First" {
+		t.Error("a creator that is not ready should not become active")
+	}
+
+	second.mutex.Lock()
+	second.notReady = false
+	second.mutex.Unlock()
+	if changed, ready := newLogtor.ChangeLogCreatorIfReady("Second"); !changed || !ready {
+		t.Errorf("ready creator: changed %t, ready %t", changed, ready)
+	}
+	if newLogtor.LogCreator().LogName() != "Second" {
+		t.Error("the ready creator should become active")
+	}
+	if previous, err := newLogtor.RevertLogCreator(); err != nil || previous != "Second" || newLogtor.LogCreator().LogName() != "First" {
+		t.Errorf("the change should be revertible: previous %s, err %v", previous, err)
+	}
+}
+
+// closingCreator panics when it is used after Shutdown, like a producer whose input channel was closed.
+type closingCreator struct {
+	discardCreator
+	closed atomic.Bool
+	logged atomic.Int64
+}
+
+func (cc *closingCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	if cc.closed.Load() {
+		panic("log creator used after shutdown")
+	}
+	cc.logged.Add(1)
+	return true
+}
+
+func (cc *closingCreator) Shutdown() { cc.closed.Store(true) }
+
+func TestLogtorShutdownWhileLogging(t *testing.T) {
+	for round := 0; round < 20; round++ {
+		creator := &closingCreator{discardCreator: discardCreator{name: "Closing"}}
+		newLogtor := logtor.New()
+		newLogtor.AddLogCreators(creator)
+		newLogtor.SetLogLevel(types.TRACE)
+
+		var loggers sync.WaitGroup
+		start := make(chan struct{})
+		for g := 0; g < 16; g++ {
+			loggers.Add(1)
+			go func() {
+				defer loggers.Done()
+				<-start
+				for i := 0; i < 200; i++ {
+					newLogtor.LogIt(types.INFO, i)
+					newLogtor.LogItAttempt(types.INFO, i)
+				}
+			}()
+		}
+		close(start)
+		newLogtor.Shutdown()
+		loggers.Wait()
+
+		if newLogtor.LogIt(types.INFO, "after shutdown") {
+			t.Fatal("LogIt should return false after Shutdown")
+		}
+		if result := newLogtor.LogItAttempt(types.INFO, "after shutdown"); result.Logged || result.CreatorUsed != "" {
+			t.Fatalf("unexpected result after Shutdown: %+v", result)
+		}
+		newLogtor.Shutdown()
+	}
+}
+
+// stuckCreator is a discardCreator whose Shutdown blocks until release is closed.
+type stuckCreator struct {
+	discardCreator
+	release chan struct{}
+}
+
+func (sc *stuckCreator) Shutdown() { <-sc.release }
+
+func TestLogtorShutdownCtx(t *testing.T) {
+	buffered := &flushingCreator{recordingCreator: newRecordingCreator("Buffered"), flushErr: errors.New("upload failed")}
+	stuck := &stuckCreator{discardCreator: discardCreator{name: "Stuck"}, release: make(chan struct{})}
+	defer close(stuck.release)
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(buffered, stuck)
+	newLogtor.SetLogLevel(types.INFO)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := newLogtor.ShutdownCtx(ctx)
+	if !errors.Is(err, buffered.flushErr) || !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "Stuck did not stop") {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if buffered.flushes != 1 {
+		t.Errorf("the buffered log creator should be flushed once, got %d", buffered.flushes)
+	}
+	if newLogtor.LogIt(types.INFO, "after shutdown") {
+		t.Error("LogIt should return false after ShutdownCtx")
+	}
+	if err := newLogtor.ShutdownCtx(context.Background()); err != nil {
+		t.Errorf("a second shutdown should have no effect, got %v", err)
+	}
+}
+
+// reentrantCreator logs through its Logtor from within LogIt once entered is closed, after waiting for
+// proceed, like a log creator reporting its own errors.
+type reentrantCreator struct {
+	discardCreator
+	logtor  *logtor.Logtor
+	entered chan struct{}
+	proceed chan struct{}
+	once    sync.Once
+}
+
+func (rc *reentrantCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	if logMessage != "outer" {
+		return true
+	}
+	rc.once.Do(func() { close(rc.entered) })
+	<-rc.proceed
+	return rc.logtor.LogIt(types.ERROR, "inner")
+}
+
+func TestLogtorNestedLogCallWhileWaitingForLogCalls(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	reentrant := &reentrantCreator{discardCreator: discardCreator{name: "Reentrant"}, logtor: newLogtor,
+		entered: make(chan struct{}), proceed: make(chan struct{})}
+	newLogtor.AddLogCreators(reentrant, &discardCreator{name: "Removed"})
+
+	logged := make(chan bool)
+	go func() { logged <- newLogtor.LogIt(types.INFO, "outer") }()
+	<-reentrant.entered
+	removed := make(chan error)
+	go func() { removed <- newLogtor.RemoveLogCreator("Removed") }()
+	// Give RemoveLogCreator time to start waiting for the outer log call.
+	time.Sleep(20 * time.Millisecond)
+	close(reentrant.proceed)
+
+	select {
+	case ok := <-logged:
+		if !ok {
+			t.Error("the nested log call should be logged")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("a nested log call should not wait for RemoveLogCreator")
+	}
+	if err := <-removed; err != nil {
+		t.Error(err)
+	}
+}
+
+// probeCreator answers log calls with a fixed result, optionally after waiting for release to be closed.
+type probeCreator struct {
+	discardCreator
+	result  bool
+	release chan struct{}
+}
+
+func (pc *probeCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	if pc.release != nil {
+		<-pc.release
+	}
+	return pc.result
+}
+
+func TestLogtorChangeLogCreatorWithRollback(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(
+		&probeCreator{discardCreator: discardCreator{name: "Stable"}, result: true},
+		&probeCreator{discardCreator: discardCreator{name: "Working"}, result: true},
+		&probeCreator{discardCreator: discardCreator{name: "Broken"}, result: false},
+		&probeCreator{discardCreator: discardCreator{name: "Hanging"}, result: true, release: release},
+	)
+	newLogtor.ChangeLogCreator("Stable")
+	newLogtor.SetLogLevel(types.ERROR)
+
+	if changed, err := newLogtor.ChangeLogCreatorWithRollback("Missing", time.Second); changed || err == nil {
+		t.Errorf("unknown creator: changed %t, err %v", changed, err)
+	}
+	if changed, err := newLogtor.ChangeLogCreatorWithRollback("Broken", time.Second); changed || err == nil {
+		t.Errorf("failing probe: changed %t, err %v", changed, err)
+	}
+	if active := newLogtor.LogCreator().LogName(); active != "Stable" {
+		t.Errorf("a failing probe should roll back, active creator is %s", active)
+	}
+	if changed, err := newLogtor.ChangeLogCreatorWithRollback("Hanging", 20*time.Millisecond); changed || err == nil {
+		t.Errorf("hanging probe: changed %t, err %v", changed, err)
+	}
+	if active := newLogtor.LogCreator().LogName(); active != "Stable" {
+		t.Errorf("a timed out probe should roll back, active creator is %s", active)
+	}
+	if changed, err := newLogtor.ChangeLogCreatorWithRollback("Working", time.Second); !changed || err != nil {
+		t.Errorf("working probe: changed %t, err %v", changed, err)
+	}
+	if active := newLogtor.LogCreator().LogName(); active != "Working" {
+		t.Errorf("the probed creator should stay active, active creator is %s", active)
+	}
+}
+
+// switchingCreator runs switchTo when it is probed and fails the probe.
+type switchingCreator struct {
+	discardCreator
+	switchTo func()
+}
+
+func (sc *switchingCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	sc.switchTo()
+	return false
+}
+
+func TestLogtorChangeLogCreatorWithRollbackKeepsConcurrentChange(t *testing.T) {
+	newLogtor := logtor.New()
+	switching := &switchingCreator{discardCreator: discardCreator{name: "Switching"}}
+	switching.switchTo = func() { newLogtor.ChangeLogCreator("Other") }
+	newLogtor.AddLogCreators(&discardCreator{name: "Stable"}, &discardCreator{name: "Other"}, switching)
+	newLogtor.ChangeLogCreator("Stable")
+
+	if changed, err := newLogtor.ChangeLogCreatorWithRollback("Switching", time.Second); changed || err == nil {
+		t.Errorf("failing probe: changed %t, err %v", changed, err)
+	}
+	if active := newLogtor.LogCreator().LogName(); active != "Other" {
+		t.Errorf("the rollback should not undo a concurrent change, active creator is %s", active)
+	}
+}
+
+func TestLogtorDefaultCreator(t *testing.T) {
+	var typedNil *recordingCreator
+	primary, fallback := newRecordingCreator("Primary"), newRecordingCreator("Fallback")
+	newLogtor := logtor.New().WithDefaultCreator(typedNil).WithDefaultCreator(fallback)
+	newLogtor.WithDefaultCreator(newRecordingCreator("Fallback"))
+	newLogtor.AddLogCreators(primary)
+	newLogtor.SetLogLevel(types.TRACE)
+
+	if active := newLogtor.LogCreator().LogName(); active != "Primary" {
+		t.Errorf("the default creator should not become active, active creator is %s", active)
+	}
+	rw := httptest.NewRecorder()
+	newLogtor.GetLogCreatorList(rw, httptest.NewRequest(http.MethodGet, "/log-creators", nil))
+	if rw.Body.String() != `["Fallback","Primary"]` {
+		t.Errorf("the default creator should be listed: %s", rw.Body.String())
+	}
+
+	primary.mutex.Lock()
+	primary.notReady = true
+	primary.mutex.Unlock()
+	newLogtor.LogIt(types.INFO, "fallback")
+	if len(fallback.Messages()) != 1 {
+		t.Errorf("the default creator should receive the message: %v", fallback.Messages())
+	}
+
+	if err := newLogtor.SetDefaultCreator("Missing"); err == nil {
+		t.Error("unknown creators should be rejected")
+	}
+	if err := newLogtor.SetDefaultCreator("Primary"); err != nil {
+		t.Error(err)
+	}
+
+	newLogtor.Shutdown()
+	if primary.Shutdowns() != 1 || fallback.Shutdowns() != 1 {
+		t.Errorf("every creator should be shut down once, got %d and %d", primary.Shutdowns(), fallback.Shutdowns())
+	}
+}
+
+func TestLogtorNewWithDefault(t *testing.T) {
+	newLogtor := logtor.NewWithDefault()
+	if active := newLogtor.LogCreator(); active == nil || active.LogName() != "defaultCreator" {
+		t.Fatalf("the console creator should be active, got %v", active)
+	}
+	if !newLogtor.LogIt(types.INFO, "logged out of the box") {
+		t.Error("LogIt should work without adding log creators")
+	}
+
+	primary := newRecordingCreator("Primary")
+	newLogtor.AddLogCreators(primary)
+	if active := newLogtor.LogCreator().LogName(); active != "Primary" {
+		t.Errorf("the added creator should become active, active creator is %s", active)
+	}
+	primary.mutex.Lock()
+	primary.notReady = true
+	primary.mutex.Unlock()
+	if result := newLogtor.LogItAttempt(types.INFO, "fallback"); !result.Logged || result.CreatorUsed != "defaultCreator" {
+		t.Errorf("the console creator should stay the fallback: %+v", result)
+	}
+}
+
+func TestLogtorNewIsSilent(t *testing.T) {
+	newLogtor := logtor.New()
+	if newLogtor.LogCreator() != nil || newLogtor.LogIt(types.FATAL, "dropped") {
+		t.Error("New should not log until log creators are added")
+	}
+}
+
+func TestLogtorLogItOnce(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+
+	if newLogtor.LogItOnce("init", types.INFO, "filtered") {
+		t.Error("filtered levels should not be logged")
+	}
+	if !newLogtor.LogItOnce("init", types.ERROR, "init failed") {
+		t.Error("first call should be logged")
+	}
+	if newLogtor.LogItOnce("init", types.ERROR, "init failed") {
+		t.Error("repeated call should be suppressed")
+	}
+	if !newLogtor.LogItOnce("config", types.ERROR, "config missing") {
+		t.Error("keys should be tracked independently")
+	}
+	newLogtor.ResetOnce("init")
+	if !newLogtor.LogItOnce("init", types.ERROR, "init failed") {
+		t.Error("message should be logged after ResetOnce")
+	}
+
+	var wg sync.WaitGroup
+	var logged atomic.Int32
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if newLogtor.LogItOnce("concurrent", types.ERROR, "once") {
+				logged.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if logged.Load() != 1 || len(recorder.Messages()) != 4 {
+		t.Errorf("concurrent calls logged %d times, messages: %v", logged.Load(), recorder.Messages())
+	}
+}
+
+func TestLogtorSwapAndRevertLogCreator(t *testing.T) {
+	newLogtor := logtor.New()
+	if _, err := newLogtor.RevertLogCreator(); !errors.Is(err, logtor.ErrNoPreviousLogCreator) {
+		t.Errorf("expected ErrNoPreviousLogCreator, got %v", err)
+	}
+	newLogtor.AddLogCreators(newRecordingCreator("First"), newRecordingCreator("Second"))
+
+	if _, err := newLogtor.SwapLogCreator("Missing"); !errors.Is(err, logtor.ErrUnknownLogCreator) {
+		t.Errorf("expected ErrUnknownLogCreator, got %v", err)
+	}
+	if previous, err := newLogtor.SwapLogCreator("Second"); previous != "First" || err != nil {
+		t.Errorf("unexpected swap result: %q, %v", previous, err)
+	}
+	if previous, err := newLogtor.RevertLogCreator(); previous != "Second" || err != nil {
+		t.Errorf("unexpected revert result: %q, %v", previous, err)
+	}
+	if active := newLogtor.LogCreator().LogName(); active != "First" {
+		t.Errorf("revert should restore the previous creator, active creator is %s", active)
+	}
+	if previous, err := newLogtor.RevertLogCreator(); previous != "First" || err != nil {
+		t.Errorf("a second revert should toggle back: %q, %v", previous, err)
+	}
+	if !newLogtor.ChangeLogCreator("Second") || newLogtor.ChangeLogCreator("Missing") {
+		t.Error("ChangeLogCreator should report whether the creator exists")
+	}
+}
+
+func TestLogtorLogItEvery(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+
+	if newLogtor.LogItEvery("disk", time.Hour, types.INFO, "filtered") {
+		t.Error("filtered levels should not be logged")
+	}
+	if !newLogtor.LogItEvery("disk", time.Hour, types.WARN, "disk nearly full") {
+		t.Error("first call should be logged")
+	}
+	if newLogtor.LogItEvery("disk", time.Hour, types.WARN, "disk nearly full") {
+		t.Error("calls within the interval should be suppressed")
+	}
+	if !newLogtor.LogItEvery("pool", time.Hour, types.WARN, "pool exhausted") {
+		t.Error("keys should be tracked independently")
+	}
+	if !newLogtor.LogItEvery("short", time.Millisecond, types.WARN, "tick") {
+		t.Error("first call should be logged")
+	}
+	time.Sleep(5 * time.Millisecond)
+	if !newLogtor.LogItEvery("short", time.Millisecond, types.WARN, "tick") {
+		t.Error("calls after the interval should be logged")
+	}
+	if len(recorder.Messages()) != 4 {
+		t.Errorf("unexpected messages: %v", recorder.Messages())
+	}
+}
+
+type orderPlaced struct {
+	OrderID string  `json:"order_id"`
+	Total   float64 `json:"total"`
+	Note    string  `json:"note,omitempty"`
+	Secret  string  `json:"-"`
+	hidden  string
+}
+
+func TestLogtorLogItStruct(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.INFO)
+
+	order := orderPlaced{OrderID: "ord-7", Total: 42.5, Secret: "s", hidden: "h"}
+	newLogtor.LogItStruct(types.INFO, order)
+	newLogtor.LogItStruct(types.INFO, &order)
+	newLogtor.LogItStruct(types.INFO, "not a struct")
+	newLogtor.LogItStruct(types.INFO, (*orderPlaced)(nil))
+	newLogtor.LogItStruct(types.INFO, struct{ Callback func() }{})
+	if newLogtor.LogItStruct(types.TRACE, order) {
+		t.Error("disabled levels should be skipped")
+	}
+
+	expected := []string{
+		"INFO orderPlaced order_id=ord-7 total=42.5",
+		"INFO orderPlaced order_id=ord-7 total=42.5",
+		"INFO not a struct",
+		"INFO <nil>",
+		"INFO {Callback:<nil>}",
+	}
+	if messages := recorder.Messages(); fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("unexpected messages:\n got %q\nwant %q", messages, expected)
+	}
+}
+
+func TestLogtorSetDefaultCallDepthAdjustment(t *testing.T) {
+	first, second := newRecordingCreator("First"), newRecordingCreator("Second")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(first)
+
+	newLogtor.SetDefaultCallDepthAdjustment(1)
+	newLogtor.AddLogCreators(second)
+	if newLogtor.DefaultCallDepthAdjustment() != 1 {
+		t.Errorf("unexpected adjustment %d", newLogtor.DefaultCallDepthAdjustment())
+	}
+
+	newLogtor.SetDefaultCallDepthAdjustment(2)
+	if newLogtor.DefaultCallDepthAdjustment() != 2 {
+		t.Errorf("a new adjustment should replace the previous one, got %d", newLogtor.DefaultCallDepthAdjustment())
+	}
+	if first.CallDepth() != 2 || second.CallDepth() != 2 {
+		t.Errorf("the log creators should keep their call depths, got %d and %d", first.CallDepth(), second.CallDepth())
+	}
+}
+
+func TestLogtorSetDefaultCallDepthAdjustmentWhileLogging(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.INFO)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			newLogtor.SetDefaultCallDepthAdjustment(i % 3)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		newLogtor.LogIt(types.INFO, i)
+	}
+	wg.Wait()
+	if len(recorder.Messages()) != 100 || recorder.CallDepth() != 2 {
+		t.Errorf("got %d messages and call depth %d", len(recorder.Messages()), recorder.CallDepth())
+	}
+}
+
+// logThroughHelper is a helper layer adding a stack frame between the call site and Logtor.
+func logThroughHelper(l *logtor.Logtor, logMessage string) {
+	l.LogIt(types.INFO, logMessage)
+}
+
+func TestLogtorCallDepthAdjustmentAttributesHelperCallers(t *testing.T) {
+	for _, legacy := range []bool{false, true} {
+		fileName := filepath.Join(t.TempDir(), "helper.log")
+		fileCreator, err := creators.NewFileCreatorWithOptions(fileName, creators.WithName("File"), creators.WithCallDepth(4))
+		if err != nil {
+			t.Fatal(err)
+		}
+		newLogtor := logtor.New()
+		if legacy {
+			newLogtor.AddLogCreators(legacyCreator{fileCreator})
+		} else {
+			newLogtor.AddLogCreators(fileCreator)
+		}
+		newLogtor.SetLogLevel(types.INFO)
+		newLogtor.SetDefaultCallDepthAdjustment(1)
+
+		_, _, line, _ := runtime.Caller(0)
+		logThroughHelper(newLogtor, "through helper")
+		newLogtor.Shutdown()
+
+		content, err := os.ReadFile(fileName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if expected := fmt.Sprintf("logtor_test.go:%d: through helper", line+1); !strings.Contains(string(content), expected) {
+			t.Errorf("legacy %v: entry should be attributed to the helper's caller %q: %s", legacy, expected, content)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Package logtortest provides assertion helpers for tests inspecting the entries kept by a
+// creators.MemoryCreator.
+//
+// The helpers read the entries through the MemoryCreator's own accessors, which copy them under its lock,
+// so they can be used while the code under test keeps logging from other goroutines. On failure they
+// report what was expected next to every entry kept at the level.
+package logtortest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// AssertLogged reports an error if no entry kept at the level contains the substring.
+//
+// Parameters:
+//   - t: The test or benchmark to report the failure to.
+//   - mc: The MemoryCreator whose entries are inspected.
+//   - level: The log level of the expected entry.
+//   - substring: The text the message of the expected entry contains.
+func AssertLogged(t testing.TB, mc *creators.MemoryCreator, level types.LogLevel, substring string) {
+	t.Helper()
+	if entries, ok := logged(mc, level, substring); !ok {
+		t.Error(failure(fmt.Sprintf("an entry containing %q", substring), level, entries))
+	}
+}
+
+// RequireLogged is like AssertLogged, but stops the test with t.FailNow on failure.
+//
+// Parameters:
+//   - t: The test or benchmark to report the failure to.
+//   - mc: The MemoryCreator whose entries are inspected.
+//   - level: The log level of the expected entry.
+//   - substring: The text the message of the expected entry contains.
+func RequireLogged(t testing.TB, mc *creators.MemoryCreator, level types.LogLevel, substring string) {
+	t.Helper()
+	if entries, ok := logged(mc, level, substring); !ok {
+		t.Error(failure(fmt.Sprintf("an entry containing %q", substring), level, entries))
+		t.FailNow()
+	}
+}
+
+// AssertNotLogged reports an error if an entry kept at the level contains the substring.
+//
+// Parameters:
+//   - t: The test or benchmark to report the failure to.
+//   - mc: The MemoryCreator whose entries are inspected.
+//   - level: The log level of the unexpected entry.
+//   - substring: The text the message of the unexpected entry contains.
+func AssertNotLogged(t testing.TB, mc *creators.MemoryCreator, level types.LogLevel, substring string) {
+	t.Helper()
+	if entries, ok := logged(mc, level, substring); ok {
+		t.Error(failure(fmt.Sprintf("no entry containing %q", substring), level, entries))
+	}
+}
+
+// RequireNotLogged is like AssertNotLogged, but stops the test with t.FailNow on failure.
+//
+// Parameters:
+//   - t: The test or benchmark to report the failure to.
+//   - mc: The MemoryCreator whose entries are inspected.
+//   - level: The log level of the unexpected entry.
+//   - substring: The text the message of the unexpected entry contains.
+func RequireNotLogged(t testing.TB, mc *creators.MemoryCreator, level types.LogLevel, substring string) {
+	t.Helper()
+	if entries, ok := logged(mc, level, substring); ok {
+		t.Error(failure(fmt.Sprintf("no entry containing %q", substring), level, entries))
+		t.FailNow()
+	}
+}
+
+// AssertLogCount reports an error if the number of entries kept at the level is not want.
+//
+// Parameters:
+//   - t: The test or benchmark to report the failure to.
+//   - mc: The MemoryCreator whose entries are inspected.
+//   - level: The log level of the counted entries.
+//   - want: The expected number of entries.
+func AssertLogCount(t testing.TB, mc *creators.MemoryCreator, level types.LogLevel, want int) {
+	t.Helper()
+	if entries := mc.EntriesForLevel(level); len(entries) != want {
+		t.Error(failure(fmt.Sprintf("%d entries", want), level, entries))
+	}
+}
+
+// RequireLogCount is like AssertLogCount, but stops the test with t.FailNow on failure.
+//
+// Parameters:
+//   - t: The test or benchmark to report the failure to.
+//   - mc: The MemoryCreator whose entries are inspected.
+//   - level: The log level of the counted entries.
+//   - want: The expected number of entries.
+func RequireLogCount(t testing.TB, mc *creators.MemoryCreator, level types.LogLevel, want int) {
+	t.Helper()
+	if entries := mc.EntriesForLevel(level); len(entries) != want {
+		t.Error(failure(fmt.Sprintf("%d entries", want), level, entries))
+		t.FailNow()
+	}
+}
+
+// logged returns the entries kept at the level and whether the message of one of them contains the
+// substring. Messages that are not strings are matched in their "%+v" representation, like
+// MemoryCreator.Contains does.
+func logged(mc *creators.MemoryCreator, level types.LogLevel, substring string) ([]types.LogEntry, bool) {
+	entries := mc.EntriesForLevel(level)
+	for _, entry := range entries {
+		message, ok := entry.Message.(string)
+		if !ok {
+			message = fmt.Sprintf("%+v", entry.Message)
+		}
+		if strings.Contains(message, substring) {
+			return entries, true
+		}
+	}
+	return entries, false
+}
+
+// failure renders a failure message in a diff-like layout: the expectation on a "-" line and every
+// entry kept at the level on a "+" line.
+func failure(want string, level types.LogLevel, entries []types.LogEntry) string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "unexpected %s entries\n- want: %s\n+ got:  %d entries", level, want, len(entries))
+	for _, entry := range entries {
+		fmt.Fprintf(&builder, "\n+ %s", entry)
+	}
+	return builder.String()
+}
@@ -0,0 +1,77 @@
+package logtortest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/logtortest"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// recordingTB is a testing.TB that records failures instead of failing the test running it.
+type recordingTB struct {
+	testing.TB
+	errors  []string
+	stopped bool
+}
+
+func (rt *recordingTB) Helper() {}
+
+func (rt *recordingTB) Error(args ...interface{}) {
+	for _, arg := range args {
+		rt.errors = append(rt.errors, arg.(string))
+	}
+}
+
+func (rt *recordingTB) FailNow() { rt.stopped = true }
+
+func newMemoryLogtor() (*logtor.Logtor, *creators.MemoryCreator) {
+	memoryCreator := creators.NewMemoryCreator("Memory", 10)
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(memoryCreator)
+	newLogtor.SetLogLevel(types.TRACE)
+	return newLogtor, memoryCreator
+}
+
+func TestAssertionsPass(t *testing.T) {
+	newLogtor, memoryCreator := newMemoryLogtor()
+	newLogtor.LogIt(types.INFO, "order loaded")
+	newLogtor.LogIt(types.ERROR, struct{ ID int }{ID: 7})
+
+	logtortest.AssertLogged(t, memoryCreator, types.INFO, "loaded")
+	logtortest.RequireLogged(t, memoryCreator, types.ERROR, "ID:7")
+	logtortest.AssertNotLogged(t, memoryCreator, types.ERROR, "loaded")
+	logtortest.RequireNotLogged(t, memoryCreator, types.WARN, "")
+	logtortest.AssertLogCount(t, memoryCreator, types.INFO, 1)
+	logtortest.RequireLogCount(t, memoryCreator, types.DEBUG, 0)
+}
+
+func TestAssertionsReportEntries(t *testing.T) {
+	newLogtor, memoryCreator := newMemoryLogtor()
+	newLogtor.LogIt(types.INFO, "order loaded")
+	newLogtor.WithFields(map[string]interface{}{"user": "u-1"}).LogIt(types.INFO, "order paid")
+
+	rt := &recordingTB{}
+	logtortest.AssertLogged(rt, memoryCreator, types.INFO, "refunded")
+	if rt.stopped || len(rt.errors) != 1 {
+		t.Fatalf("AssertLogged should report one error without stopping: %v %v", rt.errors, rt.stopped)
+	}
+	expected := "unexpected INFO entries\n- want: an entry containing \"refunded\"\n+ got:  2 entries\n+ order loaded\n+ order paid user=u-1"
+	if rt.errors[0] != expected {
+		t.Errorf("unexpected failure message:\n got %q\nwant %q", rt.errors[0], expected)
+	}
+
+	rt = &recordingTB{}
+	logtortest.RequireNotLogged(rt, memoryCreator, types.INFO, "paid")
+	if !rt.stopped || len(rt.errors) != 1 || !strings.Contains(rt.errors[0], "- want: no entry containing \"paid\"") {
+		t.Errorf("RequireNotLogged should report and stop: %v %v", rt.errors, rt.stopped)
+	}
+
+	rt = &recordingTB{}
+	logtortest.RequireLogCount(rt, memoryCreator, types.INFO, 3)
+	if !rt.stopped || len(rt.errors) != 1 || !strings.Contains(rt.errors[0], "- want: 3 entries\n+ got:  2 entries") {
+		t.Errorf("RequireLogCount should report and stop: %v %v", rt.errors, rt.stopped)
+	}
+}
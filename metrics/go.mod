@@ -0,0 +1,24 @@
+module github.com/Eyup-Devop/logtor/metrics
+
+go 1.21.4
+
+require (
+	github.com/Eyup-Devop/logtor v0.0.0-20261017034922-1ffb7e568c64
+	github.com/prometheus/client_golang v1.20.5
+)
+
+require (
+	github.com/beorn7/perks v1.0.1 // indirect
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/kylelemons/godebug v1.1.0 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+	github.com/prometheus/client_model v0.6.1 // indirect
+	github.com/prometheus/common v0.55.0 // indirect
+	github.com/prometheus/procfs v0.15.1 // indirect
+	golang.org/x/sys v0.22.0 // indirect
+	google.golang.org/protobuf v1.34.2 // indirect
+)
+
+// The collector is developed against the root module of this repository. The replace only applies when
+// building this module from a checkout; consumers resolve the required version of the root module.
+replace github.com/Eyup-Devop/logtor => ../
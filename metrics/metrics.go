@@ -0,0 +1,77 @@
+// Package metrics exposes the message counters of a Logtor as Prometheus metrics.
+//
+// It is a separate module, so only applications using Prometheus depend on its client library. The
+// collector reads Logtor.Stats on every scrape; the counters start over after Logtor.ResetStats, which
+// Prometheus treats as a counter reset.
+package metrics
+
+import (
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// defaultNamespace is the metric namespace used when none is given.
+const defaultNamespace = "logtor"
+
+// collector is the prometheus.Collector reading the counters of a Logtor.
+type collector struct {
+	logtor   *logtor.Logtor
+	messages *prometheus.Desc
+	drops    *prometheus.Desc
+}
+
+// NewPrometheusCollector creates a prometheus.Collector exposing the message counters of a Logtor.
+//
+// The collector exposes two counters labeled with the log level, with a series for every level but NONE:
+//   - <namespace>_messages_total: The messages recorded by at least one log creator, as Stats().Levels.
+//   - <namespace>_drops_total: The messages skipped because of the log level, as Stats().FilteredByLevel.
+//
+// Parameters:
+//   - l: The Logtor whose counters are exposed.
+//   - namespace: The namespace of the metric names, or an empty string for "logtor".
+//
+// Returns:
+//   - prometheus.Collector: The collector, to register with a prometheus.Registerer.
+func NewPrometheusCollector(l *logtor.Logtor, namespace string) prometheus.Collector {
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+	return &collector{
+		logtor: l,
+		messages: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "messages_total"),
+			"Number of messages recorded, by log level.", []string{"level"}, nil),
+		drops: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "drops_total"),
+			"Number of messages skipped because of the log level, by log level.", []string{"level"}, nil),
+	}
+}
+
+// RegisterWithDefaultRegistry registers the collector of a Logtor with the default Prometheus registry.
+//
+// Parameters:
+//   - l: The Logtor whose counters are exposed.
+//   - namespace: The namespace of the metric names, or an empty string for "logtor".
+//
+// Returns:
+//   - error: An error if a collector with the same metrics is already registered.
+func RegisterWithDefaultRegistry(l *logtor.Logtor, namespace string) error {
+	return prometheus.Register(NewPrometheusCollector(l, namespace))
+}
+
+// Describe sends the descriptors of the metrics of the collector.
+func (c *collector) Describe(descs chan<- *prometheus.Desc) {
+	descs <- c.messages
+	descs <- c.drops
+}
+
+// Collect sends the current values of the counters, read from the Stats of the Logtor.
+func (c *collector) Collect(metrics chan<- prometheus.Metric) {
+	stats := c.logtor.Stats()
+	for _, level := range types.LogLevels() {
+		if level == types.NONE {
+			continue
+		}
+		metrics <- prometheus.MustNewConstMetric(c.messages, prometheus.CounterValue, float64(stats.Levels[level]), string(level))
+		metrics <- prometheus.MustNewConstMetric(c.drops, prometheus.CounterValue, float64(stats.FilteredByLevel[level]), string(level))
+	}
+}
@@ -0,0 +1,105 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/metrics"
+	"github.com/Eyup-Devop/logtor/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+// discardCreator is a LogCreator recording every message without writing it.
+type discardCreator struct{}
+
+func (discardCreator) LogIt(level types.LogLevel, logMessage interface{}) bool { return true }
+
+func (discardCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	return true
+}
+
+func (discardCreator) LogName() types.LogCreatorName { return "Discard" }
+
+func (discardCreator) SetCallDepth(callDepth int) {}
+
+func (discardCreator) CallDepth() int { return 2 }
+
+func (discardCreator) IsReady() bool { return true }
+
+func (discardCreator) Shutdown() {}
+
+func TestNewPrometheusCollector(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(discardCreator{})
+	newLogtor.SetLogLevel(types.WARN)
+	newLogtor.LogIt(types.ERROR, "first")
+	newLogtor.LogIt(types.ERROR, "second")
+	newLogtor.LogIt(types.WARN, "third")
+	newLogtor.LogIt(types.TRACE, "filtered")
+
+	collector := metrics.NewPrometheusCollector(newLogtor, "app")
+	expected := `
+# HELP app_messages_total Number of messages recorded, by log level.
+# TYPE app_messages_total counter
+app_messages_total{level="DEBUG"} 0
+app_messages_total{level="ERROR"} 2
+app_messages_total{level="FATAL"} 0
+app_messages_total{level="INFO"} 0
+app_messages_total{level="PANIC"} 0
+app_messages_total{level="TRACE"} 0
+app_messages_total{level="WARN"} 1
+# HELP app_drops_total Number of messages skipped because of the log level, by log level.
+# TYPE app_drops_total counter
+app_drops_total{level="DEBUG"} 0
+app_drops_total{level="ERROR"} 0
+app_drops_total{level="FATAL"} 0
+app_drops_total{level="INFO"} 0
+app_drops_total{level="PANIC"} 0
+app_drops_total{level="TRACE"} 1
+app_drops_total{level="WARN"} 0
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+
+	newLogtor.LogIt(types.ERROR, "after the first scrape")
+	expected = `
+# HELP app_messages_total Number of messages recorded, by log level.
+# TYPE app_messages_total counter
+app_messages_total{level="DEBUG"} 0
+app_messages_total{level="ERROR"} 3
+app_messages_total{level="FATAL"} 0
+app_messages_total{level="INFO"} 0
+app_messages_total{level="PANIC"} 0
+app_messages_total{level="TRACE"} 0
+app_messages_total{level="WARN"} 1
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected), "app_messages_total"); err != nil {
+		t.Errorf("the counters should be read on every scrape: %v", err)
+	}
+}
+
+func TestRegisterWithDefaultRegistry(t *testing.T) {
+	newLogtor := logtor.New()
+	if err := metrics.RegisterWithDefaultRegistry(newLogtor, ""); err != nil {
+		t.Fatal(err)
+	}
+	defer prometheus.Unregister(metrics.NewPrometheusCollector(newLogtor, ""))
+	if err := metrics.RegisterWithDefaultRegistry(newLogtor, ""); err == nil {
+		t.Error("registering the same metrics twice should fail")
+	}
+
+	families, err := prometheus.DefaultGatherer.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := map[string]bool{}
+	for _, family := range families {
+		names[family.GetName()] = true
+	}
+	if !names["logtor_messages_total"] || !names["logtor_drops_total"] {
+		t.Errorf("the default namespace should be logtor: %v", names)
+	}
+}
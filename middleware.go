@@ -0,0 +1,99 @@
+package logtor
+
+import (
+	"fmt"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// CreatorMiddleware wraps a log creator with another log creator, such as one that retries, buffers,
+// filters or deduplicates messages before passing them on.
+//
+// The returned log creator is expected to pass Shutdown on to the log creator it wraps, as Logtor only
+// shuts down the outermost log creator of a chain.
+type CreatorMiddleware func(LogCreator) LogCreator
+
+// WrapCreator wraps a registered log creator with a chain of middlewares.
+//
+// The middlewares are applied in order, so the first one wraps the registered log creator and the last
+// one is called first. The chain replaces the log creator wherever it is used, as the active, default or
+// routed log creator, and keeps its name: a middleware returning a log creator with a different name is
+// given the original name back. Calling WrapCreator again adds to the existing chain. The type of each
+// layer is reported in the "chain" of the creator status endpoint.
+//
+// Parameters:
+//   - logCreatorName: The name of the log creator to wrap.
+//   - middlewares: The middlewares to apply, in order.
+//
+// Returns:
+//   - error: ErrUnknownLogCreator if no log creator is registered under the name, or an error if a
+//     middleware is nil or returns nil. Nothing is changed if an error is returned.
+func (l *Logtor) WrapCreator(logCreatorName types.LogCreatorName, middlewares ...CreatorMiddleware) error {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	original, ok := l.logCreatorList[logCreatorName]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownLogCreator, logCreatorName)
+	}
+
+	wrapped := original
+	chain := make([]string, 0, len(middlewares))
+	for i, middleware := range middlewares {
+		if middleware == nil {
+			return fmt.Errorf("logtor: middleware %d for %s is nil", i, logCreatorName)
+		}
+		layer := middleware(wrapped)
+		if layer == nil {
+			return fmt.Errorf("logtor: middleware %d for %s returned no log creator", i, logCreatorName)
+		}
+		chain = append(chain, fmt.Sprintf("%T", layer))
+		if layer.LogName() != logCreatorName {
+			layer = &namedCreator{LogCreator: layer, name: logCreatorName}
+		}
+		wrapped = layer
+	}
+	if len(chain) == 0 {
+		return nil
+	}
+
+	l.logCreatorList[logCreatorName] = wrapped
+	if l.creatorChains == nil {
+		l.creatorChains = make(map[types.LogCreatorName][]string)
+	}
+	l.creatorChains[logCreatorName] = append(l.creatorChains[logCreatorName], chain...)
+	if current := l.currentLogCreator.Load(); current != nil && sameCreator(current, original) {
+		l.currentLogCreator.Store(wrapped)
+	}
+	if defaultCreator := l.defaultCreator.Load(); defaultCreator != nil && sameCreator(defaultCreator, original) {
+		l.defaultCreator.Store(wrapped)
+	}
+	l.rebuildRoutedCreators()
+	return nil
+}
+
+// creatorChain returns the types of the middlewares wrapping a log creator, in the order they were
+// applied. It must be called with changeMutex held.
+func (l *Logtor) creatorChain(logCreatorName types.LogCreatorName) []string {
+	chain := l.creatorChains[logCreatorName]
+	if len(chain) == 0 {
+		return nil
+	}
+	return append([]string(nil), chain...)
+}
+
+// namedCreator restores the name of a wrapped log creator when a middleware does not pass it through.
+type namedCreator struct {
+	LogCreator
+	name types.LogCreatorName
+}
+
+func (nc *namedCreator) LogName() types.LogCreatorName {
+	return nc.name
+}
+
+func (nc *namedCreator) Flush() error {
+	if flusher, ok := nc.LogCreator.(Flusher); ok {
+		return flusher.Flush()
+	}
+	return nil
+}
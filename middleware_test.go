@@ -0,0 +1,128 @@
+package logtor_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// renamingCreator is a middleware layer that does not pass the name of the wrapped log creator through.
+type renamingCreator struct {
+	logtor.LogCreator
+}
+
+func (rc *renamingCreator) LogName() types.LogCreatorName { return "renamed" }
+
+func prefixMiddleware(prefix string) logtor.CreatorMiddleware {
+	return func(inner logtor.LogCreator) logtor.LogCreator {
+		return creators.NewPrefixCreator(prefix, inner)
+	}
+}
+
+func TestLogtorWrapCreator(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.INFO)
+
+	err := newLogtor.WrapCreator("Recorder", prefixMiddleware("auth"), func(inner logtor.LogCreator) logtor.LogCreator {
+		return &renamingCreator{inner}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := newLogtor.WrapCreator("Recorder", prefixMiddleware("outer")); err != nil {
+		t.Fatal(err)
+	}
+	if name := newLogtor.LogCreator().LogName(); name != "Recorder" {
+		t.Errorf("the chain should keep the name of the log creator, got %s", name)
+	}
+
+	newLogtor.LogIt(types.INFO, "signed in")
+	expected := []string{"INFO [auth] [outer] signed in"}
+	if messages := recorder.Messages(); fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("unexpected messages:\n got %q\nwant %q", messages, expected)
+	}
+
+	response := httptest.NewRecorder()
+	newLogtor.GetLogCreatorStatus(response, httptest.NewRequest(http.MethodGet, "/log-creators/status", nil))
+	var statuses []struct {
+		Name  string   `json:"name"`
+		Chain []string `json:"chain"`
+	}
+	if err := json.Unmarshal(response.Body.Bytes(), &statuses); err != nil {
+		t.Fatal(err)
+	}
+	expectedChain := []string{"*creators.PrefixCreator", "*logtor_test.renamingCreator", "*creators.PrefixCreator"}
+	if len(statuses) != 1 || statuses[0].Name != "Recorder" || fmt.Sprint(statuses[0].Chain) != fmt.Sprint(expectedChain) {
+		t.Errorf("unexpected status: %s", response.Body.String())
+	}
+
+	if err := newLogtor.RemoveLogCreator("Recorder"); err != nil {
+		t.Fatal(err)
+	}
+	if shutdowns := recorder.Shutdowns(); shutdowns != 1 {
+		t.Errorf("the chain should be shut down once, got %d shutdowns", shutdowns)
+	}
+	newLogtor.Shutdown()
+	if shutdowns := recorder.Shutdowns(); shutdowns != 1 {
+		t.Errorf("a removed log creator should not be shut down again, got %d shutdowns", shutdowns)
+	}
+}
+
+func TestLogtorWrapCreatorErrors(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+
+	if err := newLogtor.WrapCreator("Missing", prefixMiddleware("auth")); !errors.Is(err, logtor.ErrUnknownLogCreator) {
+		t.Errorf("expected ErrUnknownLogCreator, got %v", err)
+	}
+	if err := newLogtor.WrapCreator("Recorder", prefixMiddleware("auth"), nil); err == nil {
+		t.Error("a nil middleware should be rejected")
+	}
+	if err := newLogtor.WrapCreator("Recorder", func(logtor.LogCreator) logtor.LogCreator { return nil }); err == nil {
+		t.Error("a middleware returning nil should be rejected")
+	}
+	if newLogtor.LogCreator() != recorder {
+		t.Error("a failed WrapCreator should not change the log creator")
+	}
+}
+
+func TestLogtorRemoveLogCreator(t *testing.T) {
+	fallback := newRecordingCreator("Fallback")
+	active := newRecordingCreator("Active")
+	newLogtor := logtor.New().WithDefaultCreator(fallback)
+	newLogtor.AddLogCreators(active)
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.RouteLevels("Active", types.ERROR)
+	newLogtor.SetCreatorLogLevel("Active", types.ERROR)
+
+	if err := newLogtor.RemoveLogCreator("Missing"); !errors.Is(err, logtor.ErrUnknownLogCreator) {
+		t.Errorf("expected ErrUnknownLogCreator, got %v", err)
+	}
+	if err := newLogtor.RemoveLogCreator("Active"); err != nil {
+		t.Fatal(err)
+	}
+	if newLogtor.LogCreator() != fallback {
+		t.Error("the default creator should become active")
+	}
+	if _, ok := newLogtor.GetCreatorLogLevel("Active"); ok {
+		t.Error("the log level of a removed log creator should be removed")
+	}
+
+	newLogtor.LogIt(types.ERROR, "routed")
+	if messages := fallback.Messages(); len(messages) != 1 || len(active.Messages()) != 0 {
+		t.Errorf("routes to a removed log creator should be removed, got %q", messages)
+	}
+	if active.Shutdowns() != 1 {
+		t.Errorf("the removed log creator should be shut down, got %d shutdowns", active.Shutdowns())
+	}
+}
@@ -0,0 +1,323 @@
+package logtor
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// maxNamespaceFrames bounds how far the caller lookup walks up the stack past frames of this package.
+const maxNamespaceFrames = 8
+
+// logtorPackage is the package path of this package, whose frames are skipped when the caller of a log
+// call is resolved, so wrappers such as LogItOnce are attributed to their own caller.
+var logtorPackage = func() string {
+	pc, _, _, _ := runtime.Caller(0)
+	return packageOf(runtime.FuncForPC(pc).Name())
+}()
+
+// SetLogLevelFor sets the log level of a namespace, overriding the global log level for messages logged
+// from it.
+//
+// A namespace is a package path, such as "github.com/acme/app/storage". Messages logged from a package
+// use the level of the longest namespace that is the package path or one of its parent paths, so
+// "github.com/acme/app" also covers "github.com/acme/app/storage" unless the latter has a level of its
+// own. The global log level is the root of the hierarchy. The package is resolved from the caller of the
+// log call and cached per program counter, or taken from a NamedLogtor created with Named. A log level
+// set for the log creator with SetCreatorLogLevel takes precedence over namespace levels.
+//
+// Parameters:
+//   - namespace: The package path of the namespace.
+//   - level: The log level for the namespace.
+//
+// Returns:
+//   - bool: True if the log level was set; false if the namespace is empty or the level is invalid.
+func (l *Logtor) SetLogLevelFor(namespace string, level types.LogLevel) bool {
+	namespace = strings.TrimSuffix(namespace, "/")
+	if namespace == "" || !level.IsValid() {
+		return false
+	}
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	if l.namespaceLevels == nil {
+		l.namespaceLevels = make(map[string]types.LogLevel)
+	}
+	l.namespaceLevels[namespace] = level
+	l.rebuildNamespaceRanks()
+	return true
+}
+
+// ClearLogLevelFor removes the log level of a namespace, so the level of its closest parent namespace,
+// or the global log level, applies to it again.
+//
+// Parameters:
+//   - namespace: The package path of the namespace.
+func (l *Logtor) ClearLogLevelFor(namespace string) {
+	namespace = strings.TrimSuffix(namespace, "/")
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	if _, ok := l.namespaceLevels[namespace]; !ok {
+		return
+	}
+	delete(l.namespaceLevels, namespace)
+	l.rebuildNamespaceRanks()
+}
+
+// NamespaceLogLevels returns the log levels set with SetLogLevelFor.
+//
+// Returns:
+//   - map[string]types.LogLevel: A copy of the log levels by namespace.
+func (l *Logtor) NamespaceLogLevels() map[string]types.LogLevel {
+	l.changeMutex.RLock()
+	defer l.changeMutex.RUnlock()
+	levels := make(map[string]types.LogLevel, len(l.namespaceLevels))
+	for namespace, level := range l.namespaceLevels {
+		levels[namespace] = level
+	}
+	return levels
+}
+
+// Named returns a logger that logs under an explicit namespace instead of the package of its caller,
+// such as a logger per subsystem.
+//
+// The messages of the logger carry the namespace in a "logger" field, which text based creators render
+// as logger=namespace after the message and BrokerCreator sends in the fields object. The logger uses
+// the log creators, default creator and log level of the Logtor, so changing them on the Logtor affects
+// the logger as well, until the level of the namespace is set with SetLogLevel.
+//
+// Parameters:
+//   - namespace: The namespace whose log level applies to the messages of the logger.
+//
+// Returns:
+//   - *NamedLogtor: The named logger, sharing the log creators and levels of the Logtor.
+func (l *Logtor) Named(namespace string) *NamedLogtor {
+	namespace = strings.TrimSuffix(namespace, "/")
+	return &NamedLogtor{
+		logtor:    l,
+		namespace: namespace,
+		fields:    map[string]interface{}{namedLoggerField: namespace},
+	}
+}
+
+// namedLoggerField is the field carrying the namespace of the messages of a NamedLogtor.
+const namedLoggerField = "logger"
+
+// NamedLogtor logs through a Logtor under an explicit namespace.
+//
+// A NamedLogtor owns no log creators: they are shut down with the Logtor it was created from.
+type NamedLogtor struct {
+	logtor    *Logtor
+	namespace string
+	fields    map[string]interface{}
+}
+
+// mark attaches the namespace to a message as the "logger" field.
+func (nl *NamedLogtor) mark(logMessage interface{}) interface{} {
+	switch typed := logMessage.(type) {
+	case types.LogEntry:
+		return typed.WithFields(nl.fields)
+	case ContextualMessage:
+		fields := make(map[string]interface{}, len(typed.Fields)+1)
+		for key, value := range typed.Fields {
+			fields[key] = value
+		}
+		fields[namedLoggerField] = nl.namespace
+		return ContextualMessage{Message: typed.Message, Fields: fields}
+	}
+	return ContextualMessage{Message: logMessage, Fields: nl.fields}
+}
+
+// SetLogLevel sets the log level of the logger's namespace, like Logtor.SetLogLevelFor, so the logger no
+// longer follows the global log level of the Logtor.
+//
+// Parameters:
+//   - level: The log level for the namespace.
+//
+// Returns:
+//   - bool: True if the log level was set; false if the namespace is empty or the level is invalid.
+func (nl *NamedLogtor) SetLogLevel(level types.LogLevel) bool {
+	return nl.logtor.SetLogLevelFor(nl.namespace, level)
+}
+
+// ClearLogLevel removes the log level of the logger's namespace, like Logtor.ClearLogLevelFor, so the
+// logger follows the level of its closest parent namespace, or the global log level, again.
+func (nl *NamedLogtor) ClearLogLevel() {
+	nl.logtor.ClearLogLevelFor(nl.namespace)
+}
+
+// Namespace returns the namespace of the logger.
+//
+// Returns:
+//   - string: The namespace passed to Named.
+func (nl *NamedLogtor) Namespace() string {
+	return nl.namespace
+}
+
+// LogIt logs a message like Logtor.LogIt, with the log level of the logger's namespace.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged, false otherwise.
+func (nl *NamedLogtor) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return nl.logIt(level, 0, logMessage)
+}
+
+// LogItWithCallDepth logs a message like Logtor.LogItWithCallDepth, with the log level of the logger's
+// namespace.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for calling function, or zero or less for the log creator's configured call depth.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged, false otherwise.
+func (nl *NamedLogtor) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	return nl.logIt(level, callDepth, logMessage)
+}
+
+// logIt logs a message like Logtor.LogItWithCallDepth, resolving the log level from the logger's
+// namespace. The namespace is attached to the message once the level is known to be enabled, so
+// filtered messages cost no allocation.
+func (nl *NamedLogtor) logIt(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	l := nl.logtor
+	logCreator := l.creatorFor(level)
+	if !l.levelEnabledIn(level, logCreator, nl.namespace) {
+		l.countFiltered(level)
+		return false
+	}
+	if !l.creatorAccepts(logCreator, level, logMessage) || !l.dedup(level, logMessage) || !l.sample(level) || !l.rateLimit(level) {
+		return false
+	}
+	call, err := l.startLogCall(logCreator, level, nl.mark(logMessage))
+	if err != nil {
+		return false
+	}
+	defer call.end()
+	// The exported NamedLogtor method and logIt are the two frames above the log creator; a callDepth of
+	// zero or less stands in for LogIt, as it does for Logtor.LogItWithCallDepth.
+	viaLogIt := callDepth <= 0
+	return call.fallBack(callDepth, viaLogIt, 2, call.dispatch(callDepth, viaLogIt, 2)) == nil
+}
+
+// levelEnabledIn reports whether a message at the given level, logged under the namespace, is recorded
+// by the log creator.
+func (l *Logtor) levelEnabledIn(level types.LogLevel, logCreator LogCreator, namespace string) bool {
+	if selected, ok := l.creatorRank(logCreator); ok {
+		return rankEnabled(level, selected)
+	}
+	if namespaces := l.namespaceRanks.Load(); namespaces != nil {
+		if selected, ok := namespaces.rankOf(namespace); ok {
+			return rankEnabled(level, selected)
+		}
+	}
+	return l.IsLevelEnabled(level)
+}
+
+// namespaceRanks is a snapshot of the namespace levels for the logging path, with the ranks resolved for
+// the callers seen so far. A new snapshot, with an empty cache, is published whenever the levels change.
+type namespaceRanks struct {
+	ranks        map[string]int32
+	callers      atomic.Pointer[map[uintptr]resolvedCaller]
+	callersMutex sync.Mutex
+}
+
+// resolvedCaller is the cached resolution of a program counter.
+type resolvedCaller struct {
+	internal bool
+	matched  bool
+	rank     int32
+}
+
+// rebuildNamespaceRanks publishes a snapshot of namespaceLevels for the logging path.
+// It must be called with changeMutex held whenever namespaceLevels changes.
+func (l *Logtor) rebuildNamespaceRanks() {
+	if len(l.namespaceLevels) == 0 {
+		l.namespaceRanks.Store(nil)
+		return
+	}
+	snapshot := &namespaceRanks{ranks: make(map[string]int32, len(l.namespaceLevels))}
+	for namespace, level := range l.namespaceLevels {
+		snapshot.ranks[namespace] = levelRank(level)
+	}
+	l.namespaceRanks.Store(snapshot)
+}
+
+// rankOf returns the rank of the longest namespace matching the package path.
+func (nr *namespaceRanks) rankOf(packagePath string) (int32, bool) {
+	for path := packagePath; path != ""; {
+		if rank, ok := nr.ranks[path]; ok {
+			return rank, true
+		}
+		slash := strings.LastIndexByte(path, '/')
+		if slash < 0 {
+			break
+		}
+		path = path[:slash]
+	}
+	return 0, false
+}
+
+// callerRank returns the rank of the namespace of the first caller outside this package, starting the
+// search after skipping the given number of frames, as counted by runtime.Callers.
+func (nr *namespaceRanks) callerRank(skip int) (int32, bool) {
+	var pcs [1]uintptr
+	for i := 0; i < maxNamespaceFrames; i++ {
+		if runtime.Callers(skip+i, pcs[:]) == 0 {
+			return 0, false
+		}
+		resolved, ok := resolvedCaller{}, false
+		if callers := nr.callers.Load(); callers != nil {
+			resolved, ok = (*callers)[pcs[0]]
+		}
+		if !ok {
+			resolved = nr.resolve(pcs[0])
+		}
+		if !resolved.internal {
+			return resolved.rank, resolved.matched
+		}
+	}
+	return 0, false
+}
+
+// resolve resolves the namespace rank of a program counter and adds it to the cache. The cache is
+// copied on write, as every call site is resolved only once, so lookups take no lock.
+func (nr *namespaceRanks) resolve(pc uintptr) resolvedCaller {
+	frame, _ := runtime.CallersFrames([]uintptr{pc}).Next()
+	packagePath := packageOf(frame.Function)
+	resolved := resolvedCaller{internal: packagePath == logtorPackage}
+	if !resolved.internal {
+		resolved.rank, resolved.matched = nr.rankOf(packagePath)
+	}
+
+	nr.callersMutex.Lock()
+	defer nr.callersMutex.Unlock()
+	var callers map[uintptr]resolvedCaller
+	if current := nr.callers.Load(); current != nil {
+		callers = make(map[uintptr]resolvedCaller, len(*current)+1)
+		for key, value := range *current {
+			callers[key] = value
+		}
+	} else {
+		callers = make(map[uintptr]resolvedCaller, 1)
+	}
+	callers[pc] = resolved
+	nr.callers.Store(&callers)
+	return resolved
+}
+
+// packageOf returns the package path of a fully qualified function name, such as
+// "github.com/acme/app/storage" for "github.com/acme/app/storage.(*Store).Get".
+func packageOf(function string) string {
+	slash := strings.LastIndexByte(function, '/')
+	if dot := strings.IndexByte(function[slash+1:], '.'); dot >= 0 {
+		return function[:slash+1+dot]
+	}
+	return function
+}
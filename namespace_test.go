@@ -0,0 +1,180 @@
+package logtor_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// testNamespace is the package path of the callers in this file.
+const testNamespace = "github.com/Eyup-Devop/logtor_test"
+
+func TestLogtorNamespaceLogLevels(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+
+	if newLogtor.SetLogLevelFor("", types.TRACE) || newLogtor.SetLogLevelFor(testNamespace, "LOUD") {
+		t.Error("empty namespaces and invalid levels should be rejected")
+	}
+	if newLogtor.LogIt(types.DEBUG, "dropped") {
+		t.Error("without namespace levels the global level should apply")
+	}
+
+	newLogtor.SetLogLevelFor(testNamespace, types.TRACE)
+	newLogtor.SetLogLevelFor("github.com/Eyup-Devop", types.ERROR)
+	if !newLogtor.LogIt(types.DEBUG, "namespace") {
+		t.Error("the level of the caller's namespace should apply")
+	}
+	if !newLogtor.LogItOnce("once", types.DEBUG, "once") {
+		t.Error("wrappers should be attributed to their caller")
+	}
+
+	newLogtor.ClearLogLevelFor(testNamespace)
+	if newLogtor.LogIt(types.WARN, "dropped") || !newLogtor.LogIt(types.ERROR, "parent") {
+		t.Error("the parent namespace should apply after clearing")
+	}
+
+	newLogtor.SetCreatorLogLevel("Recorder", types.TRACE)
+	if !newLogtor.LogIt(types.TRACE, "creator") {
+		t.Error("the log creator's level should take precedence")
+	}
+
+	expected := []string{"DEBUG namespace", "DEBUG once", "ERROR parent", "TRACE creator"}
+	if messages := recorder.Messages(); fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("unexpected messages:\n got %q\nwant %q", messages, expected)
+	}
+	if levels := newLogtor.NamespaceLogLevels(); len(levels) != 1 || levels["github.com/Eyup-Devop"] != types.ERROR {
+		t.Errorf("unexpected namespace levels: %v", levels)
+	}
+}
+
+func TestLogtorNamed(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+	newLogtor.SetLogLevelFor("acme/storage", types.TRACE)
+	newLogtor.SetLogLevelFor(testNamespace, types.NONE)
+
+	storage := newLogtor.Named("acme/storage/cache")
+	if storage.Namespace() != "acme/storage/cache" {
+		t.Errorf("unexpected namespace: %s", storage.Namespace())
+	}
+	if !storage.LogIt(types.DEBUG, "cached") || !storage.LogItWithCallDepth(types.TRACE, 2, "evicted") {
+		t.Error("the level of the parent namespace should apply")
+	}
+	if newLogtor.Named("acme/billing").LogIt(types.INFO, "dropped") {
+		t.Error("unmatched namespaces should use the global level")
+	}
+	if newLogtor.LogIt(types.FATAL, "dropped") {
+		t.Error("the caller's package should not apply to a named logger's namespace")
+	}
+	if messages := recorder.Messages(); len(messages) != 2 {
+		t.Errorf("unexpected messages: %q", messages)
+	}
+}
+
+func TestNamedLogtorMarksMessagesAndFollowsParent(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.INFO)
+
+	auth, billing := newLogtor.Named("auth"), newLogtor.Named("billing")
+	auth.LogIt(types.INFO, "login")
+	billing.LogIt(types.INFO, types.LogEntry{Message: "charged", Fields: map[string]interface{}{"amount": 5}})
+	auth.LogIt(types.TRACE, "dropped")
+
+	newLogtor.SetLogLevel(types.ERROR)
+	billing.LogIt(types.INFO, "dropped by the parent level")
+	auth.SetLogLevel(types.TRACE)
+	auth.LogIt(types.TRACE, "token refreshed")
+	auth.ClearLogLevel()
+	auth.LogIt(types.TRACE, "dropped again")
+
+	expected := []string{
+		"INFO login logger=auth",
+		"INFO charged amount=5 logger=billing",
+		"TRACE token refreshed logger=auth",
+	}
+	if messages := recorder.Messages(); fmt.Sprint(messages) != fmt.Sprint(expected) {
+		t.Errorf("unexpected messages:\n got %q\nwant %q", messages, expected)
+	}
+}
+
+func TestNamespaceLogLevelHandlers(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(newRecordingCreator("Recorder"))
+
+	post := func(body string) *httptest.ResponseRecorder {
+		response := httptest.NewRecorder()
+		newLogtor.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/namespace-log-levels", bytes.NewBufferString(body)))
+		return response
+	}
+	if response := post(`{"namespace":"acme/storage","log_level":"LOUD"}`); response.Code != http.StatusBadRequest {
+		t.Errorf("invalid levels should be rejected, got %d", response.Code)
+	}
+	if response := post(`{"log_level":"TRACE"}`); response.Code != http.StatusBadRequest {
+		t.Errorf("a missing namespace should be rejected, got %d", response.Code)
+	}
+	post(`{"namespace":"acme/storage","log_level":"TRACE"}`)
+	post(`{"namespace":"acme/billing","log_level":"ERROR"}`)
+	post(`{"namespace":"acme/billing"}`)
+
+	response := httptest.NewRecorder()
+	newLogtor.ServeHTTP(response, httptest.NewRequest(http.MethodGet, "/namespace-log-levels", nil))
+	var levels []map[string]string
+	if err := json.Unmarshal(response.Body.Bytes(), &levels); err != nil {
+		t.Fatal(err)
+	}
+	if len(levels) != 1 || levels[0]["namespace"] != "acme/storage" || levels[0]["log_level"] != "TRACE" {
+		t.Errorf("unexpected namespace levels: %s", response.Body.String())
+	}
+}
+
+func BenchmarkLogtorLogItWithoutNamespaces(b *testing.B) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(&discardCreator{name: "Discard"})
+	newLogtor.SetLogLevel(types.INFO)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		newLogtor.LogIt(types.INFO, "Example Log Message")
+	}
+}
+
+// BenchmarkLogtorLogItWithNamespaces measures the resolution of the caller's namespace, served from the
+// per program counter cache after the first call.
+func BenchmarkLogtorLogItWithNamespaces(b *testing.B) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(&discardCreator{name: "Discard"})
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.SetLogLevelFor("github.com/acme/app/storage", types.TRACE)
+	newLogtor.SetLogLevelFor(testNamespace, types.INFO)
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		newLogtor.LogIt(types.INFO, "Example Log Message")
+	}
+}
+
+func BenchmarkNamedLogtorLogIt(b *testing.B) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(&discardCreator{name: "Discard"})
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.SetLogLevelFor("github.com/acme/app/storage", types.TRACE)
+	storage := newLogtor.Named("github.com/acme/app/storage")
+
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		storage.LogIt(types.DEBUG, "Example Log Message")
+	}
+}
@@ -0,0 +1,105 @@
+package logtor
+
+import (
+	"os"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// PreLogHook transforms a message before it is passed to a log creator.
+//
+// Hooks run in the order they were added, only for messages whose level is enabled, and each hook
+// receives the message returned by the previous one. A hook must not modify the message it receives,
+// as the caller may still use it; it should return a changed copy instead.
+type PreLogHook func(level types.LogLevel, logMessage interface{}) interface{}
+
+// AddPreLogHooks adds hooks that transform every message before it is passed to a log creator.
+//
+// Parameters:
+//   - hooks: One or more hooks, run after the hooks added earlier. Nil hooks are skipped.
+func (l *Logtor) AddPreLogHooks(hooks ...PreLogHook) {
+	l.changeMutex.Lock()
+	defer l.changeMutex.Unlock()
+	var updated []PreLogHook
+	if current := l.preLogHooks.Load(); current != nil {
+		updated = append(updated, *current...)
+	}
+	for _, hook := range hooks {
+		if hook != nil {
+			updated = append(updated, hook)
+		}
+	}
+	l.preLogHooks.Store(&updated)
+}
+
+// runPreLogHooks merges the global fields into the message, passes it through the pre-log hooks and the
+// Before method of the hooks added with AddHook, redacts it, and returns the result, or false if a hook
+// dropped it.
+func (l *Logtor) runPreLogHooks(level types.LogLevel, logMessage interface{}) (interface{}, bool) {
+	logMessage = l.mergeGlobalFields(logMessage)
+	if hooks := l.preLogHooks.Load(); hooks != nil {
+		for _, hook := range *hooks {
+			logMessage = hook(level, logMessage)
+		}
+	}
+	logMessage, ok := l.runBeforeHooks(level, logMessage)
+	if !ok {
+		return logMessage, false
+	}
+	return l.redact(logMessage), true
+}
+
+// SystemMetaHook returns a PreLogHook stamping the standard deployment metadata on every message.
+//
+// The hook converts each message to a types.LogEntry and sets the "hostname", "pid", "service",
+// "version" and "environment" fields. The hostname and the process ID are looked up once, when the
+// hook is created. Fields already present on a types.LogEntry or a ContextualMessage are kept, with the
+// metadata taking precedence on key collisions.
+//
+// Parameters:
+//   - service: The name of the service.
+//   - version: The version of the service.
+//   - environment: The deployment environment, such as "production".
+//
+// Returns:
+//   - PreLogHook: The hook, to be added with AddPreLogHooks.
+func SystemMetaHook(service, version, environment string) PreLogHook {
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "unknown"
+	}
+	meta := map[string]interface{}{
+		"hostname":    hostname,
+		"pid":         os.Getpid(),
+		"service":     service,
+		"version":     version,
+		"environment": environment,
+	}
+	return func(level types.LogLevel, logMessage interface{}) interface{} {
+		entry := toLogEntry(logMessage, len(meta))
+		for key, value := range meta {
+			entry.Fields[key] = value
+		}
+		return entry
+	}
+}
+
+// toLogEntry converts a message to a types.LogEntry with a fields map of its own, with room for
+// extra fields. The metadata of a types.LogEntry is kept.
+func toLogEntry(logMessage interface{}, extra int) types.LogEntry {
+	var entry types.LogEntry
+	switch typed := logMessage.(type) {
+	case types.LogEntry:
+		entry = typed
+	case ContextualMessage:
+		entry = types.LogEntry{Message: typed.Message, Fields: typed.Fields}
+	default:
+		entry = types.LogEntry{Message: logMessage}
+	}
+	copied := make(map[string]interface{}, len(entry.Fields)+extra)
+	for key, value := range entry.Fields {
+		copied[key] = value
+	}
+	entry.Fields = copied
+	return entry
+}
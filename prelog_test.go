@@ -0,0 +1,107 @@
+package logtor_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestSystemMetaHook(t *testing.T) {
+	hostname, _ := os.Hostname()
+	hook := logtor.SystemMetaHook("orders", "1.2.3", "production")
+
+	entry, ok := hook(types.INFO, "order placed").(types.LogEntry)
+	if !ok {
+		t.Fatal("the hook should return a types.LogEntry")
+	}
+	expected := map[string]interface{}{
+		"hostname":    hostname,
+		"pid":         os.Getpid(),
+		"service":     "orders",
+		"version":     "1.2.3",
+		"environment": "production",
+	}
+	if entry.Message != "order placed" || fmt.Sprint(entry.Fields) != fmt.Sprint(expected) {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+
+	fields := map[string]interface{}{"order_id": 7}
+	entry = hook(types.INFO, types.LogEntry{Message: "shipped", Fields: fields}).(types.LogEntry)
+	if entry.Fields["order_id"] != 7 || entry.Fields["service"] != "orders" {
+		t.Errorf("existing fields should be kept: %+v", entry)
+	}
+	if len(fields) != 1 {
+		t.Errorf("the caller's fields should not be modified: %v", fields)
+	}
+}
+
+func TestLogtorPreLogHooks(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+
+	var calls int
+	newLogtor.AddPreLogHooks(
+		logtor.SystemMetaHook("orders", "1.2.3", "production"),
+		nil,
+		func(level types.LogLevel, logMessage interface{}) interface{} {
+			calls++
+			entry := logMessage.(types.LogEntry)
+			entry.Fields["level"] = string(level)
+			return entry
+		},
+	)
+
+	newLogtor.LogIt(types.INFO, "filtered")
+	newLogtor.NewContextualLogtor(map[string]interface{}{"user": "u-1"}).LogIt(types.ERROR, "payment failed")
+	if calls != 1 {
+		t.Errorf("hooks should only run for enabled levels, ran %d times", calls)
+	}
+	messages := recorder.Messages()
+	if len(messages) != 1 {
+		t.Fatalf("unexpected messages: %v", messages)
+	}
+	for _, part := range []string{"ERROR payment failed", "environment=production", "level=ERROR", "service=orders", "user=u-1"} {
+		if !strings.Contains(messages[0], part) {
+			t.Errorf("message %q is missing %q", messages[0], part)
+		}
+	}
+}
+
+func TestLogEntryMarshalJSON(t *testing.T) {
+	body, err := json.Marshal(types.LogEntry{Message: "order placed", Fields: map[string]interface{}{"order_id": 7}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"message":"order placed","order_id":7}` {
+		t.Errorf("unexpected JSON: %s", body)
+	}
+}
+
+func TestLogtorLogItWithCorrelation(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+
+	if newLogtor.LogItWithCorrelation("req-1", types.INFO, "filtered") {
+		t.Error("filtered levels should not be logged")
+	}
+	fields := map[string]interface{}{"order_id": 7}
+	if !newLogtor.LogItWithCorrelation("req-2", types.ERROR, types.LogEntry{Message: "payment failed", Fields: fields}) {
+		t.Fatal("message not logged")
+	}
+	messages := recorder.Messages()
+	if len(messages) != 1 || messages[0] != "ERROR payment failed correlation_id=req-2 order_id=7" {
+		t.Errorf("unexpected messages: %v", messages)
+	}
+	if len(fields) != 1 {
+		t.Errorf("the caller's fields should not be modified: %v", fields)
+	}
+}
@@ -0,0 +1,166 @@
+package logtor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// rateLimitSummaryInterval is the interval at which a rate-limited level reports the messages it dropped.
+const rateLimitSummaryInterval = 10 * time.Second
+
+// levelLimiter is the token bucket limiting the messages of a log level.
+type levelLimiter struct {
+	mutex      sync.Mutex
+	perSecond  float64
+	burst      float64
+	tokens     float64
+	last       time.Time
+	suppressed int64
+	since      time.Time
+}
+
+// allow takes a token for a message at now and reports whether the message is kept, and whether it is the
+// first message dropped since the last summary, whose summary is then due after rateLimitSummaryInterval.
+func (ll *levelLimiter) allow(now time.Time) (bool, bool) {
+	ll.mutex.Lock()
+	defer ll.mutex.Unlock()
+	if ll.last.IsZero() {
+		ll.tokens = ll.burst
+	} else if elapsed := now.Sub(ll.last); elapsed > 0 {
+		ll.tokens = min(ll.burst, ll.tokens+elapsed.Seconds()*ll.perSecond)
+	}
+	ll.last = now
+	if ll.tokens >= 1 {
+		ll.tokens--
+		return true, false
+	}
+	first := ll.suppressed == 0
+	if first {
+		ll.since = now
+	}
+	ll.suppressed++
+	return false, first
+}
+
+// takeSuppressed returns the number of messages dropped since the last summary and the time they were
+// dropped in at now, and starts over.
+func (ll *levelLimiter) takeSuppressed(now time.Time) (int64, time.Duration) {
+	ll.mutex.Lock()
+	defer ll.mutex.Unlock()
+	suppressed := ll.suppressed
+	ll.suppressed = 0
+	return suppressed, now.Sub(ll.since)
+}
+
+// SetRateLimit limits the rate of the messages logged at a level with a token bucket, so a failing
+// dependency cannot flood the log creators with the same error.
+//
+// The bucket holds up to burst tokens and is refilled with perSecond tokens every second of the Logtor's
+// clock; every message at the level takes a token, and messages arriving while the bucket is empty are
+// dropped without blocking the caller. Dropped messages are counted in the Suppressed statistics of
+// Stats, and 10 seconds of the Logtor's clock after the first dropped message a summary such as
+// "suppressed 4312 ERROR messages in the last 10s" is logged at the same level, even if no further
+// message arrives. Pending summaries are logged when the Logtor is shut down. Rate limiting applies after the log level and
+// sampling, and to every level, FATAL included.
+//
+// Parameters:
+//   - level: The log level to limit.
+//   - perSecond: The number of messages logged per second once the burst is used up.
+//   - burst: The number of messages that can be logged at once.
+//
+// Returns:
+//   - bool: True if the rate limit was set; false if the level is invalid or a count is not positive.
+func (l *Logtor) SetRateLimit(level types.LogLevel, perSecond int, burst int) bool {
+	slot := levelSlot(level)
+	if slot <= 0 || perSecond <= 0 || burst <= 0 {
+		return false
+	}
+	l.limiters[slot].Store(&levelLimiter{perSecond: float64(perSecond), burst: float64(burst)})
+	return true
+}
+
+// ClearRateLimit stops limiting the rate of the messages logged at a level.
+//
+// Parameters:
+//   - level: The log level to stop limiting.
+func (l *Logtor) ClearRateLimit(level types.LogLevel) {
+	if slot := levelSlot(level); slot > 0 {
+		l.limiters[slot].Store(nil)
+	}
+}
+
+// rateLimit reports whether a message at the level is within the rate limit, counting it as suppressed
+// if it is not, and schedules the summary of the suppressed messages when the first one is dropped.
+func (l *Logtor) rateLimit(level types.LogLevel) bool {
+	slot := levelSlot(level)
+	if slot <= 0 {
+		return true
+	}
+	limiter := l.limiters[slot].Load()
+	if limiter == nil {
+		return true
+	}
+	now := l.now()
+	allowed, first := limiter.allow(now)
+	if !allowed {
+		l.suppressedOut[slot].Add(1)
+	}
+	if first {
+		l.schedule(now.Add(rateLimitSummaryInterval), func() { l.logSuppressed(level, limiter) })
+	}
+	return allowed
+}
+
+// logSuppressed logs the summary of the messages dropped by the limiter of the level, if any were.
+func (l *Logtor) logSuppressed(level types.LogLevel, limiter *levelLimiter) {
+	if suppressed, window := limiter.takeSuppressed(l.now()); suppressed > 0 {
+		l.logSummary(level, fmt.Sprintf("suppressed %d %s messages in the last %s", suppressed, level, window.Round(time.Second)))
+	}
+}
+
+// flushSummaries logs the summaries that are pending for rate limiting and deduplication, before the
+// Logtor is shut down.
+func (l *Logtor) flushSummaries() {
+	if d := l.deduplicator.Load(); d != nil {
+		l.logRepeats(d.flush())
+	}
+	for _, level := range types.LogLevels() {
+		if slot := levelSlot(level); slot > 0 {
+			if limiter := l.limiters[slot].Load(); limiter != nil {
+				l.logSuppressed(level, limiter)
+			}
+		}
+	}
+}
+
+// logSummary logs a message produced by the Logtor itself with the log creator for the level, or the
+// fallback chain, bypassing hooks, sampling and rate limiting.
+func (l *Logtor) logSummary(level types.LogLevel, message string) {
+	epoch, ok := l.beginLog()
+	if !ok {
+		return
+	}
+	defer l.endLog(epoch)
+	call := logCall{l: l, level: level, logMessage: message, logCreator: l.creatorFor(level), epoch: epoch}
+	if call.route() != nil {
+		return
+	}
+	call.fallBack(0, true, 1, call.dispatch(0, true, 1))
+}
+
+// suppressedStats returns the number of messages dropped by rate limiting per log level, nil if none were.
+func (l *Logtor) suppressedStats() map[types.LogLevel]int64 {
+	var suppressed map[types.LogLevel]int64
+	for _, level := range types.LogLevels() {
+		if count := l.suppressedOut[levelSlot(level)].Load(); count > 0 {
+			if suppressed == nil {
+				suppressed = make(map[types.LogLevel]int64)
+			}
+			suppressed[level] = count
+		}
+	}
+	return suppressed
+}
@@ -0,0 +1,93 @@
+package logtor_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorSetRateLimit(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetClock(clock)
+	newLogtor.SetLogLevel(types.TRACE)
+
+	if newLogtor.SetRateLimit(types.ERROR, 0, 1) || newLogtor.SetRateLimit(types.ERROR, 1, 0) || newLogtor.SetRateLimit("LOUD", 1, 1) {
+		t.Error("invalid rate limits should be rejected")
+	}
+	if !newLogtor.SetRateLimit(types.ERROR, 2, 5) {
+		t.Fatal("the rate limit should be set")
+	}
+
+	logged := 0
+	for i := 0; i < 100; i++ {
+		if newLogtor.LogIt(types.ERROR, "dependency down") {
+			logged++
+		}
+	}
+	if logged != 5 {
+		t.Errorf("got %d messages logged, want the burst of 5", logged)
+	}
+	newLogtor.LogIt(types.WARN, "not limited")
+
+	clock.Advance(time.Second)
+	if result := newLogtor.LogItAttempt(types.ERROR, "refilled"); !result.Logged {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	newLogtor.LogIt(types.ERROR, "refilled")
+	if result := newLogtor.LogItAttempt(types.ERROR, "limited"); !result.RateLimited || result.Logged {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if suppressed := newLogtor.Stats().Suppressed; len(suppressed) != 1 || suppressed[types.ERROR] != 96 {
+		t.Errorf("unexpected suppressed counts: %v", suppressed)
+	}
+
+	clock.Advance(10 * time.Second)
+	waitFor(t, func() bool { return len(recorder.Messages()) == 5+1+2+1 })
+	newLogtor.LogIt(types.ERROR, "after the flood")
+	messages := recorder.Messages()
+	if len(messages) != 5+1+2+2 {
+		t.Fatalf("unexpected messages: %v", messages)
+	}
+	if summary := messages[8]; !strings.HasPrefix(summary, "ERROR suppressed 96 ERROR messages in the last 11s") {
+		t.Errorf("unexpected summary: %s", summary)
+	}
+	if messages[9] != "ERROR after the flood" {
+		t.Errorf("the message should be logged after the summary: %s", messages[9])
+	}
+
+	newLogtor.ClearRateLimit(types.ERROR)
+	for i := 0; i < 10; i++ {
+		if !newLogtor.LogIt(types.ERROR, i) {
+			t.Error("messages should not be limited once the rate limit is cleared")
+		}
+	}
+}
+
+func TestLogtorSetRateLimitFlushesOnShutdown(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetClock(clock)
+	newLogtor.SetLogLevel(types.TRACE)
+	newLogtor.SetRateLimit(types.WARN, 1, 1)
+
+	for i := 0; i < 4; i++ {
+		newLogtor.LogIt(types.WARN, "disk almost full")
+	}
+	clock.Advance(3 * time.Second)
+	newLogtor.Shutdown()
+	clock.Advance(10 * time.Second)
+
+	expected := "[WARN disk almost full WARN suppressed 3 WARN messages in the last 3s]"
+	if messages := recorder.Messages(); fmt.Sprint(messages) != expected {
+		t.Errorf("the pending summary should be logged once on shutdown: %q", messages)
+	}
+}
@@ -0,0 +1,133 @@
+package logtor
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// RoutingState tells whether the messages of the active log creator go to it or to the fallback chain.
+type RoutingState string
+
+const (
+	// RoutingPrimary means the active log creator is ready and records its messages.
+	RoutingPrimary RoutingState = "primary"
+
+	// RoutingFallback means the active log creator is missing or not ready, so its messages go to the
+	// fallback chain.
+	RoutingFallback RoutingState = "fallback"
+)
+
+// readinessState is the readiness of the active log creator, as last seen by the readiness monitor.
+type readinessState struct {
+	name  types.LogCreatorName
+	ready bool
+}
+
+// readinessMonitor is a running readiness monitor goroutine.
+type readinessMonitor struct {
+	done     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// stop stops the goroutine and waits for it to exit.
+func (m *readinessMonitor) stop() {
+	m.stopOnce.Do(func() { close(m.done) })
+	<-m.stopped
+}
+
+// MonitorReadiness starts a goroutine polling whether the active log creator is ready.
+//
+// While the monitor runs, log calls addressed to the active log creator use the readiness it last saw
+// instead of calling IsReady on every message: once the active log creator stops being ready its messages
+// go to the fallback chain, and once it is ready again they are routed back to it. Every transition is
+// logged at INFO. Log creators other than the active one, such as routed log creators, are still checked
+// on every message. Starting a monitor stops the previous one, and Shutdown stops it.
+//
+// Parameters:
+//   - interval: How often the active log creator is polled. Zero or less means one second.
+//
+// Returns:
+//   - func(): A function that stops the monitor and waits for it to exit. It is safe to call more than once.
+func (l *Logtor) MonitorReadiness(interval time.Duration) func() {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	monitor := &readinessMonitor{done: make(chan struct{}), stopped: make(chan struct{})}
+
+	l.readinessMutex.Lock()
+	if l.readinessMonitor != nil {
+		l.readinessMonitor.stop()
+	}
+	l.readinessMonitor = monitor
+	l.pollReadiness()
+	l.readinessMutex.Unlock()
+
+	go func() {
+		defer close(monitor.stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-monitor.done:
+				return
+			case <-ticker.C:
+				l.pollReadiness()
+			}
+		}
+	}()
+
+	stop := func() {
+		l.readinessMutex.Lock()
+		defer l.readinessMutex.Unlock()
+		monitor.stop()
+		if l.readinessMonitor == monitor {
+			l.readinessMonitor = nil
+			l.readiness.Store(nil)
+		}
+	}
+	l.addShutdownHook(stop)
+	return stop
+}
+
+// pollReadiness records whether the active log creator is ready and logs the transitions.
+func (l *Logtor) pollReadiness() {
+	logCreator := l.currentLogCreator.Load()
+	state := &readinessState{}
+	if logCreator != nil {
+		state.name, state.ready = logCreator.LogName(), logCreator.IsReady()
+	}
+	previous := l.readiness.Swap(state)
+	if previous == nil || previous.name != state.name || previous.ready == state.ready {
+		return
+	}
+	if state.ready {
+		l.LogIt(types.INFO, fmt.Sprintf("logtor: log creator %q is ready again, routing back to it", state.name))
+	} else {
+		l.LogIt(types.INFO, fmt.Sprintf("logtor: log creator %q is not ready, routing to the fallback chain", state.name))
+	}
+}
+
+// creatorReady reports whether a log creator is ready, using the readiness last seen by the readiness
+// monitor for the active log creator, and IsReady otherwise.
+func (l *Logtor) creatorReady(logCreator LogCreator) bool {
+	if state := l.readiness.Load(); state != nil && state.name != "" && state.name == logCreator.LogName() {
+		return state.ready
+	}
+	return logCreator.IsReady()
+}
+
+// RoutingState reports whether the messages of the active log creator go to it or to the fallback chain,
+// as last seen by the readiness monitor if it runs.
+//
+// Returns:
+//   - RoutingState: RoutingPrimary or RoutingFallback.
+func (l *Logtor) RoutingState() RoutingState {
+	if logCreator := l.currentLogCreator.Load(); logCreator != nil && l.creatorReady(logCreator) {
+		return RoutingPrimary
+	}
+	return RoutingFallback
+}
@@ -0,0 +1,67 @@
+package logtor_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// setReady changes the readiness of a recordingCreator while other goroutines poll it.
+func setReady(rc *recordingCreator, ready bool) {
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+	rc.notReady = !ready
+}
+
+// waitForRouting polls the routing state of the Logtor until it matches or a second has passed.
+func waitForRouting(t *testing.T, l *logtor.Logtor, expected logtor.RoutingState) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); l.RoutingState() != expected; time.Sleep(time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("routing state should become %s", expected)
+		}
+	}
+}
+
+func TestLogtorMonitorReadiness(t *testing.T) {
+	broker, fallback := newRecordingCreator("Broker"), newRecordingCreator("Fallback")
+	newLogtor := logtor.New().WithDefaultCreator(fallback)
+	newLogtor.AddLogCreators(broker)
+	newLogtor.ChangeLogCreator("Broker")
+	newLogtor.SetLogLevel(types.INFO)
+
+	stop := newLogtor.MonitorReadiness(time.Millisecond)
+	if state := newLogtor.RoutingState(); state != logtor.RoutingPrimary {
+		t.Errorf("unexpected routing state: %s", state)
+	}
+	setReady(broker, false)
+	waitForRouting(t, newLogtor, logtor.RoutingFallback)
+	newLogtor.LogIt(types.INFO, "while down")
+	setReady(broker, true)
+	waitForRouting(t, newLogtor, logtor.RoutingPrimary)
+	newLogtor.LogIt(types.INFO, "after recovery")
+	recorder := httptest.NewRecorder()
+	newLogtor.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/log-creators/status", nil))
+	if !strings.Contains(recorder.Body.String(), `"name":"Broker","active":true,"ready":true,"routing":"primary"`) {
+		t.Errorf("the status should report the routing state: %s", recorder.Body)
+	}
+	stop()
+	stop()
+
+	expected := `INFO logtor: log creator "Broker" is not ready, routing to the fallback chain|INFO while down`
+	if messages := strings.Join(fallback.Messages(), "|"); messages != expected {
+		t.Errorf("unexpected Fallback messages: %s", messages)
+	}
+	expected = `INFO logtor: log creator "Broker" is ready again, routing back to it|INFO after recovery`
+	if messages := strings.Join(broker.Messages(), "|"); messages != expected {
+		t.Errorf("unexpected Broker messages: %s", messages)
+	}
+
+	newLogtor.MonitorReadiness(time.Millisecond)
+	newLogtor.Shutdown()
+}
@@ -0,0 +1,205 @@
+package logtor
+
+import (
+	"reflect"
+	"strings"
+)
+
+// redactedValue replaces the values of the redacted keys.
+const redactedValue = "[REDACTED]"
+
+// maxRedactDepth bounds the nesting walked for redaction, so cyclic values do not recurse forever.
+const maxRedactDepth = 32
+
+// SetRedactedKeys replaces the values of sensitive keys, such as "password" or "authorization", with
+// "[REDACTED]" in every message before it is passed to the log creators.
+//
+// Map keys, the JSON names of struct fields, or the names of fields without a JSON tag, are matched case
+// insensitively. The walk goes through nested maps, structs, slices, arrays, pointers and interfaces, after
+// the global fields are merged and the hooks ran. Matched values of a string or interface type are
+// replaced with "[REDACTED]", and those of other types with their zero value. Unexported struct fields are
+// left as they are. A message containing no matched key is passed on as it is, and the message passed by
+// the caller is never modified. Without redacted keys, messages are not walked at all.
+//
+// Parameters:
+//   - keys: The keys to redact. They replace any keys set earlier, and no keys stop redaction.
+func (l *Logtor) SetRedactedKeys(keys ...string) {
+	if len(keys) == 0 {
+		l.redactedKeys.Store(nil)
+		return
+	}
+	redacted := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		redacted[strings.ToLower(key)] = struct{}{}
+	}
+	l.redactedKeys.Store(&redacted)
+}
+
+// redact returns the message with the values of the redacted keys replaced, or the message itself if no
+// redacted keys are set or it contains none.
+func (l *Logtor) redact(logMessage interface{}) interface{} {
+	keys := l.redactedKeys.Load()
+	if keys == nil || logMessage == nil {
+		return logMessage
+	}
+	redacted, changed := redactor(*keys).value(reflect.ValueOf(logMessage), 0)
+	if !changed {
+		return logMessage
+	}
+	return redacted.Interface()
+}
+
+// redactor walks values for the keys to redact, lower-cased.
+type redactor map[string]struct{}
+
+// matches reports whether the key is redacted.
+func (r redactor) matches(key string) bool {
+	_, ok := r[strings.ToLower(key)]
+	return ok
+}
+
+// value returns a copy of v with the redacted keys replaced and true, or v and false if it contains none.
+func (r redactor) value(v reflect.Value, depth int) (reflect.Value, bool) {
+	if depth > maxRedactDepth {
+		return v, false
+	}
+	switch v.Kind() {
+	case reflect.Interface:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, changed := r.value(v.Elem(), depth+1)
+		if !changed {
+			return v, false
+		}
+		redacted := reflect.New(v.Type()).Elem()
+		redacted.Set(elem)
+		return redacted, true
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v, false
+		}
+		elem, changed := r.value(v.Elem(), depth+1)
+		if !changed {
+			return v, false
+		}
+		redacted := reflect.New(v.Type().Elem())
+		redacted.Elem().Set(elem)
+		return redacted, true
+	case reflect.Map:
+		return r.mapValue(v, depth)
+	case reflect.Struct:
+		return r.structValue(v, depth)
+	case reflect.Slice:
+		if v.IsNil() || v.Type().Elem().Kind() == reflect.Uint8 {
+			return v, false
+		}
+		return r.elements(v, depth, func() reflect.Value {
+			redacted := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+			reflect.Copy(redacted, v)
+			return redacted
+		})
+	case reflect.Array:
+		return r.elements(v, depth, func() reflect.Value {
+			redacted := reflect.New(v.Type()).Elem()
+			redacted.Set(v)
+			return redacted
+		})
+	}
+	return v, false
+}
+
+// mapValue redacts the values of the matched string keys of a map and walks the others.
+func (r redactor) mapValue(v reflect.Value, depth int) (reflect.Value, bool) {
+	var redacted reflect.Value
+	iter := v.MapRange()
+	for iter.Next() {
+		key := iter.Key()
+		var replaced reflect.Value
+		var changed bool
+		if key.Kind() == reflect.String && r.matches(key.String()) {
+			replaced, changed = redactedFor(v.Type().Elem()), true
+		} else {
+			replaced, changed = r.value(iter.Value(), depth+1)
+		}
+		if !changed {
+			continue
+		}
+		if !redacted.IsValid() {
+			redacted = reflect.MakeMapWithSize(v.Type(), v.Len())
+			copied := v.MapRange()
+			for copied.Next() {
+				redacted.SetMapIndex(copied.Key(), copied.Value())
+			}
+		}
+		redacted.SetMapIndex(key, replaced)
+	}
+	return redacted, redacted.IsValid()
+}
+
+// structValue redacts the matched exported fields of a struct and walks the others.
+func (r redactor) structValue(v reflect.Value, depth int) (reflect.Value, bool) {
+	var redacted reflect.Value
+	structType := v.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		var replaced reflect.Value
+		var changed bool
+		if r.matches(fieldKey(field)) {
+			replaced, changed = redactedFor(field.Type), true
+		} else {
+			replaced, changed = r.value(v.Field(i), depth+1)
+		}
+		if !changed {
+			continue
+		}
+		if !redacted.IsValid() {
+			redacted = reflect.New(structType).Elem()
+			redacted.Set(v)
+		}
+		redacted.Field(i).Set(replaced)
+	}
+	return redacted, redacted.IsValid()
+}
+
+// elements walks the elements of a slice or an array, copying it with copy on the first change.
+func (r redactor) elements(v reflect.Value, depth int, copy func() reflect.Value) (reflect.Value, bool) {
+	var redacted reflect.Value
+	for i := 0; i < v.Len(); i++ {
+		replaced, changed := r.value(v.Index(i), depth+1)
+		if !changed {
+			continue
+		}
+		if !redacted.IsValid() {
+			redacted = copy()
+		}
+		redacted.Index(i).Set(replaced)
+	}
+	return redacted, redacted.IsValid()
+}
+
+// fieldKey returns the JSON name of a struct field, or its name if it has none.
+func fieldKey(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
+
+// redactedFor returns the value replacing a redacted value of type t: "[REDACTED]" where it can be
+// assigned, and the zero value otherwise.
+func redactedFor(t reflect.Type) reflect.Value {
+	value := reflect.ValueOf(redactedValue)
+	if t.Kind() == reflect.String {
+		return value.Convert(t)
+	}
+	if value.Type().AssignableTo(t) {
+		redacted := reflect.New(t).Elem()
+		redacted.Set(value)
+		return redacted
+	}
+	return reflect.Zero(t)
+}
@@ -0,0 +1,88 @@
+package logtor_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// messageCreator keeps the messages passed to it as they are.
+type messageCreator struct {
+	discardCreator
+	messages []interface{}
+}
+
+func (mc *messageCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	mc.messages = append(mc.messages, logMessage)
+	return true
+}
+
+func (mc *messageCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	return mc.LogIt(level, logMessage)
+}
+
+type credentials struct {
+	User     string `json:"user"`
+	Password string `json:"password,omitempty"`
+	PIN      int    `json:"pin"`
+	secret   string
+}
+
+type loginRequest struct {
+	Credentials *credentials
+	Headers     map[string]string        `json:"headers"`
+	Attempts    []map[string]interface{} `json:"attempts"`
+	Token       interface{}
+}
+
+func TestLogtorSetRedactedKeys(t *testing.T) {
+	captured := &messageCreator{discardCreator: discardCreator{name: "Captured"}}
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(captured)
+	newLogtor.SetLogLevel(types.INFO)
+
+	request := &loginRequest{
+		Credentials: &credentials{User: "alice", Password: "hunter2", PIN: 1234, secret: "kept"},
+		Headers:     map[string]string{"Authorization": "Bearer abc", "Accept": "*/*"},
+		Attempts:    []map[string]interface{}{{"ssn": "123-45-6789", "ok": false}},
+		Token:       "t0k3n",
+	}
+	newLogtor.LogIt(types.INFO, request)
+	newLogtor.SetRedactedKeys("password", "AUTHORIZATION", "ssn", "pin", "token")
+	newLogtor.LogIt(types.INFO, request)
+	newLogtor.LogIt(types.INFO, "plain")
+	newLogtor.LogEntry(types.LogEntry{Level: types.INFO, Message: "entry", Fields: map[string]interface{}{"password": "x", "user": "bob"}})
+	newLogtor.SetRedactedKeys()
+	newLogtor.LogIt(types.INFO, request)
+
+	if len(captured.messages) != 5 {
+		t.Fatalf("unexpected messages: %v", captured.messages)
+	}
+	if captured.messages[0] != request || captured.messages[4] != request {
+		t.Error("messages should be passed on as they are without redacted keys")
+	}
+	redacted := captured.messages[1].(*loginRequest)
+	expected := &loginRequest{
+		Credentials: &credentials{User: "alice", Password: "[REDACTED]", secret: "kept"},
+		Headers:     map[string]string{"Authorization": "[REDACTED]", "Accept": "*/*"},
+		Attempts:    []map[string]interface{}{{"ssn": "[REDACTED]", "ok": false}},
+		Token:       "[REDACTED]",
+	}
+	if !reflect.DeepEqual(redacted, expected) {
+		encoded, _ := json.Marshal(redacted)
+		t.Errorf("unexpected redacted message: %s", encoded)
+	}
+	if request.Credentials.Password != "hunter2" || request.Headers["Authorization"] != "Bearer abc" || request.Attempts[0]["ssn"] != "123-45-6789" {
+		t.Error("the message passed by the caller should not be modified")
+	}
+	if captured.messages[2] != "plain" {
+		t.Errorf("messages without redacted keys should be passed on: %v", captured.messages[2])
+	}
+	entry := captured.messages[3].(types.LogEntry)
+	if entry.Fields["password"] != "[REDACTED]" || entry.Fields["user"] != "bob" {
+		t.Errorf("entry fields should be redacted: %v", entry.Fields)
+	}
+}
@@ -0,0 +1,84 @@
+package logtor
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// renderEntry renders a message for a RenderedLogCreator.
+//
+// The depth counts the frames above the caller of renderEntry, so 0 attributes the entry to the Logtor
+// method calling renderEntry. Logtor methods pass the depth at which the log creator itself would have
+// found the caller, keeping the attribution of rendered and legacy log creators the same.
+func (l *Logtor) renderEntry(level types.LogLevel, depth int, logMessage interface{}) types.RenderedEntry {
+	if depth < 0 {
+		depth = 0
+	}
+	entry := types.RenderedEntry{
+		Level: level,
+		Time:  l.now(),
+		Value: logMessage,
+	}
+	var ok bool
+	if _, entry.File, entry.Line, ok = runtime.Caller(depth + 1); !ok {
+		entry.File = "???"
+		entry.Line = 0
+	}
+
+	var message interface{}
+	switch typed := logMessage.(type) {
+	case types.LogEntry:
+		message, entry.Fields = typed.Message, typed.Fields
+	case ContextualMessage:
+		message, entry.Fields = typed.Message, typed.Fields
+	default:
+		message = logMessage
+	}
+	if text, isString := message.(string); isString {
+		entry.Message = text
+	} else {
+		entry.Message = fmt.Sprintf("%+v", message)
+	}
+	return entry
+}
+
+// legacyDepth returns the depth for renderEntry at which a log creator receiving the message through
+// LogItWithCallDepth would have found the caller. A callDepth of zero or less, or the LogIt path,
+// uses the log creator's configured call depth.
+func legacyDepth(logCreator LogCreator, callDepth int) int {
+	if callDepth > 0 {
+		// The log creator resolves runtime.Caller(callDepth - 1) one frame below the Logtor method.
+		return callDepth - 2
+	}
+	// The log creator's LogIt adds a frame on top of its LogItWithCallDepth.
+	return logCreator.CallDepth() - 3
+}
+
+// adjustedDepth returns legacyDepth for the log creator, shifted by the default call depth adjustment
+// when the configured call depth of the log creator is used.
+func (l *Logtor) adjustedDepth(logCreator LogCreator, callDepth int) int {
+	depth := legacyDepth(logCreator, callDepth)
+	if callDepth <= 0 {
+		depth += int(l.callDepthAdjustment.Load())
+	}
+	return depth
+}
+
+// adjustedCallDepth returns the call depth to pass to LogItWithCallDepth of a log creator: callDepth if it
+// is positive or no default call depth adjustment is set, otherwise the configured call depth of the log
+// creator shifted by the adjustment.
+func (l *Logtor) adjustedCallDepth(logCreator LogCreator, callDepth int) int {
+	if adjustment := int(l.callDepthAdjustment.Load()); callDepth <= 0 && adjustment != 0 {
+		return logCreator.CallDepth() + adjustment
+	}
+	return callDepth
+}
+
+// logItCallDepth returns the call depth to pass to LogItWithCallDepth in place of calling LogIt of the
+// log creator, shifted by the default call depth adjustment.
+func logItCallDepth(logCreator LogCreator, adjustment int32) int {
+	// LogItWithCallDepth is called without the frame the log creator's LogIt adds.
+	return logCreator.CallDepth() - 1 + int(adjustment)
+}
@@ -0,0 +1,79 @@
+package logtor_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// legacyCreator hides the LogRendered method of the wrapped log creator, so Logtor passes it the
+// message instead of a rendered entry.
+type legacyCreator struct {
+	logtor.LogCreator
+}
+
+// newRenderFileCreator creates a file creator in dir with a frozen clock and the call depth that
+// attributes entries to the caller of Logtor.
+func newRenderFileCreator(t *testing.T, dir string, name types.LogCreatorName, clock logtor.Clock) (logtor.LogCreator, string) {
+	t.Helper()
+	fileName := filepath.Join(dir, string(name)+".log")
+	logCreator, err := creators.NewFileCreatorWithOptions(fileName, creators.WithName(name), creators.WithCallDepth(4), creators.WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return logCreator, fileName
+}
+
+func TestRenderedEntriesMatchLegacyOutput(t *testing.T) {
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.Local))
+	dir := t.TempDir()
+	rendered, renderedFile := newRenderFileCreator(t, dir, "rendered", clock)
+	legacy, legacyFile := newRenderFileCreator(t, dir, "legacy", clock)
+	if _, ok := rendered.(logtor.RenderedLogCreator); !ok {
+		t.Fatal("file creator should accept rendered entries")
+	}
+
+	logtorInstance := logtor.New()
+	logtorInstance.SetClock(clock)
+	logtorInstance.SetLogLevel(types.TRACE)
+	logtorInstance.AddLogCreators(rendered, legacyCreator{legacy})
+
+	messages := []interface{}{
+		"plain message",
+		struct{ ID int }{ID: 7},
+		types.LogEntry{Message: "entry", Fields: map[string]interface{}{"user": "u-1", "attempt": 2}},
+		logtor.ContextualMessage{Message: "contextual", Fields: map[string]interface{}{"request_id": "r-9"}},
+	}
+	for _, name := range []types.LogCreatorName{"rendered", "legacy"} {
+		logtorInstance.ChangeLogCreator(name)
+		for _, message := range messages {
+			logtorInstance.LogIt(types.INFO, message)
+			logtorInstance.LogItWithCallDepth(types.WARN, 4, message)
+		}
+	}
+	logtorInstance.Shutdown()
+
+	renderedOutput, err := os.ReadFile(renderedFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	legacyOutput, err := os.ReadFile(legacyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(renderedOutput) != string(legacyOutput) {
+		t.Errorf("rendered output differs from legacy output:\n got %s\nwant %s", renderedOutput, legacyOutput)
+	}
+	if !strings.Contains(string(renderedOutput), "render_test.go") {
+		t.Errorf("entries should be attributed to the caller: %s", renderedOutput)
+	}
+	if !strings.Contains(string(renderedOutput), "entry attempt=2 user=u-1\n") {
+		t.Errorf("fields should follow the message: %s", renderedOutput)
+	}
+}
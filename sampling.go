@@ -0,0 +1,133 @@
+package logtor
+
+import (
+	"sync/atomic"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// levelSlots is the number of slots returned by levelSlot for valid log levels, NONE included.
+const levelSlots = types.MaxLogLevels
+
+// levelSampler samples the messages of a log level within one-second windows of the Logtor's clock.
+type levelSampler struct {
+	initial    int64
+	thereafter int64
+	window     atomic.Int64
+	count      atomic.Int64
+}
+
+// allow counts a message in the window of second and reports whether it is kept: the first initial
+// messages of every window are, and after them every thereafter-th message.
+func (ls *levelSampler) allow(second int64) bool {
+	if window := ls.window.Load(); window != second && ls.window.CompareAndSwap(window, second) {
+		ls.count.Store(0)
+	}
+	n := ls.count.Add(1)
+	if n <= ls.initial {
+		return true
+	}
+	return ls.thereafter > 0 && (n-ls.initial)%ls.thereafter == 0
+}
+
+// SetSampling samples the messages logged at a level, for hot paths that would otherwise flood the log
+// creators.
+//
+// Within every second of the Logtor's clock, the first initial messages at the level are logged, and after
+// them only every thereafter-th message; a thereafter of zero drops the rest of the second. Messages
+// dropped by sampling are counted in the Sampled statistics of Stats. The decision takes two atomic
+// operations, and sampling applies after the log level, so filtered messages are not counted. PANIC,
+// FATAL and ERROR messages are exempt from sampling by default; SetSamplingExempt changes the exempt
+// levels.
+//
+// Parameters:
+//   - level: The log level to sample.
+//   - initial: The number of messages logged every second before sampling starts.
+//   - thereafter: The interval at which messages are logged once initial is reached, or zero to drop them.
+//
+// Returns:
+//   - bool: True if sampling was set; false if the level is invalid or exempt, or a count is negative.
+func (l *Logtor) SetSampling(level types.LogLevel, initial int, thereafter int) bool {
+	slot := levelSlot(level)
+	if slot <= 0 || l.samplingExempted(slot) || initial < 0 || thereafter < 0 {
+		return false
+	}
+	l.samplers[slot].Store(&levelSampler{initial: int64(initial), thereafter: int64(thereafter)})
+	return true
+}
+
+// defaultSamplingExempt are the log levels exempt from sampling until SetSamplingExempt is called.
+var defaultSamplingExempt = []types.LogLevel{types.PANIC, types.FATAL, types.ERROR}
+
+// SetSamplingExempt sets the log levels exempt from sampling, replacing the default of PANIC, FATAL and
+// ERROR. Messages at an exempt level are never sampled, even if sampling was set for the level before it
+// became exempt, and SetSampling rejects exempt levels.
+//
+// Parameters:
+//   - levels: The exempt log levels. No levels make every level subject to sampling.
+//
+// Returns:
+//   - bool: True if the exempt levels were set; false if a level is invalid, in which case they are unchanged.
+func (l *Logtor) SetSamplingExempt(levels ...types.LogLevel) bool {
+	var exempt [levelSlots]bool
+	for _, level := range levels {
+		slot := levelSlot(level)
+		if slot <= 0 {
+			return false
+		}
+		exempt[slot] = true
+	}
+	l.samplingExempt.Store(&exempt)
+	return true
+}
+
+// samplingExempted reports whether the log level in the slot is exempt from sampling.
+func (l *Logtor) samplingExempted(slot int) bool {
+	if exempt := l.samplingExempt.Load(); exempt != nil {
+		return exempt[slot]
+	}
+	for _, level := range defaultSamplingExempt {
+		if levelSlot(level) == slot {
+			return true
+		}
+	}
+	return false
+}
+
+// ClearSampling stops sampling the messages logged at a level.
+//
+// Parameters:
+//   - level: The log level to stop sampling.
+func (l *Logtor) ClearSampling(level types.LogLevel) {
+	if slot := levelSlot(level); slot > 0 {
+		l.samplers[slot].Store(nil)
+	}
+}
+
+// sample reports whether a message at the level passes sampling, counting it as dropped if it does not.
+func (l *Logtor) sample(level types.LogLevel) bool {
+	slot := levelSlot(level)
+	if slot <= 0 {
+		return true
+	}
+	sampler := l.samplers[slot].Load()
+	if sampler == nil || l.samplingExempted(slot) || sampler.allow(l.now().Unix()) {
+		return true
+	}
+	l.sampledOut[slot].Add(1)
+	return false
+}
+
+// sampledStats returns the number of messages dropped by sampling per log level, nil if none were.
+func (l *Logtor) sampledStats() map[types.LogLevel]int64 {
+	var sampled map[types.LogLevel]int64
+	for _, level := range types.LogLevels() {
+		if count := l.sampledOut[levelSlot(level)].Load(); count > 0 {
+			if sampled == nil {
+				sampled = make(map[types.LogLevel]int64)
+			}
+			sampled[level] = count
+		}
+	}
+	return sampled
+}
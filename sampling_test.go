@@ -0,0 +1,105 @@
+package logtor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorSetSampling(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetClock(clock)
+	newLogtor.SetLogLevel(types.TRACE)
+
+	if newLogtor.SetSampling(types.ERROR, 1, 0) || newLogtor.SetSampling(types.FATAL, 1, 0) {
+		t.Error("ERROR and FATAL should be exempt from sampling")
+	}
+	if newLogtor.SetSampling(types.TRACE, -1, 0) || newLogtor.SetSampling("LOUD", 1, 1) {
+		t.Error("invalid sampling should be rejected")
+	}
+	if !newLogtor.SetSampling(types.TRACE, 3, 4) {
+		t.Fatal("sampling should be set")
+	}
+
+	logged := 0
+	for i := 0; i < 20; i++ {
+		if newLogtor.LogIt(types.TRACE, i) {
+			logged++
+		}
+	}
+	// The first 3, then every 4th of the remaining 17.
+	if logged != 7 {
+		t.Errorf("got %d messages logged in the first second, want 7", logged)
+	}
+	newLogtor.LogIt(types.ERROR, "never sampled")
+
+	clock.Advance(time.Second)
+	if !newLogtor.LogIt(types.TRACE, "new second") {
+		t.Error("a new second should start a new window")
+	}
+	if result := newLogtor.LogItAttempt(types.TRACE, "second"); !result.Logged {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	newLogtor.LogIt(types.TRACE, "third")
+	if result := newLogtor.LogItAttempt(types.TRACE, "sampled"); !result.Sampled || result.Logged {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if sampled := newLogtor.Stats().Sampled; len(sampled) != 1 || sampled[types.TRACE] != 14 {
+		t.Errorf("unexpected sampled counts: %v", sampled)
+	}
+	newLogtor.ClearSampling(types.TRACE)
+	for i := 0; i < 5; i++ {
+		if !newLogtor.LogIt(types.TRACE, i) {
+			t.Error("messages should not be sampled once sampling is cleared")
+		}
+	}
+	if messages := recorder.Messages(); len(messages) != 7+1+3+5 {
+		t.Errorf("got %d messages", len(messages))
+	}
+}
+
+func TestLogtorSetSamplingExempt(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	clock := logtor.NewFakeClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetClock(clock)
+	newLogtor.SetLogLevel(types.TRACE)
+
+	if newLogtor.SetSamplingExempt(types.FATAL, "LOUD") {
+		t.Error("invalid exempt levels should be rejected")
+	}
+	if newLogtor.SetSampling(types.ERROR, 1, 0) {
+		t.Error("ERROR should stay exempt after a rejected change")
+	}
+	if !newLogtor.SetSamplingExempt(types.FATAL, types.TRACE) {
+		t.Fatal("exempt levels should be set")
+	}
+	if newLogtor.SetSampling(types.TRACE, 1, 0) {
+		t.Error("TRACE should be exempt")
+	}
+	if !newLogtor.SetSampling(types.ERROR, 1, 0) || !newLogtor.SetSampling(types.WARN, 1, 0) {
+		t.Fatal("ERROR and WARN should no longer be exempt")
+	}
+	for i := 0; i < 3; i++ {
+		newLogtor.LogIt(types.ERROR, i)
+	}
+
+	newLogtor.SetSamplingExempt(types.WARN)
+	for i := 0; i < 3; i++ {
+		newLogtor.LogIt(types.WARN, i)
+	}
+
+	if sampled := newLogtor.Stats().Sampled; len(sampled) != 1 || sampled[types.ERROR] != 2 {
+		t.Errorf("unexpected sampled counts: %v", sampled)
+	}
+	if messages := recorder.Messages(); len(messages) != 1+3 {
+		t.Errorf("got %d messages", len(messages))
+	}
+}
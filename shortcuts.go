@@ -0,0 +1,103 @@
+package logtor
+
+import "github.com/Eyup-Devop/logtor/types"
+
+// shortcutCallDepth is the call depth attributing a message to the caller of a level shortcut such as
+// Info: the log creator, LogItWithCallDepth and the shortcut sit between the log creator's
+// runtime.Caller and that caller. The shortcuts must call LogItWithCallDepth directly.
+const shortcutCallDepth = 4
+
+// Trace logs a message at TRACE level, like LogIt.
+//
+// Parameters:
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged, false otherwise.
+func (l *Logtor) Trace(logMessage interface{}) bool {
+	return l.LogItWithCallDepth(types.TRACE, shortcutCallDepth, logMessage)
+}
+
+// Debug logs a message at DEBUG level, like LogIt.
+//
+// Parameters:
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged, false otherwise.
+func (l *Logtor) Debug(logMessage interface{}) bool {
+	return l.LogItWithCallDepth(types.DEBUG, shortcutCallDepth, logMessage)
+}
+
+// Info logs a message at INFO level, like LogIt.
+//
+// Parameters:
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged, false otherwise.
+func (l *Logtor) Info(logMessage interface{}) bool {
+	return l.LogItWithCallDepth(types.INFO, shortcutCallDepth, logMessage)
+}
+
+// Warn logs a message at WARN level, like LogIt.
+//
+// Parameters:
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged, false otherwise.
+func (l *Logtor) Warn(logMessage interface{}) bool {
+	return l.LogItWithCallDepth(types.WARN, shortcutCallDepth, logMessage)
+}
+
+// Error logs a message at ERROR level, like LogIt.
+//
+// Parameters:
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged, false otherwise.
+func (l *Logtor) Error(logMessage interface{}) bool {
+	return l.LogItWithCallDepth(types.ERROR, shortcutCallDepth, logMessage)
+}
+
+// Fatal logs a message at FATAL level, like LogIt, then ends the process if SetExitOnFatal is enabled.
+//
+// Parameters:
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: True if the message was successfully logged, false otherwise. Only returns if the process
+//     is not ended.
+func (l *Logtor) Fatal(logMessage interface{}) bool {
+	logged := l.LogItWithCallDepth(types.FATAL, shortcutCallDepth, logMessage)
+	l.exitIfFatal()
+	return logged
+}
+
+// LogFatal logs a message at FATAL level, like LogIt, then ends the process whether or not SetExitOnFatal
+// is enabled.
+//
+// The log creators are flushed and shut down like FlushOnExit, bounded by the timeout set with
+// SetExitTimeout, and then the exit function set with SetExitFunc is called with a nil signal, or
+// os.Exit(1) without one.
+//
+// Parameters:
+//   - logMessage: The message to be logged, which can be of any type.
+func (l *Logtor) LogFatal(logMessage interface{}) {
+	l.LogItWithCallDepth(types.FATAL, shortcutCallDepth, logMessage)
+	l.exit()
+}
+
+// LogPanic logs a message at PANIC level, like LogIt, then panics with the message.
+//
+// Log creators implementing EntryLogCreator receive the entry with the stack of the calling goroutine in
+// the "stack" field. The log creators are not shut down, so the panic can be recovered.
+//
+// Parameters:
+//   - logMessage: The message to be logged and panicked with, which can be of any type.
+func (l *Logtor) LogPanic(logMessage interface{}) {
+	l.LogItWithCallDepth(types.PANIC, shortcutCallDepth, logMessage)
+	panic(logMessage)
+}
@@ -0,0 +1,148 @@
+package logtor_test
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorLevelShortcutsReportCaller(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = writer
+	defer func() { os.Stderr = stderr }()
+
+	baseCreator, err := creators.NewBaseCreator(creators.Console, 3, 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.TRACE)
+	newLogtor.AddLogCreators(baseCreator)
+
+	shortcuts := []struct {
+		level types.LogLevel
+		log   func(interface{}) bool
+	}{
+		{types.TRACE, newLogtor.Trace},
+		{types.DEBUG, newLogtor.Debug},
+		{types.INFO, newLogtor.Info},
+		{types.WARN, newLogtor.Warn},
+		{types.ERROR, newLogtor.Error},
+		{types.FATAL, newLogtor.Fatal},
+	}
+	for _, shortcut := range shortcuts {
+		if !shortcut.log("shortcut message") {
+			t.Errorf("%s shortcut should log", shortcut.level)
+		}
+	}
+	os.Stderr = stderr
+	writer.Close()
+
+	output, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(output), "\n"), "\n")
+	if len(lines) != len(shortcuts) {
+		t.Fatalf("expected %d lines, got %q", len(shortcuts), output)
+	}
+	for i, line := range lines {
+		if !strings.Contains(line, string(shortcuts[i].level)) || !strings.Contains(line, "shortcuts_test.go:") {
+			t.Errorf("line %d should be attributed to the test file at %s level: %q", i, shortcuts[i].level, line)
+		}
+	}
+}
+
+func TestLogtorFatalExitsWhenEnabled(t *testing.T) {
+	recorder := newRecordingCreator("recorder")
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.AddLogCreators(recorder)
+	var exits []os.Signal
+	newLogtor.SetExitFunc(func(sig os.Signal) { exits = append(exits, sig) })
+
+	newLogtor.Fatal("not exiting")
+	if len(exits) != 0 || recorder.Shutdowns() != 0 {
+		t.Fatalf("Fatal should not exit by default: exits=%v shutdowns=%d", exits, recorder.Shutdowns())
+	}
+
+	newLogtor.SetExitOnFatal(true)
+	if !newLogtor.Fatal("exiting") {
+		t.Error("Fatal should log before exiting")
+	}
+	if len(exits) != 1 || exits[0] != nil {
+		t.Errorf("Fatal should call the exit function with a nil signal: %v", exits)
+	}
+	if recorder.Shutdowns() != 1 {
+		t.Errorf("Fatal should shut down the log creators before exiting: %d", recorder.Shutdowns())
+	}
+
+	newLogtor.Fatalf("exiting %d", 2)
+	if len(exits) != 2 {
+		t.Errorf("Fatalf should exit as well: %v", exits)
+	}
+}
+
+func TestLogtorLogFatalAlwaysExits(t *testing.T) {
+	recorder := newRecordingCreator("recorder")
+	newLogtor := logtor.New()
+	newLogtor.SetLogLevel(types.INFO)
+	newLogtor.AddLogCreators(recorder)
+	var exits []os.Signal
+	newLogtor.SetExitFunc(func(sig os.Signal) { exits = append(exits, sig) })
+
+	newLogtor.LogFatal("exiting")
+	if messages := recorder.Messages(); len(messages) != 1 || messages[0] != "FATAL exiting" {
+		t.Errorf("LogFatal should log before exiting: %q", messages)
+	}
+	if len(exits) != 1 || exits[0] != nil || recorder.Shutdowns() != 1 {
+		t.Errorf("LogFatal should shut down and exit without SetExitOnFatal: exits=%v shutdowns=%d", exits, recorder.Shutdowns())
+	}
+}
+
+func TestLogtorLogPanic(t *testing.T) {
+	entries := &entryCreator{discardCreator: discardCreator{name: "Entries"}}
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(entries, recorder)
+	newLogtor.SetLogLevel(types.FATAL)
+
+	for _, name := range []types.LogCreatorName{"Entries", "Recorder"} {
+		newLogtor.ChangeLogCreator(name)
+		recovered := func() (recovered interface{}) {
+			defer func() { recovered = recover() }()
+			newLogtor.LogPanic("unrecoverable state")
+			return nil
+		}()
+		if recovered != "unrecoverable state" {
+			t.Errorf("LogPanic should panic with the message, got %v", recovered)
+		}
+	}
+
+	if len(entries.entries) != 1 {
+		t.Fatalf("unexpected entries: %+v", entries.entries)
+	}
+	entry := entries.entries[0]
+	stack, _ := entry.Fields["stack"].(string)
+	if entry.Level != types.PANIC || entry.Message != "unrecoverable state" || !strings.Contains(stack, "TestLogtorLogPanic") {
+		t.Errorf("the entry should carry the stack: %+v", entry)
+	}
+	if !strings.HasSuffix(entry.Caller, "shortcuts_test.go") {
+		t.Errorf("the entry should be attributed to the caller of LogPanic: %s", entry.Caller)
+	}
+	if messages := recorder.Messages(); len(messages) != 1 || messages[0] != "PANIC unrecoverable state" {
+		t.Errorf("log creators without LogItEntry should receive the message: %q", messages)
+	}
+	if types.FATAL.IsLogLevelAcceptable(types.PANIC) != true || types.PANIC.IsLogLevelAcceptable(types.FATAL) {
+		t.Error("PANIC should be more severe than FATAL")
+	}
+}
@@ -0,0 +1,89 @@
+package logtor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// ErrSignalsUnsupported is returned by HandleSignals on platforms without user-defined signals.
+var ErrSignalsUnsupported = errors.New("logtor: signal-driven log levels are not supported on this platform")
+
+// handleSignals steps the global log level on every increase or decrease signal until the returned
+// function is called or the Logtor is shut down.
+func (l *Logtor) handleSignals(increase, decrease os.Signal) func() {
+	// Buffered, so signals sent in quick succession are not dropped while a step is being logged.
+	signals := make(chan os.Signal, 8)
+	signal.Notify(signals, increase, decrease)
+
+	done := make(chan struct{})
+	var stopOnce sync.Once
+	stop := func() {
+		stopOnce.Do(func() {
+			signal.Stop(signals)
+			close(done)
+		})
+	}
+	l.addShutdownHook(stop)
+
+	go func() {
+		for {
+			select {
+			case <-done:
+				return
+			case received := <-signals:
+				l.stepLogLevel(received == increase, received.String())
+			}
+		}
+	}()
+	return stop
+}
+
+// severityLadder is the ladder stepped by stepLogLevel. Custom levels are not on it, so signals keep
+// moving between the built-in levels whatever levels are registered.
+var severityLadder = []types.LogLevel{types.FATAL, types.ERROR, types.WARN, types.DEBUG, types.INFO, types.TRACE}
+
+// stepLogLevel moves the global log level one step up or down the severity ladder, clamped at TRACE
+// and FATAL, and logs the change at WARN. The change is logged while the more verbose of the two levels
+// is in effect, so it is recorded whenever that level enables WARN.
+func (l *Logtor) stepLogLevel(increase bool, cause string) {
+	oldLevel := l.LogLevel()
+	rank := levelRank(oldLevel)
+	var newLevel types.LogLevel
+	for _, level := range severityLadder {
+		if increase && levelRank(level) > rank {
+			newLevel = level
+			break
+		} else if !increase && levelRank(level) < rank {
+			newLevel = level
+		}
+	}
+	if newLevel == "" {
+		return
+	}
+	message := fmt.Sprintf("log level %s -> %s on %s", oldLevel, newLevel, cause)
+	if increase {
+		l.SetLogLevel(newLevel)
+		l.LogIt(types.WARN, message)
+	} else {
+		l.LogIt(types.WARN, message)
+		l.SetLogLevel(newLevel)
+	}
+}
+
+// addShutdownHook registers a function that Shutdown calls, or calls it at once if the Logtor has
+// already been shut down.
+func (l *Logtor) addShutdownHook(hook func()) {
+	l.shutdownMutex.Lock()
+	if l.shutDown.Load() {
+		l.shutdownMutex.Unlock()
+		hook()
+		return
+	}
+	l.shutdownHooks = append(l.shutdownHooks, hook)
+	l.shutdownMutex.Unlock()
+}
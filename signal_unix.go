@@ -0,0 +1,33 @@
+//go:build !windows
+
+package logtor
+
+import (
+	"os"
+	"syscall"
+)
+
+// HandleSignals steps the global log level up or down the severity ladder on every signal received, so
+// verbosity can be changed on a live process without an HTTP surface.
+//
+// Each increase signal moves the level one step towards TRACE, and each decrease signal one step towards
+// FATAL, in the order of the built-in levels; a custom level steps to the nearest built-in level. The
+// level is clamped at TRACE and FATAL, and every change is logged at WARN as an audit trail. Signal
+// handling stops when the returned function is called or when the Logtor is shut down.
+//
+// Parameters:
+//   - increase: The signal raising the verbosity, or nil for SIGUSR1.
+//   - decrease: The signal lowering the verbosity, or nil for SIGUSR2.
+//
+// Returns:
+//   - func(): A function that stops handling the signals. It is safe to call more than once.
+//   - error: Always nil on Unix; ErrSignalsUnsupported on Windows.
+func (l *Logtor) HandleSignals(increase, decrease os.Signal) (stop func(), err error) {
+	if increase == nil {
+		increase = syscall.SIGUSR1
+	}
+	if decrease == nil {
+		decrease = syscall.SIGUSR2
+	}
+	return l.handleSignals(increase, decrease), nil
+}
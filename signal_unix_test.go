@@ -0,0 +1,93 @@
+//go:build !windows
+
+package logtor_test
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// waitForLogLevel waits until the global log level of the Logtor becomes the expected level.
+func waitForLogLevel(t *testing.T, l *logtor.Logtor, expected types.LogLevel) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for l.LogLevel() != expected {
+		if time.Now().After(deadline) {
+			t.Fatalf("log level should become %s, got %s", expected, l.LogLevel())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestLogtorHandleSignals(t *testing.T) {
+	recorder := newRecordingCreator("Recorder")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+
+	stop, err := newLogtor.HandleSignals(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	waitForLogLevel(t, newLogtor, types.DEBUG)
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	waitForLogLevel(t, newLogtor, types.WARN)
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	waitForLogLevel(t, newLogtor, types.ERROR)
+
+	messages := recorder.Messages()
+	if len(messages) != 3 || messages[0] != "WARN log level WARN -> DEBUG on user defined signal 1" {
+		t.Errorf("every change should be logged at WARN: %q", messages)
+	}
+}
+
+func TestLogtorHandleSignalsClampsAndStops(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(newRecordingCreator("Recorder"))
+	newLogtor.SetLogLevel(types.TRACE)
+
+	stop, err := newLogtor.HandleSignals(syscall.SIGUSR1, syscall.SIGUSR2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	time.Sleep(50 * time.Millisecond)
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	waitForLogLevel(t, newLogtor, types.INFO)
+
+	newLogtor.SetLogLevel(types.FATAL)
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR2)
+	time.Sleep(50 * time.Millisecond)
+	if level := newLogtor.LogLevel(); level != types.FATAL {
+		t.Errorf("the level should be clamped at FATAL, got %s", level)
+	}
+
+	stop()
+	stop()
+	ignored, err := newLogtor.HandleSignals(syscall.SIGUSR1, syscall.SIGUSR2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newLogtor.Shutdown()
+	ignored()
+
+	// Keeps the signal from reaching its default action once every handler has stopped.
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, syscall.SIGUSR1)
+	defer signal.Stop(received)
+	syscall.Kill(syscall.Getpid(), syscall.SIGUSR1)
+	<-received
+	time.Sleep(50 * time.Millisecond)
+	if level := newLogtor.LogLevel(); level != types.FATAL {
+		t.Errorf("stopped handlers should not change the level, got %s", level)
+	}
+}
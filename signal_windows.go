@@ -0,0 +1,20 @@
+//go:build windows
+
+package logtor
+
+import (
+	"os"
+)
+
+// HandleSignals is not supported on Windows, which has no user-defined signals. It changes nothing.
+//
+// Parameters:
+//   - increase: Ignored.
+//   - decrease: Ignored.
+//
+// Returns:
+//   - func(): A function that does nothing.
+//   - error: ErrSignalsUnsupported.
+func (l *Logtor) HandleSignals(increase, decrease os.Signal) (stop func(), err error) {
+	return func() {}, ErrSignalsUnsupported
+}
@@ -0,0 +1,157 @@
+package logtor
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// NewSlogHandler creates a slog.Handler logging the records of a slog.Logger through a Logtor.
+//
+// The level of a record is mapped to the log level of the same name, with levels below slog.LevelDebug
+// logged as TRACE and levels from slog.LevelError+4 on as FATAL. The attributes of the record and those
+// added with WithAttrs become the fields of a types.LogEntry passed to Logtor.LogEntry, with groups
+// nested as maps, so the entry keeps the time and caller of the record.
+//
+// Parameters:
+//   - l: The Logtor the records are logged through.
+//
+// Returns:
+//   - slog.Handler: The handler, sharing the log creators and log level of the Logtor.
+func NewSlogHandler(l *Logtor) slog.Handler {
+	return &slogHandler{logtor: l}
+}
+
+// slogHandler is the slog.Handler returned by NewSlogHandler. It is immutable: WithAttrs and WithGroup
+// return new handlers.
+type slogHandler struct {
+	logtor *Logtor
+	// attrs are the attributes added with WithAttrs, each with the groups open when it was added.
+	attrs []groupedAttrs
+	// groups are the groups opened with WithGroup, which the attributes of the records belong to.
+	groups []string
+}
+
+// groupedAttrs are attributes added to a slogHandler within a group path.
+type groupedAttrs struct {
+	groups []string
+	attrs  []slog.Attr
+}
+
+// slogLevel maps a slog level to the log level of the same name.
+func slogLevel(level slog.Level) types.LogLevel {
+	switch {
+	case level < slog.LevelDebug:
+		return types.TRACE
+	case level < slog.LevelInfo:
+		return types.DEBUG
+	case level < slog.LevelWarn:
+		return types.INFO
+	case level < slog.LevelError:
+		return types.WARN
+	case level < slog.LevelError+4:
+		return types.ERROR
+	}
+	return types.FATAL
+}
+
+// Enabled reports whether the global log level of the Logtor records messages at the level.
+func (sh *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return sh.logtor.IsLevelEnabled(slogLevel(level))
+}
+
+// Handle logs a record as a types.LogEntry with Logtor.LogEntry.
+func (sh *slogHandler) Handle(_ context.Context, record slog.Record) error {
+	entry := types.LogEntry{
+		Level:     slogLevel(record.Level),
+		Message:   record.Message,
+		Timestamp: record.Time,
+	}
+	if record.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+		entry.Caller, entry.Line = frame.File, frame.Line
+	}
+
+	fields := make(map[string]interface{})
+	for _, added := range sh.attrs {
+		addSlogAttrs(fields, added.groups, added.attrs)
+	}
+	attrs := make([]slog.Attr, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = append(attrs, attr)
+		return true
+	})
+	addSlogAttrs(fields, sh.groups, attrs)
+	if len(fields) > 0 {
+		entry.Fields = fields
+	}
+	sh.logtor.LogEntry(entry)
+	return nil
+}
+
+// WithAttrs returns a handler adding the attributes to every record, within the groups open on the handler.
+func (sh *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return sh
+	}
+	handler := *sh
+	handler.attrs = append(sh.attrs[:len(sh.attrs):len(sh.attrs)], groupedAttrs{groups: sh.groups, attrs: attrs})
+	return &handler
+}
+
+// WithGroup returns a handler nesting the attributes added afterwards, and those of the records, in a group.
+func (sh *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return sh
+	}
+	handler := *sh
+	handler.groups = append(sh.groups[:len(sh.groups):len(sh.groups)], name)
+	return &handler
+}
+
+// addSlogAttrs adds attributes to the fields within a group path, following the slog rules: empty
+// attributes are ignored, groups become nested maps, groups without attributes are omitted and the
+// attributes of a group with an empty key are inlined.
+func addSlogAttrs(fields map[string]interface{}, groups []string, attrs []slog.Attr) {
+	if !hasSlogAttrs(attrs) {
+		return
+	}
+	target := fields
+	for _, group := range groups {
+		nested, ok := target[group].(map[string]interface{})
+		if !ok {
+			nested = make(map[string]interface{})
+			target[group] = nested
+		}
+		target = nested
+	}
+	for _, attr := range attrs {
+		attr.Value = attr.Value.Resolve()
+		switch {
+		case attr.Equal(slog.Attr{}):
+		case attr.Value.Kind() != slog.KindGroup:
+			target[attr.Key] = attr.Value.Any()
+		case attr.Key == "":
+			addSlogAttrs(target, nil, attr.Value.Group())
+		default:
+			addSlogAttrs(target, []string{attr.Key}, attr.Value.Group())
+		}
+	}
+}
+
+// hasSlogAttrs reports whether the attributes contain a non-empty attribute, so a group holding them is kept.
+func hasSlogAttrs(attrs []slog.Attr) bool {
+	for _, attr := range attrs {
+		attr.Value = attr.Value.Resolve()
+		if attr.Equal(slog.Attr{}) {
+			continue
+		}
+		if attr.Value.Kind() == slog.KindGroup && !hasSlogAttrs(attr.Value.Group()) {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package logtor_test
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestSlogHandler(t *testing.T) {
+	memoryCreator := creators.NewMemoryCreator("Memory", 10)
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(memoryCreator)
+	newLogtor.SetLogLevel(types.INFO)
+
+	logger := slog.New(logtor.NewSlogHandler(newLogtor))
+	logger.Info("hello", "key", "val")
+	logger.With("service", "api").WithGroup("request").With("id", 7).Warn("slow", "ms", 250, slog.Group("empty"))
+	logger.WithGroup("unused").Error("failed", slog.Group("", slog.String("inlined", "yes")))
+	logger.Log(context.Background(), slog.LevelError+4, "fatal")
+	if logger.Enabled(context.Background(), slog.Level(-8)) {
+		t.Error("levels below DEBUG should map to TRACE, which is disabled at INFO")
+	}
+
+	entries := memoryCreator.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("unexpected entries: %v", entries)
+	}
+	expected := []string{
+		"INFO hello map[key:val]",
+		"WARN slow map[request:map[id:7 ms:250] service:api]",
+		"ERROR failed map[unused:map[inlined:yes]]",
+		"FATAL fatal map[]",
+	}
+	for i, entry := range entries {
+		if got := fmt.Sprintf("%s %v %v", entry.Level, entry.Message, entry.Fields); got != expected[i] {
+			t.Errorf("entry %d: got %q want %q", i, got, expected[i])
+		}
+	}
+	if !strings.HasSuffix(entries[0].Caller, "slog_test.go") || entries[0].Timestamp.IsZero() {
+		t.Errorf("the entry should keep the caller and time of the record: %+v", entries[0])
+	}
+}
@@ -0,0 +1,181 @@
+package logtor
+
+import (
+	"sync/atomic"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// LogStats is a snapshot of the statistics collected by a Logtor.
+//
+// Fields:
+//   - Creators: The statistics of every registered log creator implementing CreatorStatsReporter, keyed by name.
+//   - Levels: The number of messages recorded per log level, counting a message recorded by several log
+//     creators, as with BroadcastIt and LogItAll, once.
+//   - Sampled: The number of messages dropped by sampling per log level, omitting levels without drops.
+//   - Suppressed: The number of messages dropped by rate limiting per log level, omitting levels without drops.
+//   - Logged: The number of messages each log creator recorded per log level.
+//   - Failed: The number of messages each log creator was passed but did not record, per log level.
+//   - FilteredByLevel: The number of messages skipped because of the log level, per log level.
+//   - NotReady: The number of messages dropped per log creator and log level because the log creator was
+//     not ready and the fallback chain was empty.
+//   - Fallback: The number of messages per log creator and log level that were passed to the fallback
+//     chain because the log creator was not ready.
+//
+// The message counters cover LogIt, LogItWithCallDepth, LogItE, LogItWithCallDepthE, LogEntry,
+// LogItAttempt, LogItContext, the methods of NamedLogtor, BroadcastIt and LogItAll, as well as the
+// summaries logged by rate limiting and deduplication, and omit log creators and log levels without
+// messages. They are updated atomically, without taking the
+// locks of the Logtor.
+type LogStats struct {
+	Creators        map[types.LogCreatorName]map[string]interface{}    `json:"creators"`
+	Levels          map[types.LogLevel]uint64                          `json:"levels,omitempty"`
+	Sampled         map[types.LogLevel]int64                           `json:"sampled,omitempty"`
+	Suppressed      map[types.LogLevel]int64                           `json:"suppressed,omitempty"`
+	Logged          map[types.LogCreatorName]map[types.LogLevel]uint64 `json:"logged,omitempty"`
+	Failed          map[types.LogCreatorName]map[types.LogLevel]uint64 `json:"failed,omitempty"`
+	FilteredByLevel map[types.LogLevel]uint64                          `json:"filtered_by_level,omitempty"`
+	NotReady        map[types.LogCreatorName]map[types.LogLevel]uint64 `json:"not_ready,omitempty"`
+	Fallback        map[types.LogCreatorName]map[types.LogLevel]uint64 `json:"fallback,omitempty"`
+}
+
+// Stats returns a snapshot of the statistics collected by the Logtor and its log creators.
+//
+// Returns:
+//   - LogStats: The statistics snapshot.
+func (l *Logtor) Stats() LogStats {
+	stats := LogStats{
+		Creators:   make(map[types.LogCreatorName]map[string]interface{}),
+		Sampled:    l.sampledStats(),
+		Suppressed: l.suppressedStats(),
+	}
+	stats.Levels = levelCounts(&l.loggedOut)
+	stats.FilteredByLevel = levelCounts(&l.filteredOut)
+	l.messageCounters.Range(func(key, value interface{}) bool {
+		name, counters := key.(types.LogCreatorName), value.(*messageCounters)
+		addCreatorCounts(&stats.Logged, name, &counters.logged)
+		addCreatorCounts(&stats.Failed, name, &counters.failed)
+		addCreatorCounts(&stats.NotReady, name, &counters.notReady)
+		addCreatorCounts(&stats.Fallback, name, &counters.fallback)
+		return true
+	})
+	l.changeMutex.RLock()
+	defer l.changeMutex.RUnlock()
+	for name, logCreator := range l.logCreatorList {
+		if reporter, ok := logCreator.(CreatorStatsReporter); ok {
+			stats.Creators[name] = reporter.CreatorStats()
+		}
+	}
+	return stats
+}
+
+// ResetStats sets the message counters reported by Stats back to zero. The statistics of the log
+// creators implementing CreatorStatsReporter are kept.
+func (l *Logtor) ResetStats() {
+	for slot := range l.loggedOut {
+		l.loggedOut[slot].Store(0)
+		l.filteredOut[slot].Store(0)
+		l.sampledOut[slot].Store(0)
+		l.suppressedOut[slot].Store(0)
+	}
+	l.messageCounters.Range(func(key, value interface{}) bool {
+		l.messageCounters.Delete(key)
+		return true
+	})
+}
+
+// messageCounters counts the messages addressed to a log creator, per log level slot.
+type messageCounters struct {
+	logged   [levelSlots]atomic.Uint64
+	failed   [levelSlots]atomic.Uint64
+	notReady [levelSlots]atomic.Uint64
+	fallback [levelSlots]atomic.Uint64
+}
+
+// countersFor returns the counters of the log creator, creating them on its first message.
+func (l *Logtor) countersFor(logCreator LogCreator) *messageCounters {
+	name := logCreator.LogName()
+	if counters, ok := l.messageCounters.Load(name); ok {
+		return counters.(*messageCounters)
+	}
+	counters, _ := l.messageCounters.LoadOrStore(name, &messageCounters{})
+	return counters.(*messageCounters)
+}
+
+// countFiltered counts a message skipped because of the log level.
+func (l *Logtor) countFiltered(level types.LogLevel) {
+	if slot := levelSlot(level); slot > 0 {
+		l.filteredOut[slot].Add(1)
+	}
+}
+
+// countNotReady counts a message addressed to a log creator that was not ready, as recorded by the
+// default creator if fellBack is true and as dropped otherwise. A missing log creator is not counted.
+func (l *Logtor) countNotReady(logCreator LogCreator, level types.LogLevel, fellBack bool) {
+	slot := levelSlot(level)
+	if isNilCreator(logCreator) || slot <= 0 {
+		return
+	}
+	counters := l.countersFor(logCreator)
+	if fellBack {
+		counters.fallback[slot].Add(1)
+	} else {
+		counters.notReady[slot].Add(1)
+	}
+}
+
+// countLogged counts a message passed to a log creator as recorded or failed and returns logged, so it
+// can wrap the call of the log creator.
+func (l *Logtor) countLogged(logCreator LogCreator, level types.LogLevel, logged bool) bool {
+	if logged {
+		l.countRecorded(level)
+	}
+	return l.countDelivered(logCreator, level, logged)
+}
+
+// countRecorded counts a message recorded by at least one log creator.
+func (l *Logtor) countRecorded(level types.LogLevel) {
+	if slot := levelSlot(level); slot > 0 {
+		l.loggedOut[slot].Add(1)
+	}
+}
+
+// countDelivered counts a message passed to one of the log creators of a broadcast as recorded or failed
+// and returns logged. The message itself is counted by countRecorded.
+func (l *Logtor) countDelivered(logCreator LogCreator, level types.LogLevel, logged bool) bool {
+	if slot := levelSlot(level); slot > 0 {
+		counters := l.countersFor(logCreator)
+		if logged {
+			counters.logged[slot].Add(1)
+		} else {
+			counters.failed[slot].Add(1)
+		}
+	}
+	return logged
+}
+
+// levelCounts returns the non-zero counters per log level, nil if all are zero.
+func levelCounts(counters *[levelSlots]atomic.Uint64) map[types.LogLevel]uint64 {
+	var counts map[types.LogLevel]uint64
+	for _, level := range types.LogLevels() {
+		if count := counters[levelSlot(level)].Load(); count > 0 {
+			if counts == nil {
+				counts = make(map[types.LogLevel]uint64)
+			}
+			counts[level] = count
+		}
+	}
+	return counts
+}
+
+// addCreatorCounts adds the non-zero counters of a log creator to counts, allocating it on first use.
+func addCreatorCounts(counts *map[types.LogCreatorName]map[types.LogLevel]uint64, name types.LogCreatorName, counters *[levelSlots]atomic.Uint64) {
+	levels := levelCounts(counters)
+	if levels == nil {
+		return
+	}
+	if *counts == nil {
+		*counts = make(map[types.LogCreatorName]map[types.LogLevel]uint64)
+	}
+	(*counts)[name] = levels
+}
@@ -0,0 +1,128 @@
+package logtor_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorStatsCountsMessages(t *testing.T) {
+	console, offline := newRecordingCreator("Console"), newRecordingCreator("Offline")
+	offline.notReady = true
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(console, &failingCreator{discardCreator{name: "Failing"}}, offline)
+	newLogtor.ChangeLogCreator("Console")
+	newLogtor.SetLogLevel(types.INFO)
+
+	newLogtor.LogIt(types.INFO, "first")
+	newLogtor.LogIt(types.INFO, "second")
+	newLogtor.LogIt(types.TRACE, "filtered")
+	newLogtor.LogItWithCallDepth(types.ERROR, 0, "third")
+	newLogtor.LogEntry(types.LogEntry{Level: types.TRACE, Message: "filtered entry"})
+	newLogtor.ChangeLogCreator("Failing")
+	newLogtor.LogIt(types.WARN, "failed")
+	newLogtor.ChangeLogCreator("Offline")
+	newLogtor.LogIt(types.INFO, "dropped")
+	newLogtor.WithDefaultCreator(newRecordingCreator("Fallback"))
+	newLogtor.LogItAttempt(types.INFO, "stood in for")
+
+	stats := newLogtor.Stats()
+	expected := logtor.LogStats{
+		Creators: map[types.LogCreatorName]map[string]interface{}{},
+		Levels:   map[types.LogLevel]uint64{types.INFO: 3, types.ERROR: 1},
+		Logged: map[types.LogCreatorName]map[types.LogLevel]uint64{
+			"Console":  {types.INFO: 2, types.ERROR: 1},
+			"Fallback": {types.INFO: 1},
+		},
+		Failed:          map[types.LogCreatorName]map[types.LogLevel]uint64{"Failing": {types.WARN: 1}},
+		FilteredByLevel: map[types.LogLevel]uint64{types.TRACE: 2},
+		NotReady:        map[types.LogCreatorName]map[types.LogLevel]uint64{"Offline": {types.INFO: 1}},
+		Fallback:        map[types.LogCreatorName]map[types.LogLevel]uint64{"Offline": {types.INFO: 1}},
+	}
+	if !reflect.DeepEqual(stats, expected) {
+		t.Errorf("unexpected stats:\n got %+v\nwant %+v", stats, expected)
+	}
+
+	recorder := httptest.NewRecorder()
+	newLogtor.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/stats", nil))
+	if recorder.Code != http.StatusOK || recorder.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("unexpected response: %d %s", recorder.Code, recorder.Body)
+	}
+	var served logtor.LogStats
+	if err := json.Unmarshal(recorder.Body.Bytes(), &served); err != nil || !reflect.DeepEqual(served, expected) {
+		t.Errorf("unexpected served stats: %s", recorder.Body)
+	}
+}
+
+func TestLogtorStatsCountsBroadcastsAndResets(t *testing.T) {
+	console, file := newRecordingCreator("Console"), newRecordingCreator("File")
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(console, file, &failingCreator{discardCreator{name: "Failing"}})
+	newLogtor.SetLogLevel(types.INFO)
+
+	newLogtor.BroadcastIt(types.WARN, "broadcast")
+	newLogtor.LogItAll(types.INFO, "to all")
+	newLogtor.LogIt(types.INFO, "single")
+	newLogtor.LogIt(types.TRACE, "filtered")
+
+	stats := newLogtor.Stats()
+	if expected := map[types.LogLevel]uint64{types.WARN: 1, types.INFO: 2}; !reflect.DeepEqual(stats.Levels, expected) {
+		t.Errorf("a broadcast message should be counted once: %v", stats.Levels)
+	}
+	if expected := map[types.LogLevel]uint64{types.WARN: 1, types.INFO: 2}; !reflect.DeepEqual(stats.Logged["Console"], expected) {
+		t.Errorf("unexpected Console counts: %v", stats.Logged)
+	}
+	if expected := map[types.LogLevel]uint64{types.WARN: 1, types.INFO: 1}; !reflect.DeepEqual(stats.Logged["File"], expected) {
+		t.Errorf("unexpected File counts: %v", stats.Logged)
+	}
+	if expected := map[types.LogLevel]uint64{types.WARN: 1, types.INFO: 1}; !reflect.DeepEqual(stats.Failed["Failing"], expected) {
+		t.Errorf("unexpected Failing counts: %v", stats.Failed)
+	}
+
+	newLogtor.ResetStats()
+	if stats := newLogtor.Stats(); stats.Levels != nil || stats.Logged != nil || stats.Failed != nil || stats.FilteredByLevel != nil {
+		t.Errorf("the counters should be reset: %+v", stats)
+	}
+	newLogtor.LogIt(types.INFO, "after reset")
+	if levels := newLogtor.Stats().Levels; levels[types.INFO] != 1 {
+		t.Errorf("counting should go on after a reset: %v", levels)
+	}
+}
+
+func TestLogtorStatsCountsNamedAndContextMessages(t *testing.T) {
+	console, offline := newRecordingCreator("Console"), newRecordingCreator("Offline")
+	offline.notReady = true
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(console, offline)
+	newLogtor.SetLogLevel(types.INFO)
+	billing := newLogtor.Named("billing")
+	billing.SetLogLevel(types.WARN)
+
+	billing.LogIt(types.WARN, "named")
+	billing.LogIt(types.INFO, "named filtered")
+	newLogtor.LogItContext(context.Background(), types.INFO, "context")
+	newLogtor.LogItContext(context.Background(), types.TRACE, "context filtered")
+	newLogtor.ChangeLogCreator("Offline")
+	billing.LogIt(types.ERROR, "named offline")
+	newLogtor.LogItContext(context.Background(), types.ERROR, "context offline")
+
+	stats := newLogtor.Stats()
+	if expected := map[types.LogLevel]uint64{types.WARN: 1, types.INFO: 1}; !reflect.DeepEqual(stats.Levels, expected) {
+		t.Errorf("unexpected level counts: %v", stats.Levels)
+	}
+	if expected := map[types.LogLevel]uint64{types.WARN: 1, types.INFO: 1}; !reflect.DeepEqual(stats.Logged["Console"], expected) {
+		t.Errorf("unexpected Console counts: %v", stats.Logged)
+	}
+	if expected := map[types.LogLevel]uint64{types.INFO: 1, types.TRACE: 1}; !reflect.DeepEqual(stats.FilteredByLevel, expected) {
+		t.Errorf("unexpected filtered counts: %v", stats.FilteredByLevel)
+	}
+	if expected := map[types.LogLevel]uint64{types.ERROR: 2}; !reflect.DeepEqual(stats.NotReady["Offline"], expected) {
+		t.Errorf("unexpected not ready counts: %v", stats.NotReady)
+	}
+}
@@ -0,0 +1,63 @@
+package logtor
+
+import (
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// stdLogPrefix matches the date and time the standard library logger writes before a message with the
+// log.Ldate, log.Ltime and log.Lmicroseconds flags.
+var stdLogPrefix = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} )?(\d{2}:\d{2}:\d{2}(\.\d{6})? )?`)
+
+// NewStdLogger creates a *log.Logger whose output is logged through a Logtor, for libraries that only
+// accept a standard library logger.
+//
+// Every line written by the logger is logged at the given level with Logtor.LogIt, without the date and
+// time the standard library logger adds if its flags are changed to include them. The logger's own prefix
+// is kept.
+//
+// Parameters:
+//   - l: The Logtor the output is logged through.
+//   - level: The log level of the logged lines.
+//
+// Returns:
+//   - *log.Logger: The standard library logger, created without a prefix or flags.
+func NewStdLogger(l *Logtor, level types.LogLevel) *log.Logger {
+	return log.New(&stdLogWriter{logtor: l, level: level}, "", 0)
+}
+
+// NewStdLoggerWithCallDepth creates a *log.Logger like NewStdLogger, whose lines are logged with
+// Logtor.LogItWithCallDepth at the given call depth.
+//
+// Parameters:
+//   - l: The Logtor the output is logged through.
+//   - level: The log level of the logged lines.
+//   - callDepth: The call depth passed to LogItWithCallDepth, or zero or less for the log creator's configured call depth.
+//
+// Returns:
+//   - *log.Logger: The standard library logger, created without a prefix or flags.
+func NewStdLoggerWithCallDepth(l *Logtor, level types.LogLevel, callDepth int) *log.Logger {
+	return log.New(&stdLogWriter{logtor: l, level: level, callDepth: callDepth}, "", 0)
+}
+
+// stdLogWriter is the io.Writer behind the loggers of NewStdLogger and NewStdLoggerWithCallDepth.
+type stdLogWriter struct {
+	logtor    *Logtor
+	level     types.LogLevel
+	callDepth int
+}
+
+// Write logs a line written by a standard library logger. It always reports the whole line as written,
+// so the logger never fails because of the log level or the state of the Logtor.
+func (sw *stdLogWriter) Write(p []byte) (int, error) {
+	line := stdLogPrefix.ReplaceAllString(strings.TrimSuffix(string(p), "\n"), "")
+	if sw.callDepth > 0 {
+		sw.logtor.LogItWithCallDepth(sw.level, sw.callDepth, line)
+	} else {
+		sw.logtor.LogIt(sw.level, line)
+	}
+	return len(p), nil
+}
@@ -0,0 +1,47 @@
+package logtor_test
+
+import (
+	"log"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestNewStdLogger(t *testing.T) {
+	memoryCreator := creators.NewMemoryCreator("Memory", 10)
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(memoryCreator)
+	newLogtor.SetLogLevel(types.INFO)
+
+	stdLogger := logtor.NewStdLogger(newLogtor, types.WARN)
+	stdLogger.Println("plain line")
+	stdLogger.SetFlags(log.LstdFlags | log.Lmicroseconds)
+	stdLogger.Printf("dated line %d", 2)
+	logtor.NewStdLogger(newLogtor, types.TRACE).Print("filtered")
+
+	entries := memoryCreator.Entries()
+	if len(entries) != 2 {
+		t.Fatalf("unexpected entries: %v", entries)
+	}
+	for i, expected := range []string{"plain line", "dated line 2"} {
+		if entries[i].Message != expected || entries[i].Level != types.WARN {
+			t.Errorf("entry %d: got %s %q want WARN %q", i, entries[i].Level, entries[i].Message, expected)
+		}
+	}
+}
+
+func TestNewStdLoggerWithCallDepth(t *testing.T) {
+	memoryCreator := creators.NewMemoryCreator("Memory", 10)
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(memoryCreator)
+	newLogtor.SetLogLevel(types.INFO)
+
+	logtor.NewStdLoggerWithCallDepth(newLogtor, types.INFO, 6).Print("attributed")
+	entries := memoryCreator.Entries()
+	if len(entries) != 1 || !strings.HasSuffix(entries[0].Caller, "stdlog_test.go") {
+		t.Errorf("the entry should be attributed to the caller of the logger: %+v", entries)
+	}
+}
@@ -0,0 +1,179 @@
+package logtor
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// NewTestCreator creates a new instance of TestCreator, which captures log entries in memory for
+// test assertions.
+//
+// Parameters:
+//   - logName: The name of the log creator.
+//
+// Returns:
+//   - *TestCreator: A pointer to the newly created TestCreator.
+func NewTestCreator(logName types.LogCreatorName) *TestCreator {
+	return &TestCreator{logName: logName, callDepth: 2}
+}
+
+// TestCreator is an implementation of the LogCreator interface that captures every message as a
+// types.LogEntry carrying the level it was logged at, for tests that inspect what was logged.
+//
+// Messages that are not a types.LogEntry or a ContextualMessage are captured as the message of an entry
+// without fields. The fields are deep copied when a message is captured, so later changes to the maps
+// and slices passed to the log call do not change captured entries. Use Logtor.Snapshot or Entries to
+// read the captured entries while the code under test keeps logging.
+type TestCreator struct {
+	mutex     sync.Mutex
+	logName   types.LogCreatorName
+	callDepth int
+	entries   []types.LogEntry
+}
+
+// LogItWithCallDepth captures a message. The call depth is ignored.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - callDepth: The call depth for recording the log entry.
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: Always returns true, indicating the message was captured.
+func (tc *TestCreator) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	entry := toLogEntry(logMessage, 0)
+	entry.Level = level
+	if len(entry.Fields) == 0 {
+		entry.Fields = nil
+	} else {
+		entry.Fields = copyFields(entry.Fields)
+	}
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	tc.entries = append(tc.entries, entry)
+	return true
+}
+
+// LogIt captures a message.
+//
+// Parameters:
+//   - level: The log level for the message (e.g., INFO, DEBUG).
+//   - logMessage: The message to be logged, which can be of any type.
+//
+// Returns:
+//   - bool: Always returns true, indicating the message was captured.
+func (tc *TestCreator) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return tc.LogItWithCallDepth(level, tc.callDepth, logMessage)
+}
+
+// Entries returns a deep copy of the entries captured so far.
+//
+// Returns:
+//   - []types.LogEntry: The captured entries, in the order they were logged.
+func (tc *TestCreator) Entries() []types.LogEntry {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	entries := make([]types.LogEntry, len(tc.entries))
+	for i, entry := range tc.entries {
+		entry.Fields = copyFields(entry.Fields)
+		entries[i] = entry
+	}
+	return entries
+}
+
+// Reset discards the entries captured so far.
+func (tc *TestCreator) Reset() {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	tc.entries = nil
+}
+
+// LogName returns the name of the TestCreator.
+//
+// Returns:
+//   - LogCreatorName: The name of the log creator.
+func (tc *TestCreator) LogName() types.LogCreatorName {
+	return tc.logName
+}
+
+// SetCallDepth sets the call depth of the TestCreator.
+//
+// Parameters:
+//   - callDepth: The call depth to set.
+func (tc *TestCreator) SetCallDepth(callDepth int) {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	tc.callDepth = callDepth
+}
+
+// CallDepth returns the call depth of the TestCreator.
+//
+// Returns:
+//   - int: The call depth.
+func (tc *TestCreator) CallDepth() int {
+	tc.mutex.Lock()
+	defer tc.mutex.Unlock()
+	return tc.callDepth
+}
+
+// IsReady reports whether the TestCreator is ready, which it always is.
+func (tc *TestCreator) IsReady() bool {
+	return true
+}
+
+// Shutdown does nothing; the captured entries stay available.
+func (tc *TestCreator) Shutdown() {}
+
+// Snapshot returns a point-in-time copy of the entries captured by the registered TestCreator.
+//
+// The entries are deep copied under the TestCreator's lock, so a test can inspect them without racing the
+// goroutines that keep logging, and later log calls never change the returned entries. If several
+// TestCreators are registered, the one with the lowest name is used.
+//
+// Returns:
+//   - []types.LogEntry: The captured entries, or nil if no TestCreator is registered.
+func (l *Logtor) Snapshot() []types.LogEntry {
+	l.changeMutex.RLock()
+	var testCreators []*TestCreator
+	for _, logCreator := range l.logCreatorList {
+		if testCreator, ok := logCreator.(*TestCreator); ok {
+			testCreators = append(testCreators, testCreator)
+		}
+	}
+	l.changeMutex.RUnlock()
+	if len(testCreators) == 0 {
+		return nil
+	}
+	sort.Slice(testCreators, func(i, j int) bool { return testCreators[i].logName < testCreators[j].logName })
+	return testCreators[0].Entries()
+}
+
+// copyFields returns a deep copy of the fields, copying nested maps and slices of fields as well.
+func copyFields(fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		copied[key] = copyFieldValue(value)
+	}
+	return copied
+}
+
+func copyFieldValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return copyFields(typed)
+	case []interface{}:
+		copied := make([]interface{}, len(typed))
+		for i, element := range typed {
+			copied[i] = copyFieldValue(element)
+		}
+		return copied
+	case []string:
+		return append([]string(nil), typed...)
+	}
+	return value
+}
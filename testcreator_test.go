@@ -0,0 +1,83 @@
+package logtor_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestLogtorSnapshot(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(newRecordingCreator("Recorder"))
+	if snapshot := newLogtor.Snapshot(); snapshot != nil {
+		t.Errorf("without a TestCreator the snapshot should be nil, got %v", snapshot)
+	}
+
+	testCreator := logtor.NewTestCreator("Test")
+	newLogtor.AddLogCreators(testCreator)
+	newLogtor.ChangeLogCreator("Test")
+	newLogtor.SetLogLevel(types.INFO)
+
+	nested := map[string]interface{}{"region": "eu"}
+	tags := []string{"a", "b"}
+	fields := map[string]interface{}{"job_id": "abc123", "nested": nested, "tags": tags}
+	newLogtor.LogIt(types.INFO, types.LogEntry{Message: "started", Fields: fields})
+	newLogtor.LogIt(types.INFO, "plain")
+
+	snapshot := newLogtor.Snapshot()
+	fields["job_id"] = "changed"
+	nested["region"] = "us"
+	tags[0] = "changed"
+	newLogtor.LogIt(types.INFO, "later")
+
+	if len(snapshot) != 2 {
+		t.Fatalf("unexpected snapshot: %v", snapshot)
+	}
+	if snapshot[0].Fields["job_id"] != "abc123" || snapshot[0].Fields["nested"].(map[string]interface{})["region"] != "eu" ||
+		snapshot[0].Fields["tags"].([]string)[0] != "a" {
+		t.Errorf("captured entries should not change: %v", snapshot[0])
+	}
+	if snapshot[1].Message != "plain" || snapshot[1].Fields != nil {
+		t.Errorf("unexpected plain entry: %v", snapshot[1])
+	}
+
+	snapshot[0].Fields["job_id"] = "mutated"
+	if entries := newLogtor.Snapshot(); len(entries) != 3 || entries[0].Fields["job_id"] != "abc123" {
+		t.Errorf("snapshots should be independent: %v", entries)
+	}
+
+	testCreator.Reset()
+	if entries := testCreator.Entries(); len(entries) != 0 {
+		t.Errorf("Reset should discard entries: %v", entries)
+	}
+}
+
+func TestLogtorSnapshotWhileLogging(t *testing.T) {
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(logtor.NewTestCreator("Test"))
+	newLogtor.SetLogLevel(types.INFO)
+
+	var wg sync.WaitGroup
+	for g := 0; g < 4; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				newLogtor.LogIt(types.INFO, types.LogEntry{Message: "tick", Fields: map[string]interface{}{"i": i}})
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		for _, entry := range newLogtor.Snapshot() {
+			if entry.Message != "tick" {
+				t.Fatalf("unexpected entry: %v", entry)
+			}
+		}
+	}
+	wg.Wait()
+	if entries := newLogtor.Snapshot(); len(entries) != 400 {
+		t.Errorf("expected 400 entries, got %d", len(entries))
+	}
+}
@@ -0,0 +1,168 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogEntry is a log message carrying structured fields.
+//
+// Text based creators render it as the message followed by sorted key=value pairs, and JSON based
+// creators receive the fields next to the message under the "message" key. The level, timestamp and
+// caller are metadata used by Logtor.LogEntry and log creators implementing logtor.LogCreatorV2; they are
+// not part of the rendered message. A LogEntry used as a plain message may leave them unset.
+//
+// Fields:
+//   - Level: The log level of the entry.
+//   - Message: The log message, which can be of any type.
+//   - Timestamp: The time the entry was created.
+//   - Fields: The structured fields attached to the message.
+//   - Caller: The full path of the source file that created the entry.
+//   - Line: The line number in the source file that created the entry.
+type LogEntry struct {
+	Level     LogLevel
+	Message   interface{}
+	Timestamp time.Time
+	Fields    map[string]interface{}
+	Caller    string
+	Line      int
+}
+
+// NewLogEntry creates a new LogEntry stamped with the current time and the caller of NewLogEntry.
+//
+// Parameters:
+//   - level: The log level of the entry.
+//   - message: The log message, which can be of any type.
+//
+// Returns:
+//   - LogEntry: The new entry, without fields.
+func NewLogEntry(level LogLevel, message interface{}) LogEntry {
+	entry := LogEntry{Level: level, Message: message, Timestamp: time.Now()}
+	if _, file, line, ok := runtime.Caller(1); ok {
+		entry.Caller, entry.Line = file, line
+	}
+	return entry
+}
+
+// WithField returns a copy of the entry with the field set. The entry itself is not modified.
+//
+// Parameters:
+//   - key: The name of the field.
+//   - value: The value of the field.
+//
+// Returns:
+//   - LogEntry: The copy carrying the field.
+func (e LogEntry) WithField(key string, value interface{}) LogEntry {
+	fields := make(map[string]interface{}, len(e.Fields)+1)
+	for k, v := range e.Fields {
+		fields[k] = v
+	}
+	fields[key] = value
+	e.Fields = fields
+	return e
+}
+
+// WithFields returns a copy of the entry with the fields set, replacing fields with the same names.
+// The entry itself is not modified.
+//
+// Parameters:
+//   - fields: The fields to set.
+//
+// Returns:
+//   - LogEntry: The copy carrying the fields.
+func (e LogEntry) WithFields(fields map[string]interface{}) LogEntry {
+	merged := make(map[string]interface{}, len(e.Fields)+len(fields))
+	for k, v := range e.Fields {
+		merged[k] = v
+	}
+	for k, v := range fields {
+		merged[k] = v
+	}
+	e.Fields = merged
+	return e
+}
+
+// Rendered returns the entry as a RenderedEntry, using its own level, timestamp and caller.
+//
+// Returns:
+//   - RenderedEntry: The rendered entry, whose value is the LogEntry itself.
+func (e LogEntry) Rendered() RenderedEntry {
+	message, ok := e.Message.(string)
+	if !ok {
+		message = fmt.Sprintf("%+v", e.Message)
+	}
+	return RenderedEntry{
+		Level:   e.Level,
+		Time:    e.Timestamp,
+		File:    e.Caller,
+		Line:    e.Line,
+		Message: message,
+		Fields:  e.Fields,
+		Value:   e,
+	}
+}
+
+// String renders the message followed by its fields as sorted key=value pairs.
+func (e LogEntry) String() string {
+	return renderText(fmt.Sprintf("%+v", e.Message), e.Fields)
+}
+
+// renderText renders a message followed by the fields as sorted key=value pairs.
+func renderText(message string, fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return message
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var builder strings.Builder
+	builder.WriteString(message)
+	for _, key := range keys {
+		fmt.Fprintf(&builder, " %s=%+v", key, fields[key])
+	}
+	return builder.String()
+}
+
+// MarshalJSON renders the fields and the message as a single JSON object.
+func (e LogEntry) MarshalJSON() ([]byte, error) {
+	object := make(map[string]interface{}, len(e.Fields)+1)
+	for key, value := range e.Fields {
+		object[key] = value
+	}
+	object["message"] = e.Message
+	return json.Marshal(object)
+}
+
+// RenderedEntry is a log entry as rendered by Logtor before it is passed to a log creator, so every
+// destination records the same time, caller, message and fields.
+//
+// Fields:
+//   - Level: The log level of the entry.
+//   - Time: The time the entry was logged.
+//   - File: The full path of the source file of the caller.
+//   - Line: The line number of the caller.
+//   - Message: The message rendered with "%+v", without its fields.
+//   - Fields: The structured fields of the entry, nil if it has none.
+//   - Value: The message as it was logged, for destinations that encode it as JSON.
+type RenderedEntry struct {
+	Level   LogLevel
+	Time    time.Time
+	File    string
+	Line    int
+	Message string
+	Fields  map[string]interface{}
+	Value   interface{}
+}
+
+// Text renders the message followed by the fields as sorted key=value pairs, the way text based
+// destinations record it.
+func (e RenderedEntry) Text() string {
+	return renderText(e.Message, e.Fields)
+}
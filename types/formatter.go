@@ -0,0 +1,177 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Formatter renders a log entry into the bytes a log creator writes, so the output format can be chosen
+// independently of the destination.
+//
+// Formatters are passed entries carrying the level, timestamp, caller and fields resolved for the
+// message. The output must not end with a newline; text based creators add one after every entry.
+type Formatter interface {
+	// Format renders the entry.
+	Format(entry LogEntry) ([]byte, error)
+}
+
+// JSONFormatter is a Formatter rendering an entry as a single JSON object.
+//
+// The object holds the fields of the entry next to the "level", "time" (RFC 3339 with nanoseconds),
+// "caller" ("file.go:42") and "message" keys, which take precedence over fields with the same names.
+type JSONFormatter struct{}
+
+// Format renders the entry as a JSON object.
+//
+// Parameters:
+//   - entry: The entry to render.
+//
+// Returns:
+//   - []byte: The JSON object.
+//   - error: An error if the message or a field cannot be encoded.
+func (JSONFormatter) Format(entry LogEntry) ([]byte, error) {
+	object := make(map[string]interface{}, len(entry.Fields)+4)
+	for key, value := range entry.Fields {
+		object[key] = value
+	}
+	object["level"] = entry.Level
+	object["time"] = entry.Timestamp.Format(time.RFC3339Nano)
+	object["caller"] = entryCaller(entry)
+	object["message"] = entry.Message
+	return json.Marshal(object)
+}
+
+// LogfmtFormatter is a Formatter rendering an entry as a logfmt line: "time", "level", "caller" and "msg"
+// pairs followed by the fields of the entry, sorted by key. Values containing spaces, quotes or equals
+// signs are quoted.
+type LogfmtFormatter struct{}
+
+// Format renders the entry as a logfmt line.
+//
+// Parameters:
+//   - entry: The entry to render.
+//
+// Returns:
+//   - []byte: The logfmt line.
+//   - error: Always nil.
+func (LogfmtFormatter) Format(entry LogEntry) ([]byte, error) {
+	var buffer bytes.Buffer
+	appendLogfmtPair(&buffer, "time", entry.Timestamp.Format(time.RFC3339))
+	appendLogfmtPair(&buffer, "level", string(entry.Level))
+	appendLogfmtPair(&buffer, "caller", entryCaller(entry))
+	appendLogfmtPair(&buffer, "msg", fmt.Sprintf("%+v", entry.Message))
+	keys := make([]string, 0, len(entry.Fields))
+	for key := range entry.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		appendLogfmtPair(&buffer, key, fmt.Sprintf("%+v", entry.Fields[key]))
+	}
+	return buffer.Bytes(), nil
+}
+
+func appendLogfmtPair(buffer *bytes.Buffer, key, value string) {
+	if buffer.Len() > 0 {
+		buffer.WriteByte(' ')
+	}
+	buffer.WriteString(key)
+	buffer.WriteByte('=')
+	if value == "" || strings.ContainsAny(value, " =\"\t\n") {
+		value = strconv.Quote(value)
+	}
+	buffer.WriteString(value)
+}
+
+// DefaultTextTemplate is the template of a TextFormatter created with an empty template string. It matches
+// the text format of the file creator.
+const DefaultTextTemplate = "{{printf \"%-5s\" .Level}} : {{.Time}} {{.File}}:{{.Line}}: {{.Text}}"
+
+// NewTextFormatter creates a new instance of TextFormatter, which renders entries with a text/template.
+//
+// The template is executed with the following values:
+//   - .Level: The log level of the entry.
+//   - .Time: The timestamp, formatted as "2006/01/02 15:04:05".
+//   - .Timestamp: The timestamp as a time.Time.
+//   - .File: The base name of the caller's source file.
+//   - .Caller: The full path of the caller's source file.
+//   - .Line: The line number of the caller.
+//   - .Message: The message, rendered with "%+v".
+//   - .Fields: The fields of the entry.
+//   - .Text: The message followed by the fields as sorted key=value pairs.
+//
+// Parameters:
+//   - text: The template, or an empty string for DefaultTextTemplate.
+//
+// Returns:
+//   - *TextFormatter: A pointer to the newly created TextFormatter.
+//   - error: An error if the template cannot be parsed.
+func NewTextFormatter(text string) (*TextFormatter, error) {
+	if text == "" {
+		text = DefaultTextTemplate
+	}
+	parsed, err := template.New("entry").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("types: invalid text formatter template: %w", err)
+	}
+	return &TextFormatter{template: parsed}, nil
+}
+
+// TextFormatter is a Formatter rendering an entry with a configurable text/template. Create it with
+// NewTextFormatter. It is safe for concurrent use.
+type TextFormatter struct {
+	template *template.Template
+}
+
+// textFormatterData holds the values a TextFormatter template is executed with.
+type textFormatterData struct {
+	Level     LogLevel
+	Time      string
+	Timestamp time.Time
+	File      string
+	Caller    string
+	Line      int
+	Message   string
+	Fields    map[string]interface{}
+	Text      string
+}
+
+// Format renders the entry with the template. Trailing newlines are trimmed from the output.
+//
+// Parameters:
+//   - entry: The entry to render.
+//
+// Returns:
+//   - []byte: The rendered entry.
+//   - error: An error if the template cannot be executed for the entry.
+func (tf *TextFormatter) Format(entry LogEntry) ([]byte, error) {
+	message := fmt.Sprintf("%+v", entry.Message)
+	data := textFormatterData{
+		Level:     entry.Level,
+		Time:      entry.Timestamp.Format("2006/01/02 15:04:05"),
+		Timestamp: entry.Timestamp,
+		File:      filepath.Base(entry.Caller),
+		Caller:    entry.Caller,
+		Line:      entry.Line,
+		Message:   message,
+		Fields:    entry.Fields,
+		Text:      renderText(message, entry.Fields),
+	}
+	var buffer bytes.Buffer
+	if err := tf.template.Execute(&buffer, data); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buffer.Bytes(), "\n"), nil
+}
+
+// entryCaller returns the caller of an entry as "file.go:42".
+func entryCaller(entry LogEntry) string {
+	return filepath.Base(entry.Caller) + ":" + strconv.Itoa(entry.Line)
+}
@@ -4,23 +4,36 @@
 //
 // Variables:
 //   - ResetColor: ANSI escape code to reset color.
-//   - NoneColor, FatalColor, ErrorColor, WarnColor, DebugColor, InfoColor, TraceColor:
+//   - NoneColor, PanicColor, FatalColor, ErrorColor, WarnColor, DebugColor, InfoColor, TraceColor:
 //     ANSI escape codes for log level colors.
 //
 // Constants:
-// - LogLevel: Represents different log levels (NONE, FATAL, ERROR, WARN, DEBUG, INFO, TRACE).
+// - LogLevel: Represents different log levels (NONE, PANIC, FATAL, ERROR, WARN, DEBUG, INFO, TRACE).
 // - LogCreatorName: Represents the names of log creators.
 // - Color Codes: ANSI escape codes for log level colors.
 //
 // Functions:
 // - GetColorForLogLevel: Returns the ANSI escape code for the color associated with a log level.
 // - IsLogLevelAcceptable: Checks if a given log level is acceptable based on the selected log level.
+// - RegisterLogLevel: Registers a custom log level ordered among the others by its rank.
 package types
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode"
+)
+
 type LogLevel string
 
 const (
 	NONE  LogLevel = "NONE"
+	PANIC LogLevel = "PANIC"
 	FATAL LogLevel = "FATAL"
 	ERROR LogLevel = "ERROR"
 	WARN  LogLevel = "WARN"
@@ -29,13 +42,166 @@ const (
 	TRACE LogLevel = "TRACE"
 )
 
-var LogLevelList = []LogLevel{NONE, FATAL, ERROR, WARN, DEBUG, INFO, TRACE}
+// LogLevelList lists the known log levels ordered by rank, NONE first. It is regenerated by
+// RegisterLogLevel without synchronization, so it must not be read while levels are registered; use
+// LogLevels to list the levels from goroutines that may run concurrently with a registration.
+var LogLevelList = []LogLevel{NONE, PANIC, FATAL, ERROR, WARN, DEBUG, INFO, TRACE}
+
+// MaxLogLevels is the number of log levels that can be known at once, NONE and the built-in levels included.
+const MaxLogLevels = 32
+
+// levelInfo describes a known log level.
+//
+// Fields:
+//   - rank: The severity rank of the level. A lower rank is more severe, and NONE is 0.
+//   - slot: The position of the level in registration order, stable for the life of the process.
+//   - color: The color of a custom level, empty for the built-in levels, whose colors are variables.
+type levelInfo struct {
+	rank  int
+	slot  int
+	color string
+}
+
+// levelTable is a snapshot of the known log levels.
+//
+// Fields:
+//   - levels: The description of every known level.
+//   - ordered: The known levels ordered by rank, NONE first.
+type levelTable struct {
+	levels  map[LogLevel]levelInfo
+	ordered []LogLevel
+}
+
+var (
+	// levelRegistry is the snapshot of the known log levels. It is replaced, never modified, under
+	// levelRegistryMutex, so readers load it without locking.
+	levelRegistry      atomic.Pointer[levelTable]
+	levelRegistryMutex sync.Mutex
+)
+
+func init() {
+	ranks := []int{0, 50, 100, 200, 300, 400, 500, 600}
+	table := &levelTable{levels: make(map[LogLevel]levelInfo, len(LogLevelList)), ordered: append([]LogLevel(nil), LogLevelList...)}
+	for slot, level := range LogLevelList {
+		table.levels[level] = levelInfo{rank: ranks[slot], slot: slot}
+	}
+	levelRegistry.Store(table)
+}
+
+// LogLevels returns the known log levels ordered by rank, NONE first, including the levels registered
+// with RegisterLogLevel. Unlike LogLevelList, it is safe to call while levels are registered.
+//
+// Returns:
+//   - []LogLevel: A copy of the ordered list of the known levels.
+func LogLevels() []LogLevel {
+	return append([]LogLevel(nil), levelRegistry.Load().ordered...)
+}
+
+// RegisterLogLevel registers a custom log level with the default color. See RegisterLogLevelWithColor.
+//
+// Parameters:
+//   - name: The name of the log level. It is upper-cased.
+//   - rank: The severity rank of the log level.
+//
+// Returns:
+//   - LogLevel: The registered log level.
+//   - error: An error if the log level cannot be registered.
+func RegisterLogLevel(name string, rank int) (LogLevel, error) {
+	return RegisterLogLevelWithColor(name, rank, ResetColor)
+}
+
+// RegisterLogLevelWithColor registers a custom log level, such as SECURITY or AUDIT, ordered among the
+// other levels by its rank.
+//
+// The built-in levels have the ranks PANIC 50, FATAL 100, ERROR 200, WARN 300, DEBUG 400, INFO 500 and TRACE 600,
+// and a lower rank is more severe: a level of rank 250 is recorded whenever WARN or a more verbose level
+// is selected, and a level of rank 700 only when that level itself is selected. Registering the same name
+// with the same rank again returns the registered level, so packages can register the levels they use.
+//
+// Parameters:
+//   - name: The name of the log level, made of letters, digits and '_'. It is upper-cased.
+//   - rank: The severity rank of the log level. It must be positive and not used by another level.
+//   - color: The ANSI escape code used to color the level, see GetColorForLogLevel.
+//
+// Returns:
+//   - LogLevel: The registered log level.
+//   - error: An error if the name is invalid or registered with another rank, the rank is taken, or
+//     MaxLogLevels levels are known.
+func RegisterLogLevelWithColor(name string, rank int, color string) (LogLevel, error) {
+	level := LogLevel(strings.ToUpper(strings.TrimSpace(name)))
+	if level == "" {
+		return "", errors.New("log level name must not be empty")
+	}
+	for _, r := range level {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_' {
+			return "", fmt.Errorf("log level name %q contains invalid character %q", level, r)
+		}
+	}
+	if rank <= 0 {
+		return "", fmt.Errorf("log level %s: rank %d is not positive", level, rank)
+	}
+
+	levelRegistryMutex.Lock()
+	defer levelRegistryMutex.Unlock()
+	current := levelRegistry.Load().levels
+	if info, ok := current[level]; ok {
+		if info.rank != rank {
+			return "", fmt.Errorf("log level %s is already registered with rank %d", level, info.rank)
+		}
+		return level, nil
+	}
+	for other, info := range current {
+		if info.rank == rank {
+			return "", fmt.Errorf("log level %s: rank %d is used by %s", level, rank, other)
+		}
+	}
+	if len(current) >= MaxLogLevels {
+		return "", fmt.Errorf("log level %s: at most %d log levels can be registered", level, MaxLogLevels)
+	}
+
+	levels := make(map[LogLevel]levelInfo, len(current)+1)
+	list := make([]LogLevel, 0, len(current)+1)
+	for known, info := range current {
+		levels[known] = info
+		list = append(list, known)
+	}
+	levels[level] = levelInfo{rank: rank, slot: len(current), color: color}
+	list = append(list, level)
+	sort.Slice(list, func(i, j int) bool { return levels[list[i]].rank < levels[list[j]].rank })
+	levelRegistry.Store(&levelTable{levels: levels, ordered: list})
+	LogLevelList = append([]LogLevel(nil), list...)
+	return level, nil
+}
+
+// LogLevelRank returns the severity rank of a log level, 0 for NONE.
+//
+// Returns:
+//   - int: The rank of the level.
+//   - bool: False if the level is unknown.
+func LogLevelRank(level LogLevel) (int, bool) {
+	info, ok := levelRegistry.Load().levels[level]
+	return info.rank, ok
+}
+
+// LogLevelSlot returns the position of a log level in registration order, NONE being 0 and the built-in
+// levels following in the order of their rank. Unlike the position in LogLevels, it does not change
+// when levels are registered, so it can index per-level tables of MaxLogLevels entries.
+//
+// Returns:
+//   - int: The slot of the level, or -1 if the level is unknown.
+func LogLevelSlot(level LogLevel) int {
+	if info, ok := levelRegistry.Load().levels[level]; ok {
+		return info.slot
+	}
+	return -1
+}
 
 type LogCreatorName string
 
 var (
 	ResetColor = "\033[0m"
 	NoneColor  = "\033[97m"
+	PanicColor = "\033[41m"
 	FatalColor = "\033[31m"
 	ErrorColor = "\033[31m"
 	WarnColor  = "\033[33m"
@@ -46,6 +212,8 @@ var (
 
 func GetColorForLogLevel(level LogLevel) string {
 	switch level {
+	case PANIC:
+		return PanicColor
 	case FATAL:
 		return FatalColor
 	case ERROR:
@@ -58,51 +226,25 @@ func GetColorForLogLevel(level LogLevel) string {
 		return InfoColor
 	case TRACE:
 		return TraceColor
-	default:
-		return ResetColor
 	}
+	if info := levelRegistry.Load().levels[level]; info.color != "" {
+		return info.color
+	}
+	return ResetColor
 }
 
 func IsLogLevelAcceptable(selected, using LogLevel) bool {
-	switch selected {
-	case FATAL:
-		if using == FATAL {
-			return true
-		}
-		return false
-	case ERROR:
-		if using == FATAL || using == ERROR {
-			return true
-		}
-		return false
-	case WARN:
-		if using == FATAL || using == ERROR || using == WARN {
-			return true
-		}
-		return false
-	case DEBUG:
-		if using == FATAL || using == ERROR || using == WARN || using == DEBUG {
-			return true
-		}
-		return false
-	case INFO:
-		if using == FATAL || using == ERROR || using == WARN || using == DEBUG || using == INFO {
-			return true
-		}
-		return false
-	case TRACE:
-		if using == FATAL || using == ERROR || using == WARN || using == DEBUG || using == INFO || using == TRACE {
-			return true
-		}
-		return false
-	default:
+	levels := levelRegistry.Load().levels
+	selectedInfo, ok := levels[selected]
+	if !ok || selectedInfo.rank <= 0 {
 		return false
 	}
+	usingInfo, ok := levels[using]
+	return ok && usingInfo.rank > 0 && usingInfo.rank <= selectedInfo.rank
 }
 
 func (d LogLevel) IsValid() bool {
-	levels := GetLogLevelList()
-	_, ok := levels[d]
+	_, ok := levelRegistry.Load().levels[d]
 
 	return ok
 }
@@ -112,13 +254,81 @@ func (d LogLevel) IsLogLevelAcceptable(level LogLevel) bool {
 }
 
 func GetLogLevelList() map[LogLevel]struct{} {
-	return map[LogLevel]struct{}{
-		FATAL: {},
-		ERROR: {},
-		WARN:  {},
-		DEBUG: {},
-		INFO:  {},
-		TRACE: {},
-		NONE:  {},
+	levels := levelRegistry.Load().levels
+	list := make(map[LogLevel]struct{}, len(levels))
+	for level := range levels {
+		list[level] = struct{}{}
+	}
+	return list
+}
+
+// String returns the name of the log level. Together with Set it makes *LogLevel a flag.Value.
+func (d LogLevel) String() string {
+	return string(d)
+}
+
+// Set parses a log level name case-insensitively, so a *LogLevel can be used with flag.Var.
+//
+// Parameters:
+//   - value: The log level name, e.g. "info" or "WARN".
+//
+// Returns:
+//   - error: An error if the value is not a known log level.
+func (d *LogLevel) Set(value string) error {
+	level := LogLevel(strings.ToUpper(strings.TrimSpace(value)))
+	if !level.IsValid() {
+		return fmt.Errorf("invalid log level %q", value)
+	}
+	*d = level
+	return nil
+}
+
+// LogAttemptResult describes how a single log call was handled.
+//
+// Fields:
+//   - Logged: Whether a log creator recorded the message.
+//   - CreatorUsed: The name of the log creator the message was passed to, empty if none was.
+//   - FilteredByLevel: Whether the message was skipped because of the log level.
+//   - FallbackUsed: Whether the fallback chain was used because the selected log creator was missing, not
+//     ready or failed to log the message. CreatorUsed is then the last log creator of the chain tried.
+//   - Duration: How long the log creator took to record the message.
+//   - Sampled: Whether the message was dropped by the sampling set with Logtor.SetSampling.
+//   - RateLimited: Whether the message was dropped by the rate limit set with Logtor.SetRateLimit.
+//   - FilteredByCreator: Whether the message was skipped by the filter set with Logtor.SetCreatorFilter.
+//   - Deduplicated: Whether the message was dropped as a repeat by the deduplication set with Logtor.SetDedup.
+type LogAttemptResult struct {
+	Logged            bool
+	CreatorUsed       LogCreatorName
+	FilteredByLevel   bool
+	FallbackUsed      bool
+	Duration          time.Duration
+	Sampled           bool
+	RateLimited       bool
+	FilteredByCreator bool
+	Deduplicated      bool
+}
+
+// maxLogCreatorNameLength is the longest name a log creator can be registered under.
+const maxLogCreatorNameLength = 128
+
+// Validate reports whether the name can be used to register a log creator.
+//
+// A valid name is not empty, at most 128 bytes long, and consists of letters, digits, '-', '_' and '.',
+// so it can be used in URLs, configuration files and metric labels without escaping.
+//
+// Returns:
+//   - error: An error describing why the name is invalid, or nil if it is valid.
+func (n LogCreatorName) Validate() error {
+	if n == "" {
+		return errors.New("log creator name must not be empty")
+	}
+	if len(n) > maxLogCreatorNameLength {
+		return fmt.Errorf("log creator name %q is longer than %d bytes", n, maxLogCreatorNameLength)
+	}
+	for _, r := range n {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.' {
+			return fmt.Errorf("log creator name %q contains invalid character %q", n, r)
+		}
 	}
+	return nil
 }
@@ -0,0 +1,116 @@
+package types_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// levelRecorder is a LogCreator that keeps every logged message in memory.
+type levelRecorder struct {
+	mutex    sync.Mutex
+	messages []string
+}
+
+func (lr *levelRecorder) LogIt(level types.LogLevel, logMessage interface{}) bool {
+	return lr.LogItWithCallDepth(level, 2, logMessage)
+}
+
+func (lr *levelRecorder) LogItWithCallDepth(level types.LogLevel, callDepth int, logMessage interface{}) bool {
+	lr.mutex.Lock()
+	defer lr.mutex.Unlock()
+	lr.messages = append(lr.messages, fmt.Sprintf("%s %+v", level, logMessage))
+	return true
+}
+
+func (lr *levelRecorder) LogName() types.LogCreatorName { return "Recorder" }
+
+func (lr *levelRecorder) SetCallDepth(callDepth int) {}
+
+func (lr *levelRecorder) CallDepth() int { return 2 }
+
+func (lr *levelRecorder) IsReady() bool { return true }
+
+func (lr *levelRecorder) Shutdown() {}
+
+func (lr *levelRecorder) Messages() []string {
+	lr.mutex.Lock()
+	defer lr.mutex.Unlock()
+	return append([]string(nil), lr.messages...)
+}
+
+func TestRegisterLogLevel(t *testing.T) {
+	security, err := types.RegisterLogLevelWithColor("security", 250, "\033[91m")
+	if err != nil || security != "SECURITY" {
+		t.Fatalf("got %q, %v", security, err)
+	}
+	verbose, err := types.RegisterLogLevel("VERBOSE", 700)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if level, err := types.RegisterLogLevel("Security", 250); err != nil || level != security {
+		t.Errorf("registering the same level again should return it, got %q, %v", level, err)
+	}
+	for _, conflict := range []struct {
+		name string
+		rank int
+	}{{"SECURITY", 260}, {"WARN", 350}, {"AUDIT", 250}, {"AUDIT", 0}, {"", 800}, {"NOT VALID", 800}} {
+		if _, err := types.RegisterLogLevel(conflict.name, conflict.rank); err == nil {
+			t.Errorf("registering %q with rank %d should fail", conflict.name, conflict.rank)
+		}
+	}
+
+	expected := []types.LogLevel{types.NONE, types.PANIC, types.FATAL, types.ERROR, security, types.WARN, types.DEBUG, types.INFO, types.TRACE, verbose}
+	if fmt.Sprint(types.LogLevels()) != fmt.Sprint(expected) || fmt.Sprint(types.LogLevelList) != fmt.Sprint(expected) {
+		t.Errorf("LogLevels and LogLevelList should be ordered by rank: %v %v", types.LogLevels(), types.LogLevelList)
+	}
+	if !security.IsValid() || !verbose.IsValid() || types.LogLevel("AUDIT").IsValid() {
+		t.Error("registered levels should be valid")
+	}
+	if _, ok := types.GetLogLevelList()[security]; !ok {
+		t.Error("GetLogLevelList should include registered levels")
+	}
+	if !types.WARN.IsLogLevelAcceptable(security) || types.ERROR.IsLogLevelAcceptable(security) {
+		t.Error("SECURITY should be accepted from WARN on")
+	}
+	if types.TRACE.IsLogLevelAcceptable(verbose) || !verbose.IsLogLevelAcceptable(types.TRACE) {
+		t.Error("VERBOSE should only be accepted at VERBOSE")
+	}
+	if types.GetColorForLogLevel(security) != "\033[91m" || types.GetColorForLogLevel(verbose) != types.ResetColor {
+		t.Error("registered levels should use their color")
+	}
+	var parsed types.LogLevel
+	if err := parsed.Set("verbose"); err != nil || parsed != verbose {
+		t.Errorf("Set should parse registered levels, got %q, %v", parsed, err)
+	}
+}
+
+func TestLogtorWithCustomLogLevels(t *testing.T) {
+	security, _ := types.RegisterLogLevel("SECURITY", 250)
+	verbose, _ := types.RegisterLogLevel("VERBOSE", 700)
+	recorder := &levelRecorder{}
+	newLogtor := logtor.New()
+	newLogtor.AddLogCreators(recorder)
+	newLogtor.SetLogLevel(types.WARN)
+
+	newLogtor.LogIt(security, "login failed")
+	newLogtor.LogIt(verbose, "hidden")
+	if !newLogtor.SetLogLevel(verbose) || newLogtor.LogLevel() != verbose {
+		t.Errorf("the log level should be set to VERBOSE, got %s", newLogtor.LogLevel())
+	}
+	newLogtor.LogIt(verbose, "shown")
+	newLogtor.SetLogLevel(types.ERROR)
+	newLogtor.LogIt(security, "filtered")
+
+	if messages := recorder.Messages(); fmt.Sprint(messages) != "[SECURITY login failed VERBOSE shown]" {
+		t.Errorf("custom levels should be filtered by rank: %q", messages)
+	}
+	stats := newLogtor.Stats()
+	if stats.Logged["Recorder"][security] != 1 || stats.Logged["Recorder"][verbose] != 1 || stats.FilteredByLevel[security] != 1 || stats.FilteredByLevel[verbose] != 1 {
+		t.Errorf("custom levels should be counted: %+v %+v", stats.Logged, stats.FilteredByLevel)
+	}
+}
@@ -0,0 +1,179 @@
+package logtor
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// IssueSeverity classifies a ConfigIssue.
+type IssueSeverity string
+
+const (
+	// SeverityError marks an issue that prevents the configuration from being applied.
+	SeverityError IssueSeverity = "error"
+	// SeverityWarning marks an issue that does not prevent the configuration from being applied but is likely a mistake.
+	SeverityWarning IssueSeverity = "warning"
+)
+
+// ConfigIssue describes a single problem found by ValidateConfig.
+//
+// Fields:
+//   - Severity: Whether the issue is an error or a warning.
+//   - Path: The JSON path of the offending value, e.g. "$.creators[1].settings.topic".
+//   - Message: A human readable description of the issue.
+type ConfigIssue struct {
+	Severity IssueSeverity `json:"severity"`
+	Path     string        `json:"path"`
+	Message  string        `json:"message"`
+}
+
+// CreatorValidator checks the settings of a log creator type without constructing it.
+//
+// Validators must not open long-lived resources such as network connections. The Path of the returned
+// issues is the setting key (or empty for the settings as a whole); ValidateConfig prefixes it with the
+// JSON path of the creator.
+type CreatorValidator func(settings map[string]interface{}) []ConfigIssue
+
+var (
+	creatorValidators     = make(map[string]CreatorValidator)
+	creatorValidatorMutex sync.RWMutex
+)
+
+// RegisterCreatorValidator registers a validator for the settings of the given creator type.
+//
+// Creator types without a validator are only checked for being registered.
+//
+// Parameters:
+//   - typeName: The type name referenced by CreatorConfig.Type.
+//   - validator: The function checking the settings.
+//
+// Returns:
+//   - error: An error if the type name is empty, the validator is nil or the type name already has a validator.
+func RegisterCreatorValidator(typeName string, validator CreatorValidator) error {
+	if typeName == "" {
+		return fmt.Errorf("logtor: creator validator type name must not be empty")
+	}
+	if validator == nil {
+		return fmt.Errorf("logtor: creator validator %q must not be nil", typeName)
+	}
+	creatorValidatorMutex.Lock()
+	defer creatorValidatorMutex.Unlock()
+	if _, ok := creatorValidators[typeName]; ok {
+		return fmt.Errorf("logtor: creator validator %q is already registered", typeName)
+	}
+	creatorValidators[typeName] = validator
+	return nil
+}
+
+// ValidateConfig checks a configuration without constructing any log creator.
+//
+// It reports structural problems (invalid levels, missing or duplicated names, unknown types),
+// problems with the creator settings reported by the validators registered with RegisterCreatorValidator,
+// and cross-references (active creator, default creator and routes) to undeclared creators.
+// Unlike ApplyConfig it does not stop at the first problem.
+//
+// Parameters:
+//   - cfg: The configuration to check.
+//
+// Returns:
+//   - []ConfigIssue: The issues found, ordered by their position in the configuration. Empty if the configuration is valid.
+func ValidateConfig(cfg Config) []ConfigIssue {
+	issues := []ConfigIssue{}
+	addIssue := func(severity IssueSeverity, path string, format string, args ...interface{}) {
+		issues = append(issues, ConfigIssue{Severity: severity, Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if cfg.LogLevel != "" && !cfg.LogLevel.IsValid() {
+		addIssue(SeverityError, "$.log_level", "invalid log level %q", cfg.LogLevel)
+	}
+	if len(cfg.Creators) == 0 {
+		addIssue(SeverityWarning, "$.creators", "no creators are declared")
+	}
+
+	names := make(map[types.LogCreatorName]struct{}, len(cfg.Creators))
+	for i, creatorConfig := range cfg.Creators {
+		creatorPath := fmt.Sprintf("$.creators[%d]", i)
+		if creatorConfig.Name == "" {
+			addIssue(SeverityError, creatorPath+".name", "creator has no name")
+		} else if err := creatorConfig.Name.Validate(); err != nil {
+			addIssue(SeverityError, creatorPath+".name", "%v", err)
+		} else if _, ok := names[creatorConfig.Name]; ok {
+			addIssue(SeverityError, creatorPath+".name", "creator %q is declared more than once", creatorConfig.Name)
+		}
+		names[creatorConfig.Name] = struct{}{}
+
+		if !isCreatorTypeRegistered(creatorConfig.Type) {
+			addIssue(SeverityError, creatorPath+".type", "unknown creator type %q", creatorConfig.Type)
+			continue
+		}
+		creatorValidatorMutex.RLock()
+		validator, ok := creatorValidators[creatorConfig.Type]
+		creatorValidatorMutex.RUnlock()
+		if !ok {
+			continue
+		}
+		settings := make(map[string]interface{}, len(creatorConfig.Settings)+1)
+		for k, v := range creatorConfig.Settings {
+			settings[k] = v
+		}
+		settings["name"] = string(creatorConfig.Name)
+		for _, issue := range validator(settings) {
+			if issue.Path == "" {
+				issue.Path = creatorPath + ".settings"
+			} else {
+				issue.Path = creatorPath + ".settings." + issue.Path
+			}
+			issues = append(issues, issue)
+		}
+	}
+
+	isDeclared := func(name types.LogCreatorName) bool {
+		_, ok := names[name]
+		return ok
+	}
+	if cfg.ActiveCreator != "" && !isDeclared(cfg.ActiveCreator) {
+		addIssue(SeverityError, "$.active_creator", "active creator %q is not declared", cfg.ActiveCreator)
+	}
+	if cfg.DefaultCreator != "" && !isDeclared(cfg.DefaultCreator) {
+		addIssue(SeverityError, "$.default_creator", "default creator %q is not declared", cfg.DefaultCreator)
+	}
+	levels := make([]string, 0, len(cfg.Routes))
+	for level := range cfg.Routes {
+		levels = append(levels, string(level))
+	}
+	sort.Strings(levels)
+	for _, level := range levels {
+		logLevel := types.LogLevel(level)
+		routePath := "$.routes." + level
+		if !logLevel.IsValid() || logLevel == types.NONE {
+			addIssue(SeverityError, routePath, "invalid route log level %q", level)
+		}
+		if name := cfg.Routes[logLevel]; !isDeclared(name) {
+			addIssue(SeverityError, routePath, "route targets creator %q which is not declared", name)
+		}
+	}
+	return issues
+}
+
+// ValidateConfigFile reads a JSON configuration file and checks it with ValidateConfig.
+//
+// Parameters:
+//   - path: The path of the configuration file.
+//
+// Returns:
+//   - []ConfigIssue: The issues found. A file that cannot be read or decoded is reported as a single error issue.
+func ValidateConfigFile(path string) []ConfigIssue {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []ConfigIssue{{Severity: SeverityError, Path: "$", Message: err.Error()}}
+	}
+	cfg, err := parseConfig(data)
+	if err != nil {
+		return []ConfigIssue{{Severity: SeverityError, Path: "$", Message: err.Error()}}
+	}
+	return ValidateConfig(cfg)
+}
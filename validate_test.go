@@ -0,0 +1,114 @@
+package logtor_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Eyup-Devop/logtor"
+	_ "github.com/Eyup-Devop/logtor/creators"
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+func TestValidateConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg := logtor.Config{
+		LogLevel:       "LOUD",
+		ActiveCreator:  "Missing",
+		DefaultCreator: "Console",
+		Routes:         map[types.LogLevel]types.LogCreatorName{types.ERROR: "Kafka"},
+		Creators: []logtor.CreatorConfig{
+			{Name: "Console", Type: "console"},
+			{Name: "File", Type: "file", Settings: map[string]interface{}{"file_name": filepath.Join(dir, "missing", "app.log")}},
+			{Name: "Kafka", Type: "broker", Settings: map[string]interface{}{"brokers": []interface{}{"127.0.0.1:9092", "localhost"}, "topic": "app logs"}},
+			{Name: "Console", Type: "smoke-signal"},
+		},
+	}
+
+	issues := logtor.ValidateConfig(cfg)
+	expected := map[string]logtor.IssueSeverity{
+		"$.log_level":                       logtor.SeverityError,
+		"$.creators[1].settings.file_name":  logtor.SeverityError,
+		"$.creators[2].settings.brokers[1]": logtor.SeverityError,
+		"$.creators[2].settings.topic":      logtor.SeverityError,
+		"$.creators[3].name":                logtor.SeverityError,
+		"$.creators[3].type":                logtor.SeverityError,
+		"$.active_creator":                  logtor.SeverityError,
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+	for _, issue := range issues {
+		if severity, ok := expected[issue.Path]; !ok || severity != issue.Severity {
+			t.Errorf("unexpected issue: %+v", issue)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("validation must not create files: %v", entries)
+	}
+
+	valid := logtor.Config{
+		Creators: []logtor.CreatorConfig{
+			{Name: "File", Type: "file", Settings: map[string]interface{}{"file_name": filepath.Join(dir, "app.log")}},
+			{Name: "Kafka", Type: "broker", Settings: map[string]interface{}{"brokers": []interface{}{"127.0.0.1:9092"}, "topic": "app.logs_v1"}},
+		},
+	}
+	issues = logtor.ValidateConfig(valid)
+	if len(issues) != 1 || issues[0].Severity != logtor.SeverityWarning || issues[0].Path != "$.creators[1].settings.topic" {
+		t.Errorf("expected a single topic warning: %+v", issues)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("validation must not create files: %v", entries)
+	}
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logtor.json")
+	if issues := logtor.ValidateConfigFile(path); len(issues) != 1 || issues[0].Path != "$" {
+		t.Errorf("expected a read error: %+v", issues)
+	}
+	writeConfig(t, path, `{"creators":[{"name":"Console","type":"console","settings":{"call_depth":-1}}]}`)
+	issues := logtor.ValidateConfigFile(path)
+	if len(issues) != 1 || issues[0].Path != "$.creators[0].settings" {
+		t.Errorf("expected a call depth error: %+v", issues)
+	}
+}
+
+func TestValidateConfigHandler(t *testing.T) {
+	newLogtor := logtor.New()
+
+	payload := `{"active_creator":"Console","creators":[{"name":"Console","type":"console"}]}`
+	req, err := http.NewRequest("POST", "/", bytes.NewBufferString(payload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rw := httptest.NewRecorder()
+	newLogtor.ValidateConfigHandler(rw, req)
+	if status := rw.Code; status != http.StatusOK {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusOK)
+	}
+	if rw.Body.String() != "[]" {
+		t.Errorf("handler returned unexpected body: got %v want []", rw.Body.String())
+	}
+
+	req, _ = http.NewRequest("POST", "/", bytes.NewBufferString(`{"creators":[],"colour":true}`))
+	rw = httptest.NewRecorder()
+	newLogtor.ValidateConfigHandler(rw, req)
+	if status := rw.Code; status != http.StatusBadRequest {
+		t.Errorf("handler returned wrong status code: got %v want %v",
+			status, http.StatusBadRequest)
+	}
+	var issues []logtor.ConfigIssue
+	if err := json.NewDecoder(rw.Body).Decode(&issues); err != nil {
+		t.Fatalf("handler returned not json data: %v", err)
+	}
+	if len(issues) != 1 || !strings.Contains(issues[0].Message, "colour") {
+		t.Errorf("unexpected issues: %+v", issues)
+	}
+}
@@ -0,0 +1,142 @@
+package logtor
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Eyup-Devop/logtor/types"
+)
+
+// WatchOption configures the behaviour of WatchConfig.
+type WatchOption func(*watchOptions)
+
+type watchOptions struct {
+	pollInterval time.Duration
+	debounce     time.Duration
+}
+
+// WithPollInterval sets how often the configuration file is checked for changes. Defaults to one second.
+func WithPollInterval(interval time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		if interval > 0 {
+			o.pollInterval = interval
+		}
+	}
+}
+
+// WithReloadDebounce sets how long the configuration file must stay unchanged before it is reloaded.
+// This avoids applying a file that is still being written. Defaults to 500 milliseconds.
+func WithReloadDebounce(debounce time.Duration) WatchOption {
+	return func(o *watchOptions) {
+		if debounce >= 0 {
+			o.debounce = debounce
+		}
+	}
+}
+
+// WatchConfig applies the configuration file at path and keeps watching it for changes.
+//
+// The file is polled for modification time and size changes. Once a change has settled for the
+// debounce duration the file is loaded, validated and applied with ApplyConfig, so level changes,
+// new, removed and replaced creators and the active creator take effect without restarting.
+// A file that fails to load or validate is never partially applied; the error is logged at ERROR
+// and the previous configuration stays in effect. Every applied change is logged at INFO.
+//
+// Parameters:
+//   - path: The path of the JSON configuration file.
+//   - opts: Options tuning the poll interval and reload debounce.
+//
+// Returns:
+//   - func(): A function that stops watching the file. It is safe to call more than once.
+//   - error: An error if the initial configuration cannot be loaded or applied.
+func (l *Logtor) WatchConfig(path string, opts ...WatchOption) (func(), error) {
+	options := watchOptions{
+		pollInterval: time.Second,
+		debounce:     500 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := parseConfig(content)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := l.ApplyConfig(cfg); err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	var stopOnce sync.Once
+	stop := func() {
+		stopOnce.Do(func() { close(done) })
+	}
+
+	go func() {
+		ticker := time.NewTicker(options.pollInterval)
+		defer ticker.Stop()
+
+		lastModTime, lastSize := info.ModTime(), info.Size()
+		var pending bool
+		var changedAt time.Time
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+
+			info, err := os.Stat(path)
+			if err != nil {
+				continue
+			}
+			if !info.ModTime().Equal(lastModTime) || info.Size() != lastSize {
+				lastModTime, lastSize = info.ModTime(), info.Size()
+				pending = true
+				changedAt = time.Now()
+				continue
+			}
+			if !pending || time.Since(changedAt) < options.debounce {
+				continue
+			}
+			pending = false
+
+			newContent, err := os.ReadFile(path)
+			if err != nil || bytes.Equal(newContent, content) {
+				continue
+			}
+			content = newContent
+			l.reloadConfig(path, newContent)
+		}
+	}()
+
+	return stop, nil
+}
+
+func (l *Logtor) reloadConfig(path string, content []byte) {
+	cfg, err := parseConfig(content)
+	if err == nil {
+		var changes string
+		changes, err = l.ApplyConfig(cfg)
+		if err == nil {
+			if changes != "" && l.LogCreator() != nil {
+				l.LogIt(types.INFO, fmt.Sprintf("config %s reloaded: %s", path, changes))
+			}
+			return
+		}
+	}
+	if l.LogCreator() != nil {
+		l.LogIt(types.ERROR, fmt.Sprintf("config %s not reloaded: %v", path, err))
+	}
+}